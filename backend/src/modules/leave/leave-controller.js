@@ -84,8 +84,10 @@ const handleUpdateLeaveRequest = asyncHandler(async (req, res) => {
 });
 
 const handleGetUserLeaveHistory = asyncHandler(async (req, res) => {
-    const { id } = req.user;
-    const leaveHistory = await getUserLeaveHistory(id);
+    const { id, roleId } = req.user;
+    // Admins may read another user's history, e.g. for leave reports
+    const userId = roleId === 1 && req.query.userId ? Number(req.query.userId) : id;
+    const leaveHistory = await getUserLeaveHistory(userId);
     res.json({ leaveHistory });
 });
 
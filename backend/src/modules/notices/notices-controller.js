@@ -50,7 +50,9 @@ const handleDeleteNoticeRecipient = asyncHandler(async (req, res) => {
 });
 
 const handleFetchAllNotices = asyncHandler(async (req, res) => {
-  const { id: userId } = req.user;
+  const { id, roleId } = req.user;
+  // Admins may list the notices visible to another user, e.g. for digests
+  const userId = roleId === 1 && req.query.userId ? Number(req.query.userId) : id;
   const notices = await fetchAllNotices(userId);
   res.json({ notices });
 });
@@ -57,11 +57,13 @@ const getStaffDetailById = async (id) => {
             t3.mother_name AS "motherName",
             t3.emergency_phone AS "emergencyPhone",
             t3.current_address AS "currentAddress",
-            t3.permanent_address AS "permanentAddress"
+            t3.permanent_address AS "permanentAddress",
+            t5.name AS department
         FROM users t1
         LEFT JOIN users t2 ON t1.reporter_id = t2.id
         LEFT JOIN user_profiles t3 ON t1.id = t3.user_id
         LEFT JOIN roles t4 ON t1.role_id = t4.id
+        LEFT JOIN departments t5 ON t3.department_id = t5.id
         WHERE t1.id = $1
     `;
     const queryParams = [id];
@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
 	"log"
 	"net/http"
 	"os"
@@ -9,11 +12,13 @@ import (
 	"syscall"
 	"time"
 
-	"student-report-service/internal/client"
 	"student-report-service/internal/config"
 	"student-report-service/internal/handlers"
-	"student-report-service/internal/pdf"
+	"student-report-service/internal/health"
+	"student-report-service/internal/logging"
+	"student-report-service/internal/schedule"
 	"student-report-service/internal/service"
+	"student-report-service/internal/tenant"
 
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
@@ -21,33 +26,72 @@ import (
 )
 
 func main() {
+	configPath := flag.String("config", os.Getenv("CONFIG_FILE"), "YAML configuration file; environment variables override its settings")
+	flag.Parse()
+
 	// Load configuration
-	cfg := config.Load()
-	if err := cfg.Validate(); err != nil {
-		log.Fatalf("Invalid configuration: %v", err)
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
 	// Setup logger
-	logger := setupLogger(cfg.Logging)
+	logger, redactHook := setupLogger(cfg.Logging)
+	redactHook.SetSecrets(cfg.Secrets())
 	logger.Info("Starting Student Report Service")
 
-	// Initialize components
-	nodeClient, err := client.NewNodeJSClient(&cfg.NodeJS, logger)
+	// Initialize one set of components per tenant
+	registry, err := tenant.LoadRegistry(cfg.Tenants.File, cfg)
 	if err != nil {
-		logger.WithError(err).Fatal("Failed to initialize Node.js client")
+		logger.WithError(err).Fatal("Failed to load tenants")
 	}
-	defer nodeClient.Close()
-
-	pdfGenerator, err := pdf.NewGenerator(&cfg.Report)
-	if err != nil {
-		logger.WithError(err).Fatal("Failed to initialize PDF generator")
+	redactHook.SetSecrets(registrySecrets(registry))
+
+	runtimes := make(map[string]*tenantRuntime)
+	services := make(map[string]*service.PDFReportService)
+	managers := make(map[string]*schedule.Manager)
+	var readinessChecks []health.Check
+	for _, t := range registry.Tenants() {
+		runtime, err := startTenant(t, logger)
+		if err != nil {
+			logger.WithError(err).WithField("tenant", t.ID).Fatal("Failed to initialize tenant")
+		}
+		defer runtime.Stop()
+
+		runtimes[t.ID] = runtime
+		services[t.ID] = runtime.service
+		managers[t.ID] = runtime.schedules
+		for _, check := range runtime.service.ReadinessChecks() {
+			check.Name = t.ID + "/" + check.Name
+			readinessChecks = append(readinessChecks, check)
+		}
+		logger.WithFields(logrus.Fields{
+			"tenant":  t.ID,
+			"backend": t.Config.NodeJS.BaseURL,
+		}).Info("Tenant initialized")
 	}
 
-	pdfService := service.NewPDFReportServiceWithConcreteTypes(nodeClient, pdfGenerator, cfg)
-	studentPDFHandler := handlers.NewStudentPDFHandler(pdfService)
+	// Reload the configuration on SIGHUP and when its files change
+	configReloader := newReloader(*configPath, cfg, runtimes, logger, redactHook)
+	configReloader.Start(cfg.Server.ConfigWatchInterval)
+	defer configReloader.Stop()
+
+	studentPDFHandler := handlers.NewStudentPDFHandler(services)
+	studentPDFHandler.SetReloadStatus(configReloader.Status)
+	studentPDFHandler.SetLogger(logger)
+
+	var scheduleHandler *handlers.ScheduleHandler
+	if cfg.Schedule.Enabled {
+		scheduleHandler = handlers.NewScheduleHandler(managers)
+	}
 
 	// Setup router
-	router := setupRouter(studentPDFHandler, logger)
+	router := setupRouter(studentPDFHandler, scheduleHandler, logger)
+
+	// Probes are answered before tenant resolution
+	probeHandler := handlers.NewProbeHandler(health.NewChecker(cfg.Health.CacheTTL, readinessChecks...))
+	root := setupProbes(probeHandler)
+	root.PathPrefix("/").Handler(handlers.TenantMiddleware(registry)(router))
 
 	// Setup CORS
 	c := cors.New(cors.Options{
@@ -60,7 +104,7 @@ func main() {
 	// Create server
 	server := &http.Server{
 		Addr:         ":" + cfg.Server.Port,
-		Handler:      c.Handler(router),
+		Handler:      c.Handler(root),
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 		IdleTimeout:  cfg.Server.IdleTimeout,
@@ -78,9 +122,35 @@ func main() {
 	setupGracefulShutdown(server, logger)
 }
 
-func setupLogger(cfg config.LoggingConfig) *logrus.Logger {
+// setupLogger creates the logger with a hook scrubbing secrets from its output
+func setupLogger(cfg config.LoggingConfig) (*logrus.Logger, *logging.RedactHook) {
 	logger := logrus.New()
+	hook := logging.NewRedactHook()
+	logger.AddHook(hook)
+	applyLogging(logger, cfg)
+	return logger, hook
+}
+
+// registrySecrets lists the secrets of every tenant's configuration
+func registrySecrets(registry *tenant.Registry) []string {
+	var secrets []string
+	for _, t := range registry.Tenants() {
+		secrets = append(secrets, t.Config.Secrets()...)
+	}
+	return secrets
+}
+
+// registrySecretFiles lists the files the tenants' secrets are read from
+func registrySecretFiles(registry *tenant.Registry) []string {
+	var paths []string
+	for _, t := range registry.Tenants() {
+		paths = append(paths, t.Config.SecretFiles()...)
+	}
+	return paths
+}
 
+// applyLogging sets the logger's level and format, also on reload
+func applyLogging(logger *logrus.Logger, cfg config.LoggingConfig) {
 	// Set log level
 	level, err := logrus.ParseLevel(cfg.Level)
 	if err != nil {
@@ -99,35 +169,96 @@ func setupLogger(cfg config.LoggingConfig) *logrus.Logger {
 			TimestampFormat: time.RFC3339,
 		})
 	}
-
-	return logger
 }
 
-func setupRouter(handler *handlers.StudentPDFHandler, logger *logrus.Logger) *mux.Router {
+func setupRouter(handler *handlers.StudentPDFHandler, scheduleHandler *handlers.ScheduleHandler, logger *logrus.Logger) *mux.Router {
 	router := mux.NewRouter()
 
 	// Add logging middleware
+	router.Use(requestIDMiddleware)
 	router.Use(loggingMiddleware(logger))
 	router.Use(recoveryMiddleware(logger))
 
-	// Health check endpoint
-	router.HandleFunc("/health", handler.HealthCheck).Methods("GET")
+	// Effective configuration
+	router.HandleFunc("/admin/config", handler.GetConfig).Methods("GET")
+	router.HandleFunc("/admin/audit/verify", handler.VerifyAuditLog).Methods("GET")
 
 	// API routes
 	api := router.PathPrefix("/api/v1").Subrouter()
 
-	// Student listing endpoint
+	// Student and staff listing endpoints
 	api.HandleFunc("/students", handler.GetStudents).Methods("GET")
+	api.HandleFunc("/students/export", handler.ExportStudents).Methods("GET")
+	api.HandleFunc("/staff", handler.GetStaff).Methods("GET")
+	api.HandleFunc("/notices/recipients", handler.GetNoticeRecipients).Methods("GET")
+
+	// Analytics
+	api.HandleFunc("/analytics/classes", handler.GetClassAnalytics).Methods("GET")
+
+	// Data quality
+	api.HandleFunc("/quality/students", handler.GetStudentQuality).Methods("GET")
 
 	// Report generation
 	api.HandleFunc("/reports/student/{id:[0-9]+}", handler.CreateStudentPDF).Methods("POST")
+	api.HandleFunc("/reports/student/{id:[0-9]+}/email", handler.EmailStudentPDF).Methods("POST")
+	api.HandleFunc("/reports/student/{id:[0-9]+}/preview", handler.PreviewStudentReport).Methods("GET")
+	api.HandleFunc("/deliveries", handler.GetDeliveries).Methods("GET")
+	api.HandleFunc("/reports/staff/{id:[0-9]+}", handler.CreateStaffPDF).Methods("POST")
+	api.HandleFunc("/reports/roster", handler.CreateClassRosterPDF).Methods("POST")
+	api.HandleFunc("/reports/leave/{userId:[0-9]+}", handler.CreateLeavePDF).Methods("POST")
+	api.HandleFunc("/reports/dashboard", handler.CreateDashboardPDF).Methods("POST")
+	api.HandleFunc("/reports/notices/digest", handler.CreateNoticeDigestPDF).Methods("POST")
+	api.HandleFunc("/reports/packet", handler.CreatePacketPDF).Methods("POST")
+
+	// Report download
+	api.HandleFunc("/reports/files/{filename}", handler.DownloadReport).Methods("GET")
 
 	// Cleanup endpoint
 	api.HandleFunc("/reports/cleanup", handler.CleanupReports).Methods("POST")
 
+	// Audit log
+	api.HandleFunc("/audit", handler.GetAuditLog).Methods("GET")
+
+	// Recurring report schedules
+	if scheduleHandler != nil {
+		api.HandleFunc("/schedules", scheduleHandler.ListSchedules).Methods("GET")
+		api.HandleFunc("/schedules", scheduleHandler.CreateSchedule).Methods("POST")
+		api.HandleFunc("/schedules/{id}", scheduleHandler.GetSchedule).Methods("GET")
+		api.HandleFunc("/schedules/{id}", scheduleHandler.UpdateSchedule).Methods("PUT")
+		api.HandleFunc("/schedules/{id}", scheduleHandler.DeleteSchedule).Methods("DELETE")
+		api.HandleFunc("/schedules/{id}/runs", scheduleHandler.ListRuns).Methods("GET")
+		api.HandleFunc("/schedules/{id}/run", scheduleHandler.TriggerSchedule).Methods("POST")
+	}
+
+	return router
+}
+
+// setupProbes routes the liveness and readiness probes. /health is kept as
+// an alias of /readyz for existing monitors
+func setupProbes(handler *handlers.ProbeHandler) *mux.Router {
+	router := mux.NewRouter()
+	router.HandleFunc("/livez", handler.Livez).Methods("GET")
+	router.HandleFunc("/readyz", handler.Readyz).Methods("GET")
+	router.HandleFunc("/health", handler.Readyz).Methods("GET")
 	return router
 }
 
+// requestIDMiddleware makes sure every request carries an X-Request-ID
+func requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(handlers.RequestIDHeader)
+		if requestID == "" {
+			buf := make([]byte, 8)
+			rand.Read(buf)
+			requestID = hex.EncodeToString(buf)
+			r.Header.Set(handlers.RequestIDHeader, requestID)
+		}
+		w.Header().Set(handlers.RequestIDHeader, requestID)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func loggingMiddleware(logger *logrus.Logger) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -147,6 +278,8 @@ func loggingMiddleware(logger *logrus.Logger) mux.MiddlewareFunc {
 				"duration_ms": duration.Milliseconds(),
 				"remote_addr": r.RemoteAddr,
 				"user_agent":  r.UserAgent(),
+				"request_id":  r.Header.Get(handlers.RequestIDHeader),
+				"tenant":      tenant.IDFromContext(r.Context()),
 			}).Info("Request processed")
 		})
 	}
@@ -202,3 +335,11 @@ func (w *responseWriterWrapper) WriteHeader(statusCode int) {
 	w.statusCode = statusCode
 	w.ResponseWriter.WriteHeader(statusCode)
 }
+
+// Flush passes flushes through, so streamed responses such as CSV exports
+// reach the client as they are written
+func (w *responseWriterWrapper) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"crypto/sha256"
+	"os"
+	"os/signal"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/handlers"
+	"student-report-service/internal/logging"
+	"student-report-service/internal/tenant"
+
+	"github.com/sirupsen/logrus"
+)
+
+// reloader reloads the configuration on SIGHUP and when the config file,
+// tenants file or a file secrets are read from changes, then applies it to every tenant and the logger.
+// Requests in flight are not interrupted
+type reloader struct {
+	path string
+	// tenantsFile is fixed at startup, as changing it requires a restart
+	tenantsFile string
+	logger      *logrus.Logger
+	redactHook  *logging.RedactHook
+	runtimes    map[string]*tenantRuntime
+
+	mu     sync.Mutex
+	status handlers.ReloadStatus
+	// secretFiles are the files the loaded configuration read secrets from
+	secretFiles []string
+	// fingerprints are the hashes of the watched files when last loaded
+	fingerprints map[string][32]byte
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// newReloader creates a reloader for the configuration loaded from path at startup
+func newReloader(path string, base *config.Config, runtimes map[string]*tenantRuntime, logger *logrus.Logger, redactHook *logging.RedactHook) *reloader {
+	r := &reloader{
+		path:        path,
+		tenantsFile: base.Tenants.File,
+		logger:      logger,
+		redactHook:  redactHook,
+		runtimes:    runtimes,
+		status:      handlers.ReloadStatus{Source: path, StartedAt: time.Now()},
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	for _, runtime := range runtimes {
+		r.secretFiles = append(r.secretFiles, runtime.service.Config().SecretFiles()...)
+	}
+	r.fingerprints = r.fingerprint()
+	return r
+}
+
+// Start listens for SIGHUP and polls the watched files every interval; an
+// interval of 0 disables polling
+func (r *reloader) Start(interval time.Duration) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	go func() {
+		defer close(r.done)
+		defer signal.Stop(hangup)
+
+		var tick <-chan time.Time
+		if interval > 0 {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		for {
+			select {
+			case <-hangup:
+				r.Reload("signal")
+			case <-tick:
+				if r.changed() {
+					r.Reload("file")
+				}
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops listening for reload triggers
+func (r *reloader) Stop() {
+	close(r.stop)
+	<-r.done
+}
+
+// Status returns the reload history shown by /admin/config
+func (r *reloader) Status() handlers.ReloadStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// Reload loads and validates the configuration and, when it is valid,
+// switches every tenant to it. An invalid configuration is logged and the
+// current one kept
+func (r *reloader) Reload(trigger string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt := &handlers.ReloadAttempt{At: time.Now(), Trigger: trigger}
+	r.status.LastReload = attempt
+	r.fingerprints = r.fingerprint()
+
+	next, err := config.Load(r.path)
+	if err == nil {
+		err = r.apply(next, attempt)
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+		r.logger.WithError(err).WithField("trigger", trigger).Error("Configuration reload failed, keeping the current configuration")
+		return
+	}
+
+	r.status.Reloads++
+	r.logger.WithField("trigger", trigger).Info("Configuration reloaded")
+}
+
+// apply derives each tenant's configuration from base and switches the
+// tenant's components and the logger to it. Settings that need a restart
+// keep their current value and are reported as pending
+func (r *reloader) apply(base *config.Config, attempt *handlers.ReloadAttempt) error {
+	registry, err := tenant.LoadRegistry(r.tenantsFile, base)
+	if err != nil {
+		return err
+	}
+	// Scrub both old and new secrets while tenants switch over
+	r.redactHook.SetSecrets(append(r.currentSecrets(), registrySecrets(registry)...))
+	// Backend clients decide on debug logging from the logger's level when
+	// they are rebuilt, so the level must change first
+	applyLogging(r.logger, base.Logging)
+
+	attempt.Changes = make(map[string]config.Changes)
+	seen := make(map[string]bool)
+	for _, t := range registry.Tenants() {
+		seen[t.ID] = true
+		runtime, ok := r.runtimes[t.ID]
+		if !ok {
+			r.logger.WithField("tenant", t.ID).Warn("New tenant will be served after a restart")
+			continue
+		}
+
+		effective, changes := config.Reconcile(runtime.service.Config(), t.Config)
+		if changes.Empty() {
+			continue
+		}
+		runtime.service.UpdateConfig(effective)
+		attempt.Changes[t.ID] = changes
+
+		fields := logrus.Fields{"tenant": t.ID, "applied": changes.Applied}
+		if len(changes.Pending) > 0 {
+			fields["pending_restart"] = changes.Pending
+		}
+		r.logger.WithFields(fields).Info("Tenant configuration changed")
+	}
+
+	var removed []string
+	for id := range r.runtimes {
+		if !seen[id] {
+			removed = append(removed, id)
+		}
+	}
+	if len(removed) > 0 {
+		sort.Strings(removed)
+		r.logger.WithField("tenants", removed).Warn("Removed tenants are served until a restart")
+	}
+
+	r.redactHook.SetSecrets(r.currentSecrets())
+
+	// Watch the secret files of the new configuration; ones not watched
+	// before are hashed now, so they do not trigger a reload of their own
+	r.secretFiles = registrySecretFiles(registry)
+	for path, sum := range r.fingerprint() {
+		if _, ok := r.fingerprints[path]; !ok {
+			r.fingerprints[path] = sum
+		}
+	}
+	return nil
+}
+
+// currentSecrets lists the secrets of the configurations tenants are using
+func (r *reloader) currentSecrets() []string {
+	var secrets []string
+	for _, runtime := range r.runtimes {
+		secrets = append(secrets, runtime.service.Config().Secrets()...)
+	}
+	return secrets
+}
+
+// changed reports whether a watched file differs from when it was last loaded
+func (r *reloader) changed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current := r.fingerprint()
+	for path, sum := range current {
+		if r.fingerprints[path] != sum {
+			return true
+		}
+	}
+	return false
+}
+
+// fingerprint hashes the config and tenants files and the secret files. A
+// missing file has a zero hash, so deleting a file also triggers a reload
+func (r *reloader) fingerprint() map[string][32]byte {
+	sums := make(map[string][32]byte)
+	paths := append([]string{r.path, r.tenantsFile}, r.secretFiles...)
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			sums[path] = [32]byte{}
+			continue
+		}
+		sums[path] = sha256.Sum256(data)
+	}
+	return sums
+}
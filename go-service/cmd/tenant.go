@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+
+	"student-report-service/internal/audit"
+	"student-report-service/internal/client"
+	"student-report-service/internal/mailer"
+	"student-report-service/internal/pdf"
+	"student-report-service/internal/retention"
+	"student-report-service/internal/schedule"
+	"student-report-service/internal/service"
+	"student-report-service/internal/snapshot"
+	"student-report-service/internal/tenant"
+
+	"github.com/sirupsen/logrus"
+)
+
+// tenantRuntime holds the components serving one tenant
+type tenantRuntime struct {
+	service   *service.PDFReportService
+	schedules *schedule.Manager
+	stops     []func()
+}
+
+// startTenant builds a tenant's backend client, generator, stores and
+// background jobs from its configuration
+func startTenant(t *tenant.Tenant, logger *logrus.Logger) (*tenantRuntime, error) {
+	cfg := t.Config
+	rt := &tenantRuntime{}
+
+	nodeClient, err := client.NewNodeJSClient(&cfg.NodeJS, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Node.js client: %w", err)
+	}
+	rt.stops = append(rt.stops, func() { nodeClient.Close() })
+
+	pdfGenerator, err := pdf.NewGenerator(&cfg.Report)
+	if err != nil {
+		rt.Stop()
+		return nil, fmt.Errorf("failed to initialize PDF generator: %w", err)
+	}
+
+	rt.service = service.NewPDFReportServiceWithConcreteTypes(nodeClient, pdfGenerator, cfg)
+
+	if cfg.Audit.Enabled {
+		auditStore, err := audit.NewFileStore(cfg.Audit.Path, cfg.Audit.Chained)
+		if err != nil {
+			rt.Stop()
+			return nil, fmt.Errorf("failed to initialize audit log: %w", err)
+		}
+		rt.stops = append(rt.stops, func() { auditStore.Close() })
+		rt.service.SetAuditStore(auditStore)
+
+		// A broken chain is reported but does not stop the service, so the
+		// log can be inspected through /admin/audit/verify
+		if cfg.Audit.Chained {
+			if err := auditStore.Verify(); err != nil {
+				logger.WithError(err).WithField("tenant", t.ID).Error("Audit log failed verification")
+			}
+		}
+	}
+
+	if cfg.Report.SnapshotPath != "" {
+		snapshotStore, err := snapshot.NewFileStore(cfg.Report.SnapshotPath)
+		if err != nil {
+			rt.Stop()
+			return nil, fmt.Errorf("failed to initialize dashboard snapshots: %w", err)
+		}
+		rt.service.SetSnapshotStore(snapshotStore)
+	}
+
+	if cfg.SMTP.Enabled {
+		reportMailer, err := mailer.NewMailer(&cfg.SMTP, cfg.Report.MaxFileSize, mailer.NewSMTPSender(&cfg.SMTP))
+		if err != nil {
+			rt.Stop()
+			return nil, fmt.Errorf("failed to initialize email delivery: %w", err)
+		}
+		// Queued emails are sent before the tenant stops
+		rt.stops = append(rt.stops, reportMailer.Close)
+		rt.service.SetMailer(reportMailer)
+	}
+
+	// Start background cleanup
+	if cfg.Report.Cleanup && cfg.Report.CleanupInterval > 0 {
+		reportService := rt.service
+		cleanupScheduler := retention.NewScheduler(cfg.Report.CleanupInterval, func() (*retention.Summary, error) {
+			return reportService.CleanupOldReports(service.ReportOptions{GeneratedBy: "scheduler"}, false)
+		}, logger)
+		cleanupScheduler.Start()
+		rt.stops = append(rt.stops, cleanupScheduler.Stop)
+	}
+
+	// Start recurring report schedules
+	if cfg.Schedule.Enabled {
+		scheduleStore, err := schedule.NewFileStore(cfg.Schedule.StorePath)
+		if err != nil {
+			rt.Stop()
+			return nil, fmt.Errorf("failed to initialize schedule store: %w", err)
+		}
+		rt.schedules = schedule.NewManager(scheduleStore, service.NewScheduleExecutor(rt.service), logger, cfg.Schedule.PollInterval)
+		rt.schedules.Start()
+		rt.stops = append(rt.stops, rt.schedules.Stop)
+	}
+
+	return rt, nil
+}
+
+// Stop stops background jobs and closes stores in reverse order of creation
+func (rt *tenantRuntime) Stop() {
+	for i := len(rt.stops) - 1; i >= 0; i-- {
+		rt.stops[i]()
+	}
+}
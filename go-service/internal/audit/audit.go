@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotChained is returned when verifying a log that has no hash chain
+var ErrNotChained = errors.New("audit log is not hash-chained")
+
+// Action identifies what was done to a report
+type Action string
+
+const (
+	// ActionGenerate is recorded when a report is generated
+	ActionGenerate Action = "report.generate"
+	// ActionDownload is recorded when a report file is downloaded
+	ActionDownload Action = "report.download"
+	// ActionCleanup is recorded for every file removed by report cleanup
+	ActionCleanup Action = "report.cleanup"
+	// ActionEmail is recorded when a report is emailed
+	ActionEmail Action = "report.email"
+	// ActionPreview is recorded when a report is rendered for in-browser preview
+	ActionPreview Action = "report.preview"
+)
+
+// Outcome values for audit records
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Record is a single append-only audit entry
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Actor     string    `json:"actor"`
+	Action    Action    `json:"action"`
+	StudentID int       `json:"student_id,omitempty"`
+	ReportID  string    `json:"report_id,omitempty"`
+	ClientIP  string    `json:"client_ip,omitempty"`
+	RequestID string    `json:"request_id,omitempty"`
+	Outcome   string    `json:"outcome"`
+	Details   string    `json:"details,omitempty"`
+
+	// Redaction profile and retention tags of generated reports
+	Profile string   `json:"profile,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+
+	// Hash chaining fields, populated only by chained stores
+	PrevHash string `json:"prev_hash,omitempty"`
+	Hash     string `json:"hash,omitempty"`
+}
+
+// Filter selects audit records; zero-valued fields match everything
+type Filter struct {
+	Actor     string
+	Action    Action
+	StudentID int
+	ReportID  string
+	Outcome   string
+	From      time.Time
+	To        time.Time
+	Limit     int
+}
+
+// Matches reports whether the record satisfies the filter
+func (f Filter) Matches(r Record) bool {
+	if f.Actor != "" && f.Actor != r.Actor {
+		return false
+	}
+	if f.Action != "" && f.Action != r.Action {
+		return false
+	}
+	if f.StudentID != 0 && f.StudentID != r.StudentID {
+		return false
+	}
+	if f.ReportID != "" && f.ReportID != r.ReportID {
+		return false
+	}
+	if f.Outcome != "" && f.Outcome != r.Outcome {
+		return false
+	}
+	if !f.From.IsZero() && r.Timestamp.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && r.Timestamp.After(f.To) {
+		return false
+	}
+	return true
+}
+
+// Store persists audit records. Implementations must be safe for concurrent use
+// and must never modify or remove records once appended.
+type Store interface {
+	Append(record Record) error
+	Query(filter Filter) ([]Record, error)
+	Close() error
+}
+
+// Verifier is implemented by stores that can detect tampering with their records
+type Verifier interface {
+	Verify() error
+}
+
+// NopStore discards all records; it is used when auditing is disabled
+type NopStore struct{}
+
+// Append discards the record
+func (NopStore) Append(Record) error { return nil }
+
+// Query always returns no records
+func (NopStore) Query(Filter) ([]Record, error) { return []Record{}, nil }
+
+// Close does nothing
+func (NopStore) Close() error { return nil }
@@ -0,0 +1,208 @@
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore appends audit records to a JSON-lines file. In chained mode every
+// record carries the SHA-256 of its predecessor, so that edits or deletions
+// anywhere in the file are detected by Verify.
+type FileStore struct {
+	path     string
+	chained  bool
+	file     *os.File
+	lastHash string
+	mutex    sync.Mutex
+}
+
+// NewFileStore opens (or creates) the audit file at path
+func NewFileStore(path string, chained bool) (*FileStore, error) {
+	if path == "" {
+		return nil, fmt.Errorf("audit log path cannot be empty")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create audit directory: %w", err)
+	}
+
+	store := &FileStore{
+		path:    path,
+		chained: chained,
+	}
+
+	// Resume the chain from the last record already on disk
+	if chained {
+		records, err := store.readAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > 0 {
+			store.lastHash = records[len(records)-1].Hash
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	store.file = file
+
+	return store, nil
+}
+
+// Append writes the record as a single JSON line
+func (s *FileStore) Append(record Record) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	record.PrevHash = ""
+	record.Hash = ""
+	if s.chained {
+		record.PrevHash = s.lastHash
+		hash, err := hashRecord(record)
+		if err != nil {
+			return err
+		}
+		record.Hash = hash
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit record: %w", err)
+	}
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+
+	if s.chained {
+		s.lastHash = record.Hash
+	}
+
+	return nil
+}
+
+// Query returns matching records in chronological order, newest last.
+// When filter.Limit is set only the most recent matches are returned.
+func (s *FileStore) Query(filter Filter) ([]Record, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	records, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]Record, 0)
+	for _, record := range records {
+		if filter.Matches(record) {
+			matched = append(matched, record)
+		}
+	}
+
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[len(matched)-filter.Limit:]
+	}
+
+	return matched, nil
+}
+
+// Verify walks the hash chain and reports the first broken link
+func (s *FileStore) Verify() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if !s.chained {
+		return ErrNotChained
+	}
+
+	records, err := s.readAll()
+	if err != nil {
+		return err
+	}
+
+	prevHash := ""
+	for i, record := range records {
+		if record.PrevHash != prevHash {
+			return fmt.Errorf("audit chain broken at record %d: previous hash mismatch", i+1)
+		}
+
+		expected, err := hashRecord(record)
+		if err != nil {
+			return err
+		}
+		if record.Hash != expected {
+			return fmt.Errorf("audit chain broken at record %d: content hash mismatch", i+1)
+		}
+
+		prevHash = record.Hash
+	}
+
+	return nil
+}
+
+// Close closes the underlying file
+func (s *FileStore) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *FileStore) readAll() ([]Record, error) {
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("corrupt audit record at line %d: %w", line, err)
+		}
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return records, nil
+}
+
+// hashRecord computes the chain hash over the record with its Hash field cleared
+func hashRecord(record Record) (string, error) {
+	record.Hash = ""
+	payload, err := json.Marshal(record)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode audit record: %w", err)
+	}
+
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:]), nil
+}
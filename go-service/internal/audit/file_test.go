@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore_AppendAndQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	store, err := NewFileStore(path, false)
+	require.NoError(t, err)
+	defer store.Close()
+
+	now := time.Now()
+	records := []Record{
+		{Timestamp: now.Add(-2 * time.Hour), Actor: "alice", Action: ActionGenerate, StudentID: 1, ReportID: "RPT-1", Outcome: OutcomeSuccess},
+		{Timestamp: now.Add(-1 * time.Hour), Actor: "bob", Action: ActionDownload, StudentID: 1, ReportID: "RPT-1", Outcome: OutcomeSuccess},
+		{Timestamp: now, Actor: "alice", Action: ActionGenerate, StudentID: 2, Outcome: OutcomeFailure},
+	}
+	for _, record := range records {
+		require.NoError(t, store.Append(record))
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected int
+	}{
+		{name: "No filter", filter: Filter{}, expected: 3},
+		{name: "By actor", filter: Filter{Actor: "alice"}, expected: 2},
+		{name: "By action", filter: Filter{Action: ActionDownload}, expected: 1},
+		{name: "By student", filter: Filter{StudentID: 1}, expected: 2},
+		{name: "By outcome", filter: Filter{Outcome: OutcomeFailure}, expected: 1},
+		{name: "By time range", filter: Filter{From: now.Add(-90 * time.Minute)}, expected: 2},
+		{name: "With limit", filter: Filter{Limit: 1}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := store.Query(tt.filter)
+			assert.NoError(t, err)
+			assert.Len(t, result, tt.expected)
+		})
+	}
+
+	latest, err := store.Query(Filter{Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2, latest[0].StudentID)
+}
+
+func TestFileStore_ChainedVerify(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	store, err := NewFileStore(path, true)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Append(Record{Actor: "alice", Action: ActionGenerate, StudentID: 1, Outcome: OutcomeSuccess}))
+	require.NoError(t, store.Append(Record{Actor: "alice", Action: ActionDownload, StudentID: 1, Outcome: OutcomeSuccess}))
+	require.NoError(t, store.Close())
+
+	// Reopening resumes the chain from the last record
+	store, err = NewFileStore(path, true)
+	require.NoError(t, err)
+	require.NoError(t, store.Append(Record{Actor: "bob", Action: ActionCleanup, StudentID: 1, Outcome: OutcomeSuccess}))
+	assert.NoError(t, store.Verify())
+	require.NoError(t, store.Close())
+
+	// Tampering with an earlier record breaks the chain
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	tampered := strings.Replace(string(content), `"actor":"alice"`, `"actor":"mallory"`, 1)
+	require.NoError(t, os.WriteFile(path, []byte(tampered), 0640))
+
+	store, err = NewFileStore(path, true)
+	require.NoError(t, err)
+	defer store.Close()
+	err = store.Verify()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "record 1")
+
+	unchained, err := NewFileStore(filepath.Join(t.TempDir(), "plain.jsonl"), false)
+	require.NoError(t, err)
+	defer unchained.Close()
+	assert.ErrorIs(t, unchained.Verify(), ErrNotChained)
+}
@@ -0,0 +1,195 @@
+package chart
+
+import (
+	"math"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// yTicks is the target number of steps on a value axis
+const yTicks = 4
+
+const (
+	legendHeight     = 5.0
+	legendSwatch     = 3.0
+	categoryHeight   = 5.0
+	axisLabelSize    = 5.0
+	tickLabelPadding = 1.5
+)
+
+// axes lays out a category chart: legend on top, value axis on the left and
+// category labels below the plot area
+type axes struct {
+	style      Style
+	categories []string
+	series     []Series
+	xLabel     string
+	yLabel     string
+
+	lo, hi, step float64
+	plot         box
+}
+
+// newAxes scales the value axis to lo..hi and computes the plot area within the box
+func newAxes(pdf *gofpdf.Fpdf, style Style, area box, categories []string, series []Series, xLabel, yLabel string, lo, hi float64) *axes {
+	a := &axes{style: style, categories: categories, series: series, xLabel: xLabel, yLabel: yLabel}
+	a.lo, a.hi, a.step = niceScale(lo, hi, yTicks)
+
+	plot := area
+	if a.showLegend() {
+		rows := legendRows(pdf, style, a.seriesNames(), area.width)
+		plot.y += float64(len(rows))*legendHeight + 2
+		plot.height -= float64(len(rows))*legendHeight + 2
+	}
+	if yLabel != "" {
+		plot.x += axisLabelSize
+		plot.width -= axisLabelSize
+	}
+
+	pdf.SetFont(style.FontFamily, "", style.FontSize)
+	tickWidth := 0.0
+	for _, value := range a.ticks() {
+		tickWidth = max(tickWidth, pdf.GetStringWidth(formatValue(value, a.step)))
+	}
+	plot.x += tickWidth + 2*tickLabelPadding
+	plot.width -= tickWidth + 2*tickLabelPadding
+
+	plot.height -= categoryHeight
+	if xLabel != "" {
+		plot.height -= axisLabelSize
+	}
+
+	// Leave room above the top grid line for its label
+	plot.y += 2
+	plot.height -= 2
+
+	a.plot = plot
+	return a
+}
+
+func (a *axes) showLegend() bool {
+	return len(a.series) > 1
+}
+
+func (a *axes) seriesNames() []string {
+	names := make([]string, len(a.series))
+	for i, series := range a.series {
+		names[i] = series.Name
+	}
+	return names
+}
+
+// ticks returns the values marked on the value axis
+func (a *axes) ticks() []float64 {
+	count := int(math.Round((a.hi - a.lo) / a.step))
+	values := make([]float64, 0, count+1)
+	for i := 0; i <= count; i++ {
+		values = append(values, a.lo+float64(i)*a.step)
+	}
+	return values
+}
+
+// valueY converts a value to a y position in the plot area
+func (a *axes) valueY(value float64) float64 {
+	return a.plot.bottom() - (value-a.lo)/(a.hi-a.lo)*a.plot.height
+}
+
+// slot returns the left edge and width of category i
+func (a *axes) slot(i int) (float64, float64) {
+	width := a.plot.width / float64(max(len(a.categories), 1))
+	return a.plot.x + float64(i)*width, width
+}
+
+// drawFrame draws the legend, grid lines, axis lines and all labels
+func (a *axes) drawFrame(pdf *gofpdf.Fpdf, area box) {
+	style := a.style
+
+	if a.showLegend() {
+		drawLegend(pdf, style, a.seriesNames(), area.x, area.y, area.width)
+	}
+
+	// Grid lines with value labels
+	pdf.SetLineWidth(0.1)
+	setDraw(pdf, style.Grid)
+	style.setFont(pdf, "", style.Muted)
+	tickLeft := a.plot.x - tickLabelPadding
+	for _, value := range a.ticks() {
+		y := a.valueY(value)
+		pdf.Line(a.plot.x, y, a.plot.right(), y)
+		width := pdf.GetStringWidth(formatValue(value, a.step))
+		pdf.Text(tickLeft-width, y+1, formatValue(value, a.step))
+	}
+
+	// Axis lines, with the category axis at zero
+	setDraw(pdf, style.Axis)
+	pdf.Line(a.plot.x, a.plot.y, a.plot.x, a.plot.bottom())
+	pdf.Line(a.plot.x, a.valueY(0), a.plot.right(), a.valueY(0))
+
+	// Category labels
+	for i, category := range a.categories {
+		x, width := a.slot(i)
+		pdf.SetXY(x, a.plot.bottom()+1)
+		pdf.CellFormat(width, categoryHeight-1, truncate(pdf, category, width-1), "", 0, "C", false, 0, "")
+	}
+
+	// Axis titles
+	style.setFont(pdf, "B", style.Text)
+	if a.xLabel != "" {
+		pdf.SetXY(a.plot.x, a.plot.bottom()+categoryHeight)
+		pdf.CellFormat(a.plot.width, axisLabelSize, a.xLabel, "", 0, "C", false, 0, "")
+	}
+	if a.yLabel != "" {
+		label := truncate(pdf, a.yLabel, a.plot.height)
+		labelX := area.x + axisLabelSize - 1.5
+		labelY := a.plot.y + a.plot.height/2 + pdf.GetStringWidth(label)/2
+		pdf.TransformBegin()
+		pdf.TransformRotate(90, labelX, labelY)
+		pdf.Text(labelX, labelY, label)
+		pdf.TransformEnd()
+	}
+
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetLineWidth(0.2)
+}
+
+// legendRows splits legend entries into rows that fit the width
+func legendRows(pdf *gofpdf.Fpdf, style Style, names []string, width float64) [][]int {
+	pdf.SetFont(style.FontFamily, "", style.FontSize)
+
+	var rows [][]int
+	var row []int
+	used := 0.0
+	for i, name := range names {
+		entry := legendSwatch + 1.5 + pdf.GetStringWidth(name) + 4
+		if len(row) > 0 && used+entry > width {
+			rows = append(rows, row)
+			row, used = nil, 0
+		}
+		row = append(row, i)
+		used += entry
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// drawLegend draws centred rows of colour swatches and series names
+func drawLegend(pdf *gofpdf.Fpdf, style Style, names []string, x, y, width float64) {
+	for r, row := range legendRows(pdf, style, names, width) {
+		rowWidth := 0.0
+		for _, i := range row {
+			rowWidth += legendSwatch + 1.5 + pdf.GetStringWidth(names[i]) + 4
+		}
+
+		entryX := x + (width-rowWidth+4)/2
+		entryY := y + float64(r)*legendHeight
+		style.setFont(pdf, "", style.Text)
+		for _, i := range row {
+			setFill(pdf, style.color(i))
+			pdf.Rect(entryX, entryY+1, legendSwatch, legendSwatch, "F")
+			pdf.Text(entryX+legendSwatch+1.5, entryY+legendSwatch+0.8, names[i])
+			entryX += legendSwatch + 1.5 + pdf.GetStringWidth(names[i]) + 4
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package chart
+
+import (
+	"github.com/jung-kurt/gofpdf"
+)
+
+// BarChart draws vertical bars per category. Several series are drawn side by
+// side, or on top of each other when Stacked is set.
+type BarChart struct {
+	Categories []string
+	Series     []Series
+	Stacked    bool
+
+	XLabel string
+	YLabel string
+
+	// ShowValues prints each bar's value above it; stacked bars show their total
+	ShowValues bool
+
+	Style Style
+}
+
+// NewBarChart creates a bar chart with a single series and the default style
+func NewBarChart(categories []string, values []float64) *BarChart {
+	return &BarChart{
+		Categories: categories,
+		Series:     []Series{{Values: values}},
+		Style:      DefaultStyle(),
+	}
+}
+
+// Draw renders the chart into the box at (x, y)
+func (c *BarChart) Draw(pdf *gofpdf.Fpdf, x, y, width, height float64) {
+	area := box{x, y, width, height}
+	if len(c.Categories) == 0 || len(c.Series) == 0 {
+		drawNoData(pdf, c.Style, area)
+		return
+	}
+
+	lo, hi := c.valueRange()
+	a := newAxes(pdf, c.Style, area, c.Categories, c.Series, c.XLabel, c.YLabel, lo, hi)
+	a.drawFrame(pdf, area)
+
+	for i := range c.Categories {
+		slotX, slotWidth := a.slot(i)
+		if c.Stacked {
+			c.drawStack(pdf, a, i, slotX, slotWidth)
+		} else {
+			c.drawGroup(pdf, a, i, slotX, slotWidth)
+		}
+	}
+}
+
+// valueRange returns the smallest and largest value the axis must show
+func (c *BarChart) valueRange() (float64, float64) {
+	lo, hi := 0.0, 0.0
+	for i := range c.Categories {
+		positive, negative := 0.0, 0.0
+		for _, series := range c.Series {
+			value := valueAt(series, i)
+			if !c.Stacked {
+				lo, hi = min(lo, value), max(hi, value)
+				continue
+			}
+			if value > 0 {
+				positive += value
+			} else {
+				negative += value
+			}
+		}
+		lo, hi = min(lo, negative), max(hi, positive)
+	}
+	return lo, hi
+}
+
+// drawGroup draws one bar per series side by side within the category slot
+func (c *BarChart) drawGroup(pdf *gofpdf.Fpdf, a *axes, category int, slotX, slotWidth float64) {
+	groupWidth := slotWidth * 0.7
+	barWidth := groupWidth / float64(len(c.Series))
+	left := slotX + (slotWidth-groupWidth)/2
+
+	for s, series := range c.Series {
+		value := valueAt(series, category)
+		barX := left + float64(s)*barWidth
+		c.drawBar(pdf, a, barX, barWidth, 0, value, c.Style.color(s))
+		if c.ShowValues {
+			c.drawValue(pdf, a, barX, barWidth, value)
+		}
+	}
+}
+
+// drawStack draws the series on top of each other, positives up and negatives down
+func (c *BarChart) drawStack(pdf *gofpdf.Fpdf, a *axes, category int, slotX, slotWidth float64) {
+	barWidth := slotWidth * 0.6
+	barX := slotX + (slotWidth-barWidth)/2
+
+	positive, negative := 0.0, 0.0
+	for s, series := range c.Series {
+		value := valueAt(series, category)
+		if value >= 0 {
+			c.drawBar(pdf, a, barX, barWidth, positive, positive+value, c.Style.color(s))
+			positive += value
+		} else {
+			c.drawBar(pdf, a, barX, barWidth, negative, negative+value, c.Style.color(s))
+			negative += value
+		}
+	}
+	if c.ShowValues {
+		c.drawValue(pdf, a, barX, barWidth, positive+negative)
+	}
+}
+
+// drawBar fills the bar between two values
+func (c *BarChart) drawBar(pdf *gofpdf.Fpdf, a *axes, barX, barWidth, from, to float64, color Color) {
+	if from == to {
+		return
+	}
+	top, bottom := a.valueY(max(from, to)), a.valueY(min(from, to))
+	setFill(pdf, color)
+	pdf.Rect(barX, top, barWidth, bottom-top, "F")
+}
+
+// drawValue prints a value just beyond the end of its bar
+func (c *BarChart) drawValue(pdf *gofpdf.Fpdf, a *axes, barX, barWidth, value float64) {
+	c.Style.setFont(pdf, "", c.Style.Text)
+	label := formatLabel(value)
+	labelY := a.valueY(value) - 1
+	if value < 0 {
+		labelY = a.valueY(value) + 3
+	}
+	pdf.Text(barX+(barWidth-pdf.GetStringWidth(label))/2, labelY, label)
+}
+
+// valueAt returns the series value for category i, treating missing values as zero
+func valueAt(series Series, i int) float64 {
+	if i < len(series.Values) {
+		return series.Values[i]
+	}
+	return 0
+}
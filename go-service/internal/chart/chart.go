@@ -0,0 +1,147 @@
+// Package chart draws vector charts on gofpdf documents. Charts are drawn
+// with PDF primitives into a box on the current page, so any report can
+// embed them next to its text and tables.
+package chart
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Chart is a chart that can be drawn into a box on the current page
+type Chart interface {
+	Draw(pdf *gofpdf.Fpdf, x, y, width, height float64)
+}
+
+// Color is an RGB colour with 0-255 components
+type Color struct {
+	R, G, B int
+}
+
+// Style holds the fonts and colours charts are drawn with
+type Style struct {
+	// Palette colours series in order and repeats when there are more series
+	Palette []Color
+	Text    Color
+	Muted   Color
+	Grid    Color
+	Axis    Color
+
+	FontFamily string
+	FontSize   float64
+}
+
+// DefaultStyle returns a neutral style; reports normally use their template theme
+func DefaultStyle() Style {
+	return Style{
+		Palette: []Color{
+			{0, 51, 102},
+			{46, 134, 193},
+			{241, 196, 15},
+			{231, 76, 60},
+			{39, 174, 96},
+			{142, 68, 173},
+		},
+		Text:       Color{51, 51, 51},
+		Muted:      Color{100, 100, 100},
+		Grid:       Color{220, 220, 220},
+		Axis:       Color{150, 150, 150},
+		FontFamily: "Arial",
+		FontSize:   7,
+	}
+}
+
+// Series is a named set of values, one per category
+type Series struct {
+	Name   string
+	Values []float64
+}
+
+// color returns the palette colour for series i
+func (s Style) color(i int) Color {
+	if len(s.Palette) == 0 {
+		return Color{0, 0, 0}
+	}
+	return s.Palette[i%len(s.Palette)]
+}
+
+func (s Style) setFont(pdf *gofpdf.Fpdf, styleStr string, color Color) {
+	pdf.SetFont(s.FontFamily, styleStr, s.FontSize)
+	pdf.SetTextColor(color.R, color.G, color.B)
+}
+
+func setFill(pdf *gofpdf.Fpdf, color Color) {
+	pdf.SetFillColor(color.R, color.G, color.B)
+}
+
+func setDraw(pdf *gofpdf.Fpdf, color Color) {
+	pdf.SetDrawColor(color.R, color.G, color.B)
+}
+
+// box is a rectangle on the page
+type box struct {
+	x, y, width, height float64
+}
+
+func (b box) bottom() float64 { return b.y + b.height }
+func (b box) right() float64  { return b.x + b.width }
+
+// niceScale picks axis bounds covering lo..hi that divide into at most ticks
+// steps of 1, 2, 2.5 or 5 times a power of ten. The axis always includes zero.
+func niceScale(lo, hi float64, ticks int) (float64, float64, float64) {
+	lo, hi = math.Min(lo, 0), math.Max(hi, 0)
+	if hi-lo == 0 {
+		hi = 1
+	}
+
+	raw := (hi - lo) / float64(ticks)
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := 10 * magnitude
+	for _, factor := range []float64{1, 2, 2.5, 5} {
+		if factor*magnitude >= raw {
+			step = factor * magnitude
+			break
+		}
+	}
+
+	// Bounds snap outwards to whole steps; rounding guards against float drift
+	lo = math.Floor(lo/step+1e-9) * step
+	hi = math.Ceil(hi/step-1e-9) * step
+	return lo, hi, step
+}
+
+// formatValue formats a value with as many decimals as step needs
+func formatValue(value, step float64) string {
+	decimals := 0
+	for scaled := step; decimals < 4 && math.Abs(scaled-math.Round(scaled)) > 1e-9; scaled *= 10 {
+		decimals++
+	}
+	return strconv.FormatFloat(value, 'f', decimals, 64)
+}
+
+// formatLabel formats a data value with at most two decimals
+func formatLabel(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+// truncate shortens text with an ellipsis so it fits in width
+func truncate(pdf *gofpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+// drawNoData marks an empty chart area
+func drawNoData(pdf *gofpdf.Fpdf, style Style, area box) {
+	pdf.SetFont(style.FontFamily, "I", style.FontSize+2)
+	pdf.SetTextColor(style.Muted.R, style.Muted.G, style.Muted.B)
+	pdf.SetXY(area.x, area.y+area.height/2-3)
+	pdf.CellFormat(area.width, 6, "No data", "", 0, "C", false, 0, "")
+}
@@ -0,0 +1,137 @@
+package chart
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// drawOps draws the chart on a blank page and returns the page's PDF content stream
+func drawOps(t *testing.T, chart Chart) string {
+	t.Helper()
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetCompression(false)
+	pdf.AddPage()
+	chart.Draw(pdf, 20, 20, 120, 70)
+
+	var buf bytes.Buffer
+	require.NoError(t, pdf.Output(&buf))
+
+	// The first stream in the file is the content of page 1
+	content := buf.String()
+	start := strings.Index(content, "stream\n")
+	end := strings.Index(content, "endstream")
+	require.True(t, start >= 0 && end > start, "page content stream not found")
+	return content[start+len("stream\n") : end]
+}
+
+func TestCharts_Golden(t *testing.T) {
+	months := []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun"}
+
+	tests := []struct {
+		name  string
+		chart Chart
+	}{
+		{
+			name:  "bar",
+			chart: &BarChart{Categories: []string{"Sick Leave", "Casual", "Maternity"}, Series: []Series{{Values: []float64{13, 2.5, 30}}}, ShowValues: true, Style: DefaultStyle()},
+		},
+		{
+			name: "bar_grouped",
+			chart: &BarChart{
+				Categories: []string{"Grade 1", "Grade 2", "Grade 3"},
+				Series:     []Series{{Name: "Male", Values: []float64{12, 15, 9}}, {Name: "Female", Values: []float64{14, 11, 13}}},
+				XLabel:     "Class",
+				YLabel:     "Students",
+				Style:      DefaultStyle(),
+			},
+		},
+		{
+			name: "bar_stacked",
+			chart: &BarChart{
+				Categories: months[:4],
+				Series:     []Series{{Name: "Approved", Values: []float64{4, 6, 3, 8}}, {Name: "Pending", Values: []float64{1, 2, 0, 1}}},
+				Stacked:    true,
+				ShowValues: true,
+				Style:      DefaultStyle(),
+			},
+		},
+		{
+			name: "line",
+			chart: &LineChart{
+				Categories: months,
+				Series:     []Series{{Name: "This year", Values: []float64{5, 9, 4, 12, 7, 3}}, {Name: "Last year", Values: []float64{3, 6, 8, 5, 4, 2}}},
+				YLabel:     "Admissions",
+				Markers:    true,
+				Style:      DefaultStyle(),
+			},
+		},
+		{
+			name:  "pie",
+			chart: NewPieChart([]string{"Students", "Teachers", "Parents"}, []float64{120, 14, 96}),
+		},
+		{
+			name:  "empty",
+			chart: NewPieChart([]string{"Students"}, []float64{0}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops := drawOps(t, tt.chart)
+			golden := filepath.Join("testdata", tt.name+".golden")
+
+			if *update {
+				require.NoError(t, os.MkdirAll("testdata", 0755))
+				require.NoError(t, os.WriteFile(golden, []byte(ops), 0644))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err, "run go test ./internal/chart -update to create golden files")
+			assert.Equal(t, string(expected), ops)
+		})
+	}
+}
+
+func TestNiceScale(t *testing.T) {
+	tests := []struct {
+		name       string
+		lo, hi     float64
+		expectedLo float64
+		expectedHi float64
+		step       float64
+	}{
+		{name: "Small counts", lo: 0, hi: 13, expectedLo: 0, expectedHi: 15, step: 5},
+		{name: "Exact fit", lo: 0, hi: 100, expectedLo: 0, expectedHi: 100, step: 25},
+		{name: "Fractions", lo: 0, hi: 0.7, expectedLo: 0, expectedHi: 0.8, step: 0.2},
+		{name: "Negative values", lo: -3, hi: 7, expectedLo: -5, expectedHi: 7.5, step: 2.5},
+		{name: "All zero", lo: 0, hi: 0, expectedLo: 0, expectedHi: 1, step: 0.25},
+		{name: "Positive minimum still starts at zero", lo: 40, hi: 45, expectedLo: 0, expectedHi: 60, step: 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, step := niceScale(tt.lo, tt.hi, yTicks)
+			assert.InDelta(t, tt.expectedLo, lo, 1e-9)
+			assert.InDelta(t, tt.expectedHi, hi, 1e-9)
+			assert.InDelta(t, tt.step, step, 1e-9)
+		})
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "10", formatValue(10, 5))
+	assert.Equal(t, "0.25", formatValue(0.25, 0.25))
+	assert.Equal(t, "0.50", formatValue(0.5, 0.25), "ticks share the step's decimals")
+	assert.Equal(t, "2.5", formatValue(2.5, 2.5))
+}
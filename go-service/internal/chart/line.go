@@ -0,0 +1,73 @@
+package chart
+
+import (
+	"github.com/jung-kurt/gofpdf"
+)
+
+// LineChart draws one line per series across the categories, for trends such
+// as admissions by month
+type LineChart struct {
+	Categories []string
+	Series     []Series
+
+	XLabel string
+	YLabel string
+
+	// Markers draws a dot at every data point
+	Markers bool
+
+	Style Style
+}
+
+// NewLineChart creates a line chart with markers and the default style
+func NewLineChart(categories []string, series ...Series) *LineChart {
+	return &LineChart{
+		Categories: categories,
+		Series:     series,
+		Markers:    true,
+		Style:      DefaultStyle(),
+	}
+}
+
+// Draw renders the chart into the box at (x, y)
+func (c *LineChart) Draw(pdf *gofpdf.Fpdf, x, y, width, height float64) {
+	area := box{x, y, width, height}
+	if len(c.Categories) == 0 || len(c.Series) == 0 {
+		drawNoData(pdf, c.Style, area)
+		return
+	}
+
+	lo, hi := 0.0, 0.0
+	for _, series := range c.Series {
+		for i := range c.Categories {
+			lo, hi = min(lo, valueAt(series, i)), max(hi, valueAt(series, i))
+		}
+	}
+
+	a := newAxes(pdf, c.Style, area, c.Categories, c.Series, c.XLabel, c.YLabel, lo, hi)
+	a.drawFrame(pdf, area)
+
+	pdf.SetLineWidth(0.5)
+	for s, series := range c.Series {
+		color := c.Style.color(s)
+		setDraw(pdf, color)
+		setFill(pdf, color)
+
+		points := make([]gofpdf.PointType, len(c.Categories))
+		for i := range c.Categories {
+			slotX, slotWidth := a.slot(i)
+			points[i] = gofpdf.PointType{X: slotX + slotWidth/2, Y: a.valueY(valueAt(series, i))}
+		}
+		for i := 1; i < len(points); i++ {
+			pdf.Line(points[i-1].X, points[i-1].Y, points[i].X, points[i].Y)
+		}
+		if c.Markers {
+			for _, point := range points {
+				pdf.Circle(point.X, point.Y, 0.8, "F")
+			}
+		}
+	}
+
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.SetLineWidth(0.2)
+}
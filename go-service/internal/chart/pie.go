@@ -0,0 +1,79 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// PieChart draws slices proportional to the values with a legend on the right
+// listing each label's value and share
+type PieChart struct {
+	Labels []string
+	Values []float64
+	Style  Style
+}
+
+// NewPieChart creates a pie chart with the default style
+func NewPieChart(labels []string, values []float64) *PieChart {
+	return &PieChart{Labels: labels, Values: values, Style: DefaultStyle()}
+}
+
+// Draw renders the chart into the box at (x, y). Negative values are ignored.
+func (c *PieChart) Draw(pdf *gofpdf.Fpdf, x, y, width, height float64) {
+	area := box{x, y, width, height}
+
+	total := 0.0
+	for _, value := range c.Values {
+		total += math.Max(value, 0)
+	}
+	if total <= 0 {
+		drawNoData(pdf, c.Style, area)
+		return
+	}
+
+	radius := min(height, width/2) / 2
+	cx, cy := x+radius+2, y+height/2
+
+	// Slices run clockwise from twelve o'clock
+	start := -90.0
+	for i, value := range c.Values {
+		if value <= 0 {
+			continue
+		}
+		sweep := value / total * 360
+		setFill(pdf, c.Style.color(i))
+		pdf.Polygon(sectorPoints(cx, cy, radius, start, start+sweep), "F")
+		start += sweep
+	}
+
+	legendX := cx + radius + 6
+	legendY := cy - float64(len(c.Labels))*legendHeight/2
+	style := c.Style
+	style.setFont(pdf, "", style.Text)
+	for i, label := range c.Labels {
+		value := 0.0
+		if i < len(c.Values) {
+			value = math.Max(c.Values[i], 0)
+		}
+		entryY := legendY + float64(i)*legendHeight
+		setFill(pdf, style.color(i))
+		pdf.Rect(legendX, entryY+1, legendSwatch, legendSwatch, "F")
+
+		text := fmt.Sprintf("%s: %s (%.0f%%)", label, formatLabel(value), value/total*100)
+		pdf.Text(legendX+legendSwatch+1.5, entryY+legendSwatch+0.8, truncate(pdf, text, area.right()-legendX-legendSwatch-1.5))
+	}
+}
+
+// sectorPoints approximates a pie slice as a polygon; angles are in degrees
+// clockwise from three o'clock
+func sectorPoints(cx, cy, radius, start, end float64) []gofpdf.PointType {
+	points := []gofpdf.PointType{{X: cx, Y: cy}}
+	steps := max(int(math.Ceil((end-start)/5)), 1)
+	for i := 0; i <= steps; i++ {
+		angle := (start + (end-start)*float64(i)/float64(steps)) * math.Pi / 180
+		points = append(points, gofpdf.PointType{X: cx + radius*math.Cos(angle), Y: cy + radius*math.Sin(angle)})
+	}
+	return points
+}
@@ -1,11 +1,12 @@
 package client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
-	"time"
+	"sync/atomic"
 
 	"student-report-service/internal/config"
 	"student-report-service/internal/models"
@@ -16,10 +17,11 @@ import (
 
 // NodeJSClient handles communication with the Node.js backend API
 type NodeJSClient struct {
-	client  *resty.Client
-	config  *config.NodeJSConfig
-	logger  *logrus.Logger
-	baseURL string
+	// client and config are replaced together when the configuration is
+	// reloaded; requests in flight keep the client they started with
+	client atomic.Pointer[resty.Client]
+	config atomic.Pointer[config.NodeJSConfig]
+	logger *logrus.Logger
 
 	// Authentication state - manual token management
 	accessToken  string
@@ -67,7 +69,14 @@ func NewNodeJSClient(cfg *config.NodeJSConfig, logger *logrus.Logger) (*NodeJSCl
 		logger.Warn("Service credentials not configured - authentication may fail")
 	}
 
-	// Create resty client with retry configuration
+	c := &NodeJSClient{logger: logger}
+	c.config.Store(cfg)
+	c.client.Store(newRestyClient(cfg, logger))
+	return c, nil
+}
+
+// newRestyClient creates a resty client with the configured timeout and retries
+func newRestyClient(cfg *config.NodeJSConfig, logger *logrus.Logger) *resty.Client {
 	client := resty.New().
 		SetBaseURL(cfg.BaseURL).
 		SetTimeout(cfg.Timeout).
@@ -77,53 +86,101 @@ func NewNodeJSClient(cfg *config.NodeJSConfig, logger *logrus.Logger) (*NodeJSCl
 		SetHeader("Content-Type", "application/json").
 		SetHeader("Accept", "application/json")
 
-	// Enable debug logging if logger level is debug
-	if logger.Level == logrus.DebugLevel {
+	// Enable debug logging if logger level is debug. It goes through the
+	// logger so its redaction hook scrubs credentials from request dumps
+	client.SetLogger(logger)
+	if logger.GetLevel() == logrus.DebugLevel {
 		client.SetDebug(true)
 	}
+	return client
+}
 
-	return &NodeJSClient{
-		client:  client,
-		config:  cfg,
-		logger:  logger,
-		baseURL: cfg.BaseURL,
-	}, nil
+// UpdateConfig switches to a reloaded configuration. A new backend URL or
+// service account discards the current session so the next request logs in
+func (c *NodeJSClient) UpdateConfig(cfg *config.NodeJSConfig) {
+	if cfg == nil {
+		return
+	}
+
+	previous := c.config.Load()
+	c.client.Store(newRestyClient(cfg, c.logger))
+	c.config.Store(cfg)
+
+	if cfg.BaseURL != previous.BaseURL || cfg.ServiceUsername != previous.ServiceUsername ||
+		cfg.ServicePassword != previous.ServicePassword {
+		c.authMutex.Lock()
+		c.accessToken, c.refreshToken, c.csrfToken = "", "", ""
+		c.authMutex.Unlock()
+	}
+}
+
+// sessionTokens are the tokens issued by one backend login
+type sessionTokens struct {
+	accessToken  string
+	refreshToken string
+	csrfToken    string
 }
 
 // authenticate performs login and stores authentication tokens
 func (c *NodeJSClient) authenticate() error {
+	tokens, err := c.login(context.Background())
+	if err != nil {
+		return err
+	}
+
+	// Store tokens for subsequent requests
+	c.authMutex.Lock()
+	c.accessToken = tokens.accessToken
+	c.refreshToken = tokens.refreshToken
+	c.csrfToken = tokens.csrfToken
+	c.authMutex.Unlock()
+
+	c.logger.WithFields(logrus.Fields{
+		"access_token_length":  len(tokens.accessToken),
+		"refresh_token_length": len(tokens.refreshToken),
+		"csrf_token_length":    len(tokens.csrfToken),
+	}).Debug("Successfully authenticated with Node.js API")
+
+	return nil
+}
+
+// login performs a login within ctx and returns the issued tokens without
+// storing them
+func (c *NodeJSClient) login(ctx context.Context) (*sessionTokens, error) {
+	cfg := c.config.Load()
 	c.logger.WithFields(logrus.Fields{
-		"username": c.config.ServiceUsername,
-		"base_url": c.baseURL,
+		"username": cfg.ServiceUsername,
+		"base_url": cfg.BaseURL,
 	}).Debug("Authenticating with Node.js API")
 
 	loginReq := LoginRequest{
-		Username: c.config.ServiceUsername,
-		Password: c.config.ServicePassword,
+		Username: cfg.ServiceUsername,
+		Password: cfg.ServicePassword,
 	}
 
 	var loginResp LoginResponse
 	var errorResp models.ErrorResponse
 
-	resp, err := c.client.R().
+	resp, err := c.client.Load().R().
+		SetContext(ctx).
 		SetResult(&loginResp).
 		SetError(&errorResp).
 		SetBody(loginReq).
 		Post("/auth/login")
 
 	if err != nil {
-		return fmt.Errorf("login request failed: %w", err)
+		return nil, fmt.Errorf("login request failed: %w", err)
 	}
 
 	if resp.IsError() {
 		if errorResp.Message != "" {
-			return &ClientError{
+			return nil, &ClientError{
 				StatusCode: resp.StatusCode(),
 				Message:    errorResp.Message,
 				Details:    errorResp.Error,
 			}
 		}
-		return &ClientError{
+		return nil, &ClientError{
 			StatusCode: resp.StatusCode(),
 			Message:    resp.Status(),
 			Details:    string(resp.Body()),
@@ -131,18 +188,13 @@ func (c *NodeJSClient) authenticate() error {
 	}
 
 	// Extract tokens from Set-Cookie headers in the response
-	c.authMutex.Lock()
-	defer c.authMutex.Unlock()
-
 	var accessToken, refreshToken, csrfToken string
 
 	// Parse Set-Cookie headers from the response
 	setCookieHeaders := resp.Header().Values("Set-Cookie")
-	c.logger.WithField("set_cookie_headers", setCookieHeaders).Debug("Received Set-Cookie headers")
+	c.logger.WithField("set_cookie_count", len(setCookieHeaders)).Debug("Received Set-Cookie headers")
 
 	for _, cookieHeader := range setCookieHeaders {
-		c.logger.WithField("cookie_header", cookieHeader).Debug("Processing cookie header")
-
 		// Parse each Set-Cookie header
 		if strings.HasPrefix(cookieHeader, "accessToken=") {
 			// Extract value between accessToken= and the first semicolon
@@ -169,29 +221,18 @@ func (c *NodeJSClient) authenticate() error {
 				tokenPart := strings.TrimPrefix(parts[0], "csrfToken=")
 				if tokenPart != "" && tokenPart != "csrfToken=" {
 					csrfToken = tokenPart
-					c.logger.WithField("csrf_token", csrfToken).Debug("Extracted CSRF token")
+					c.logger.WithField("csrf_token_length", len(csrfToken)).Debug("Extracted CSRF token")
 				}
 			}
 		}
 	}
 
 	if accessToken == "" || refreshToken == "" || csrfToken == "" {
-		return fmt.Errorf("failed to extract authentication tokens: accessToken=%t, refreshToken=%t, csrfToken=%t",
+		return nil, fmt.Errorf("failed to extract authentication tokens: accessToken=%t, refreshToken=%t, csrfToken=%t",
 			accessToken != "", refreshToken != "", csrfToken != "")
 	}
 
-	// Store tokens for subsequent requests
-	c.accessToken = accessToken
-	c.refreshToken = refreshToken
-	c.csrfToken = csrfToken
-
-	c.logger.WithFields(logrus.Fields{
-		"access_token_length":  len(c.accessToken),
-		"refresh_token_length": len(c.refreshToken),
-		"csrf_token":           c.csrfToken,
-	}).Debug("Successfully authenticated with Node.js API")
-
-	return nil
+	return &sessionTokens{accessToken: accessToken, refreshToken: refreshToken, csrfToken: csrfToken}, nil
 }
 
 // ensureAuthenticated ensures we have valid authentication tokens
@@ -221,7 +262,7 @@ func (c *NodeJSClient) makeAuthenticatedRequest(method, endpoint string) (*resty
 	var errorResp models.ErrorResponse
 
 	// Use manual cookie headers for reliable authentication - don't set result here
-	resp, err := c.client.R().
+	resp, err := c.client.Load().R().
 		SetError(&errorResp).
 		SetHeader("X-CSRF-TOKEN", csrfToken).
 		SetHeader("Cookie", fmt.Sprintf("accessToken=%s; refreshToken=%s", accessToken, refreshToken)).
@@ -241,7 +282,7 @@ func (c *NodeJSClient) makeAuthenticatedRequest(method, endpoint string) (*resty
 		newCsrfToken := c.csrfToken
 		c.authMutex.RUnlock()
 
-		resp, err = c.client.R().
+		resp, err = c.client.Load().R().
 			SetError(&errorResp).
 			SetHeader("X-CSRF-TOKEN", newCsrfToken).
 			SetHeader("Cookie", fmt.Sprintf("accessToken=%s; refreshToken=%s", newAccessToken, newRefreshToken)).
@@ -376,27 +417,54 @@ func (c *NodeJSClient) GetAllStudents(filters map[string]string) ([]models.Stude
 	return apiResp.Data, nil
 }
 
-// HealthCheck performs a health check against the Node.js API
-func (c *NodeJSClient) HealthCheck() error {
-	// For health check, we'll use a simple request to the base API URL
-	// without authentication to avoid circular dependencies
-	healthClient := resty.New().
-		SetBaseURL(c.baseURL).
-		SetTimeout(5 * time.Second)
-
-	resp, err := healthClient.R().Get("/")
+// getJSON performs an authenticated GET and decodes the JSON body into out
+func (c *NodeJSClient) getJSON(endpoint string, out interface{}) error {
+	c.logger.WithField("endpoint", endpoint).Debug("Making authenticated request to Node.js API")
 
+	resp, err := c.makeAuthenticatedRequest("GET", endpoint)
 	if err != nil {
-		return fmt.Errorf("health check request failed: %w", err)
+		return fmt.Errorf("request failed: %w", err)
 	}
 
-	if resp.StatusCode() >= 500 {
-		return fmt.Errorf("Node.js API is experiencing server errors (status: %d)", resp.StatusCode())
+	c.logger.WithFields(logrus.Fields{
+		"status_code": resp.StatusCode(),
+		"body_size":   len(resp.Body()),
+	}).Debug("Received response from Node.js API")
+
+	if resp.IsError() {
+		var errorResp models.ErrorResponse
+		if err := json.Unmarshal(resp.Body(), &errorResp); err == nil && errorResp.Message != "" {
+			return &ClientError{
+				StatusCode: resp.StatusCode(),
+				Message:    errorResp.Message,
+				Details:    errorResp.Error,
+			}
+		}
+
+		return &ClientError{
+			StatusCode: resp.StatusCode(),
+			Message:    resp.Status(),
+			Details:    string(resp.Body()),
+		}
+	}
+
+	if err := json.Unmarshal(resp.Body(), out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
 	return nil
 }
 
+// HealthCheck verifies that the Node.js API accepts the service account by
+// logging in. The probe's tokens are discarded, so the session used for
+// report requests is left alone
+func (c *NodeJSClient) HealthCheck(ctx context.Context) error {
+	if _, err := c.login(ctx); err != nil {
+		return fmt.Errorf("backend login failed: %w", err)
+	}
+	return nil
+}
+
 // Close closes the client and cleans up resources
 func (c *NodeJSClient) Close() error {
 	// Resty client doesn't need explicit closing, but we can implement cleanup logic here
@@ -0,0 +1,14 @@
+package client
+
+import "student-report-service/internal/models"
+
+// GetDashboard retrieves the dashboard aggregates for the service account.
+// Headcounts are only filled in for admin accounts.
+func (c *NodeJSClient) GetDashboard() (*models.Dashboard, error) {
+	var dashboard models.Dashboard
+	if err := c.getJSON("/dashboard", &dashboard); err != nil {
+		return nil, err
+	}
+
+	return &dashboard, nil
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"fmt"
+
+	"student-report-service/internal/models"
+)
+
+// GetLeaveHistory retrieves the leave requests of a user. The backend only
+// honours userId for admin service accounts; others get their own history.
+func (c *NodeJSClient) GetLeaveHistory(userID int) ([]models.LeaveRequest, error) {
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	var resp models.LeaveHistoryResponse
+	if err := c.getJSON(fmt.Sprintf("/leave/request?userId=%d", userID), &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.LeaveHistory, nil
+}
+
+// GetPendingLeaves retrieves all leave requests awaiting review
+func (c *NodeJSClient) GetPendingLeaves() ([]models.LeaveRequest, error) {
+	var resp models.PendingLeavesResponse
+	if err := c.getJSON("/leave/pending", &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.PendingLeaves, nil
+}
+
+// GetLeavePolicies retrieves all leave policies
+func (c *NodeJSClient) GetLeavePolicies() ([]models.LeavePolicy, error) {
+	var resp models.LeavePoliciesResponse
+	if err := c.getJSON("/leave/policies", &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.LeavePolicies, nil
+}
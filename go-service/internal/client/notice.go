@@ -0,0 +1,51 @@
+package client
+
+import (
+	"fmt"
+
+	"student-report-service/internal/models"
+)
+
+// GetNotices retrieves the notices visible to a user. A zero userID returns the
+// notices visible to the service account; other users require an admin account.
+func (c *NodeJSClient) GetNotices(userID int) ([]models.Notice, error) {
+	if userID < 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+
+	endpoint := "/notices"
+	if userID > 0 {
+		endpoint = fmt.Sprintf("/notices?userId=%d", userID)
+	}
+
+	var resp models.NoticesResponse
+	if err := c.getJSON(endpoint, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Notices, nil
+}
+
+// GetNoticeByID retrieves a notice with its recipient settings
+func (c *NodeJSClient) GetNoticeByID(noticeID int) (*models.NoticeDetail, error) {
+	if noticeID <= 0 {
+		return nil, fmt.Errorf("invalid notice ID: %d", noticeID)
+	}
+
+	var notice models.NoticeDetail
+	if err := c.getJSON(fmt.Sprintf("/notices/%d", noticeID), &notice); err != nil {
+		return nil, err
+	}
+
+	return &notice, nil
+}
+
+// GetNoticeRecipients retrieves the configured notice recipient groups
+func (c *NodeJSClient) GetNoticeRecipients() ([]models.NoticeRecipient, error) {
+	var resp models.NoticeRecipientsResponse
+	if err := c.getJSON("/notices/recipients", &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.NoticeRecipients, nil
+}
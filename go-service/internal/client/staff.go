@@ -0,0 +1,44 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+
+	"student-report-service/internal/models"
+)
+
+// GetStaffByID retrieves a staff member's full profile
+func (c *NodeJSClient) GetStaffByID(staffID int) (*models.Staff, error) {
+	if staffID <= 0 {
+		return nil, fmt.Errorf("invalid staff ID: %d", staffID)
+	}
+
+	var staff models.Staff
+	if err := c.getJSON(fmt.Sprintf("/staffs/%d", staffID), &staff); err != nil {
+		return nil, err
+	}
+
+	return &staff, nil
+}
+
+// GetAllStaff retrieves staff members, optionally filtered by userId, roleId or name
+func (c *NodeJSClient) GetAllStaff(filters map[string]string) ([]models.StaffListItem, error) {
+	endpoint := "/staffs"
+
+	query := url.Values{}
+	for key, value := range filters {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var resp models.StaffListResponse
+	if err := c.getJSON(endpoint, &resp); err != nil {
+		return nil, err
+	}
+
+	return resp.Staffs, nil
+}
@@ -1,132 +1,167 @@
 package config
 
-import (
-	"os"
-	"strconv"
-	"time"
-)
+import "time"
 
-// Config holds all configuration for the application
+// Config holds all configuration for the application. Every setting has a
+// yaml key for the config file, an env variable overriding it and a default.
+// Settings tagged reload:"restart" only take effect when the service starts;
+// settings tagged secret:"true" are masked when the configuration is shown
 type Config struct {
-	Server  ServerConfig
-	NodeJS  NodeJSConfig
-	Report  ReportConfig
-	Logging LoggingConfig
+	Server   ServerConfig   `yaml:"server"`
+	NodeJS   NodeJSConfig   `yaml:"nodejs"`
+	Report   ReportConfig   `yaml:"report"`
+	Audit    AuditConfig    `yaml:"audit"`
+	Schedule ScheduleConfig `yaml:"schedule"`
+	SMTP     SMTPConfig     `yaml:"smtp"`
+	Logging  LoggingConfig  `yaml:"logging"`
+	Tenants  TenantsConfig  `yaml:"tenants"`
+	Health   HealthConfig   `yaml:"health"`
+
+	// secretFiles are the files secret settings were read from, watched so
+	// rotated secrets are reloaded
+	secretFiles []string
 }
 
 // ServerConfig contains server-related configuration
 type ServerConfig struct {
-	Port         string
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
-	IdleTimeout  time.Duration
+	Port         string        `yaml:"port" env:"GO_SERVICE_PORT" default:"8080" reload:"restart"`
+	ReadTimeout  time.Duration `yaml:"read_timeout" env:"READ_TIMEOUT" default:"10s" reload:"restart"`
+	WriteTimeout time.Duration `yaml:"write_timeout" env:"WRITE_TIMEOUT" default:"10s" reload:"restart"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout" env:"IDLE_TIMEOUT" default:"60s" reload:"restart"`
+
+	// RateLimit is the number of API requests a tenant may make per minute,
+	// 0 for no limit. RateLimitBurst requests may be made at once
+	RateLimit      int `yaml:"rate_limit" env:"RATE_LIMIT_PER_MINUTE" default:"0" reload:"restart"`
+	RateLimitBurst int `yaml:"rate_limit_burst" env:"RATE_LIMIT_BURST" default:"10" reload:"restart"`
+
+	// ConfigWatchInterval is how often the config, tenants and secret files
+	// are checked for changes to reload, 0 to reload only on SIGHUP
+	ConfigWatchInterval time.Duration `yaml:"config_watch_interval" env:"CONFIG_WATCH_INTERVAL" default:"10s" reload:"restart"`
 }
 
 // NodeJSConfig contains configuration for Node.js API client
 type NodeJSConfig struct {
-	BaseURL       string        `env:"NODEJS_API_URL" default:"http://localhost:5007/api/v1"`
-	Timeout       time.Duration `env:"NODEJS_TIMEOUT" default:"30s"`
-	RetryAttempts int           `env:"NODEJS_RETRY_ATTEMPTS" default:"3"`
-	RetryDelay    time.Duration `env:"NODEJS_RETRY_DELAY" default:"1s"`
-
-	// Authentication for service-to-service communication
-	ServiceUsername string `env:"NODEJS_SERVICE_USERNAME" default:"admin@school-admin.com"`
-	ServicePassword string `env:"NODEJS_SERVICE_PASSWORD" default:"3OU4zn3q6Zh9"`
+	BaseURL       string        `yaml:"base_url" env:"NODEJS_API_URL" default:"http://localhost:5007/api/v1"`
+	Timeout       time.Duration `yaml:"timeout" env:"NODEJS_TIMEOUT" default:"30s"`
+	RetryAttempts int           `yaml:"retry_attempts" env:"NODEJS_RETRY_ATTEMPTS" default:"3"`
+	RetryDelay    time.Duration `yaml:"retry_delay" env:"NODEJS_RETRY_DELAY" default:"1s"`
+
+	// Authentication for service-to-service communication. There are no
+	// defaults; the password is usually supplied via NODEJS_SERVICE_PASSWORD_FILE
+	ServiceUsername string `yaml:"service_username" env:"NODEJS_SERVICE_USERNAME"`
+	ServicePassword string `yaml:"service_password" env:"NODEJS_SERVICE_PASSWORD" secret:"true"`
+	// AllowDemoCredentials accepts the demo account's published password,
+	// for local development against the seed database only
+	AllowDemoCredentials bool `yaml:"allow_demo_credentials" env:"NODEJS_ALLOW_DEMO_CREDENTIALS" default:"false"`
 }
 
 // ReportConfig contains PDF report generation configuration
 type ReportConfig struct {
-	OutputDir     string
-	MaxFileSize   int64
-	Cleanup       bool
-	CleanupAfter  time.Duration
-	WatermarkText string
-}
+	OutputDir         string                   `yaml:"output_dir" env:"REPORT_OUTPUT_DIR" default:"./reports" reload:"restart"`
+	MaxFileSize       int64                    `yaml:"max_file_size" env:"REPORT_MAX_FILE_SIZE" default:"10485760"`
+	Cleanup           bool                     `yaml:"cleanup" env:"REPORT_CLEANUP" default:"true"`
+	CleanupAfter      time.Duration            `yaml:"cleanup_after" env:"REPORT_CLEANUP_AFTER" default:"24h"`
+	CleanupInterval   time.Duration            `yaml:"cleanup_interval" env:"REPORT_CLEANUP_INTERVAL" default:"1h" reload:"restart"`
+	Retention         map[string]time.Duration `yaml:"retention" env:"REPORT_RETENTION"`
+	TagRetention      map[string]time.Duration `yaml:"tag_retention" env:"REPORT_TAG_RETENTION"`
+	DiskQuota         int64                    `yaml:"disk_quota" env:"REPORT_DISK_QUOTA" default:"0"`
+	WatermarkText     string                   `yaml:"watermark" env:"REPORT_WATERMARK" default:"Student Management System - Confidential"`
+	SchoolName        string                   `yaml:"school_name" env:"REPORT_SCHOOL_NAME"`
+	DefaultRole       string                   `yaml:"default_role" env:"REPORT_DEFAULT_ROLE" default:"public"`
+	LeaveAllowances   map[string]float64       `yaml:"leave_allowances" env:"LEAVE_ALLOWANCES"`
+	SnapshotPath      string                   `yaml:"snapshot_path" env:"DASHBOARD_SNAPSHOT_PATH" default:"./data/dashboard_snapshots.json" reload:"restart"`
+	AnalyticsCacheTTL time.Duration            `yaml:"analytics_cache_ttl" env:"ANALYTICS_CACHE_TTL" default:"15m"`
+	RequiredFields    []string                 `yaml:"required_fields" env:"STUDENT_REQUIRED_FIELDS"`
+	Archival          bool                     `yaml:"archival" env:"REPORT_ARCHIVAL" default:"false"`
 
-// LoggingConfig contains logging configuration
-type LoggingConfig struct {
-	Level  string
-	Format string
-}
+	// MaxJobs is the number of reports rendered at once, 0 for no limit.
+	// Up to MaxQueuedJobs more wait for a slot; further reports are refused
+	MaxJobs       int `yaml:"max_jobs" env:"REPORT_MAX_JOBS" default:"4" reload:"restart"`
+	MaxQueuedJobs int `yaml:"max_queued_jobs" env:"REPORT_MAX_QUEUED_JOBS" default:"16" reload:"restart"`
 
-// Load loads configuration from environment variables with sensible defaults
-func Load() *Config {
-	return &Config{
-		Server: ServerConfig{
-			Port:         getEnv("GO_SERVICE_PORT", "8080"),
-			ReadTimeout:  getDurationEnv("READ_TIMEOUT", 10*time.Second),
-			WriteTimeout: getDurationEnv("WRITE_TIMEOUT", 10*time.Second),
-			IdleTimeout:  getDurationEnv("IDLE_TIMEOUT", 60*time.Second),
-		},
-		NodeJS: NodeJSConfig{
-			BaseURL:         getEnv("NODEJS_API_URL", "http://localhost:5007/api/v1"),
-			Timeout:         getDurationEnv("NODEJS_TIMEOUT", 30*time.Second),
-			RetryAttempts:   getIntEnv("NODEJS_RETRY_ATTEMPTS", 3),
-			RetryDelay:      getDurationEnv("NODEJS_RETRY_DELAY", 1*time.Second),
-			ServiceUsername: getEnv("NODEJS_SERVICE_USERNAME", "admin@school-admin.com"),
-			ServicePassword: getEnv("NODEJS_SERVICE_PASSWORD", "3OU4zn3q6Zh9"),
-		},
-		Report: ReportConfig{
-			OutputDir:     getEnv("REPORT_OUTPUT_DIR", "./reports"),
-			MaxFileSize:   getInt64Env("REPORT_MAX_FILE_SIZE", 10*1024*1024), // 10MB
-			Cleanup:       getBoolEnv("REPORT_CLEANUP", true),
-			CleanupAfter:  getDurationEnv("REPORT_CLEANUP_AFTER", 24*time.Hour),
-			WatermarkText: getEnv("REPORT_WATERMARK", "Student Management System - Confidential"),
-		},
-		Logging: LoggingConfig{
-			Level:  getEnv("LOG_LEVEL", "info"),
-			Format: getEnv("LOG_FORMAT", "json"),
-		},
-	}
+	// LogoPath is a PNG or JPEG file path or http(s) URL drawn in report headers
+	LogoPath string `yaml:"logo" env:"REPORT_LOGO" reload:"restart"`
+	// PhotoDir holds student photos named <student id>.jpg, .jpeg or .png
+	PhotoDir string `yaml:"photo_dir" env:"REPORT_PHOTO_DIR"`
 }
 
-// Helper functions for environment variable parsing
+// PhotosEnabled reports whether student reports show a photo or placeholder
+func (c *ReportConfig) PhotosEnabled() bool {
+	return c.PhotoDir != ""
+}
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+// AuditConfig contains audit log configuration
+type AuditConfig struct {
+	Enabled bool   `yaml:"enabled" env:"AUDIT_ENABLED" default:"true" reload:"restart"`
+	Path    string `yaml:"path" env:"AUDIT_LOG_PATH" default:"./audit/audit.jsonl" reload:"restart"`
+	Chained bool   `yaml:"hash_chain" env:"AUDIT_HASH_CHAIN" default:"false" reload:"restart"`
 }
 
-func getIntEnv(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
-		}
-	}
-	return defaultValue
+// ScheduleConfig contains recurring report schedule configuration
+type ScheduleConfig struct {
+	Enabled      bool          `yaml:"enabled" env:"SCHEDULES_ENABLED" default:"true" reload:"restart"`
+	StorePath    string        `yaml:"store_path" env:"SCHEDULES_STORE_PATH" default:"./data/schedules.json" reload:"restart"`
+	PollInterval time.Duration `yaml:"poll_interval" env:"SCHEDULES_POLL_INTERVAL" default:"30s" reload:"restart"`
 }
 
-func getInt64Env(key string, defaultValue int64) int64 {
-	if value := os.Getenv(key); value != "" {
-		if int64Value, err := strconv.ParseInt(value, 10, 64); err == nil {
-			return int64Value
-		}
-	}
-	return defaultValue
+// SMTPConfig contains email delivery configuration
+type SMTPConfig struct {
+	Enabled         bool          `yaml:"enabled" env:"SMTP_ENABLED" default:"false" reload:"restart"`
+	Host            string        `yaml:"host" env:"SMTP_HOST" default:"localhost" reload:"restart"`
+	Port            int           `yaml:"port" env:"SMTP_PORT" default:"1025" reload:"restart"`
+	Username        string        `yaml:"username" env:"SMTP_USERNAME" reload:"restart"`
+	Password        string        `yaml:"password" env:"SMTP_PASSWORD" reload:"restart" secret:"true"`
+	From            string        `yaml:"from" env:"SMTP_FROM" default:"reports@school-admin.com" reload:"restart"`
+	RequireTLS      bool          `yaml:"require_tls" env:"SMTP_REQUIRE_TLS" default:"false" reload:"restart"`
+	Timeout         time.Duration `yaml:"timeout" env:"SMTP_TIMEOUT" default:"30s" reload:"restart"`
+	RetryAttempts   int           `yaml:"retry_attempts" env:"SMTP_RETRY_ATTEMPTS" default:"3" reload:"restart"`
+	RetryDelay      time.Duration `yaml:"retry_delay" env:"SMTP_RETRY_DELAY" default:"5s" reload:"restart"`
+	SubjectTemplate string        `yaml:"subject_template" env:"SMTP_SUBJECT_TEMPLATE" default:"Student report for {{.StudentName}}" reload:"restart"`
+	// BodyTemplate defaults to defaultEmailBody, which is too long for a tag
+	BodyTemplate string `yaml:"body_template" env:"SMTP_BODY_TEMPLATE" reload:"restart"`
+	StatusPath   string `yaml:"status_path" env:"SMTP_STATUS_PATH" default:"./data/deliveries.jsonl" reload:"restart"`
+	// AllowedDomains lists the domains reports may be sent to by address.
+	// Reports rendered with the full profile may only go to addresses in
+	// FullProfileDomains as well, and to none when it is empty
+	AllowedDomains     []string `yaml:"allowed_domains" env:"SMTP_ALLOWED_DOMAINS"`
+	FullProfileDomains []string `yaml:"full_profile_domains" env:"SMTP_FULL_PROFILE_DOMAINS"`
 }
 
-func getBoolEnv(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
-		if boolValue, err := strconv.ParseBool(value); err == nil {
-			return boolValue
-		}
-	}
-	return defaultValue
+// TenantsConfig contains multi-tenant configuration
+type TenantsConfig struct {
+	// File lists the schools served by this instance. Without it the service
+	// runs as a single tenant using this configuration
+	File string `yaml:"file" env:"TENANTS_FILE" reload:"restart"`
 }
 
-func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
-		if duration, err := time.ParseDuration(value); err == nil {
-			return duration
-		}
-	}
-	return defaultValue
+// HealthConfig contains readiness check configuration
+type HealthConfig struct {
+	// CacheTTL is how long a check result is reused before the check runs
+	// again; CheckTimeout bounds each check
+	CacheTTL     time.Duration `yaml:"cache_ttl" env:"HEALTH_CACHE_TTL" default:"30s" reload:"restart"`
+	CheckTimeout time.Duration `yaml:"check_timeout" env:"HEALTH_CHECK_TIMEOUT" default:"5s" reload:"restart"`
+
+	// The output directory's file system must keep MinFreeSpace bytes and
+	// MinFreePercent percent free for the service to be ready
+	MinFreeSpace   int64   `yaml:"min_free_space" env:"HEALTH_MIN_FREE_SPACE" default:"104857600"`
+	MinFreePercent float64 `yaml:"min_free_percent" env:"HEALTH_MIN_FREE_PERCENT" default:"5"`
 }
 
-// Validate validates the configuration
-func (c *Config) Validate() error {
-	// Add validation logic here if needed
-	return nil
+// LoggingConfig contains logging configuration
+type LoggingConfig struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL" default:"info"`
+	Format string `yaml:"format" env:"LOG_FORMAT" default:"json"`
 }
+
+// defaultEmailBody is the text/template used for report emails
+const defaultEmailBody = `Hello,
+
+Please find attached the student report for {{.StudentName}}.
+
+Report ID: {{.ReportID}}
+Generated: {{.GeneratedAt.Format "January 2, 2006 at 15:04 MST"}}
+
+This report is confidential and intended for authorized personnel only.
+
+Student Management System
+`
@@ -0,0 +1,207 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// isolate points the writable paths at a temporary directory, clears
+// variables the developer's environment may set and supplies the required
+// service credentials
+func isolate(t *testing.T) string {
+	dir := t.TempDir()
+	for _, s := range settingsOf(&Config{}) {
+		if name := s.tag.Get("env"); name != "" {
+			t.Setenv(name, "")
+			t.Setenv(name+"_FILE", "")
+		}
+	}
+	t.Setenv("REPORT_OUTPUT_DIR", filepath.Join(dir, "reports"))
+	t.Setenv("NODEJS_SERVICE_USERNAME", "reports@school.edu")
+	t.Setenv("NODEJS_SERVICE_PASSWORD", "test-password")
+	return dir
+}
+
+func writeConfigFile(t *testing.T, dir, content string) string {
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	isolate(t)
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+
+	assert.Equal(t, "8080", cfg.Server.Port)
+	assert.Equal(t, 10*time.Second, cfg.Server.ReadTimeout)
+	assert.Equal(t, "http://localhost:5007/api/v1", cfg.NodeJS.BaseURL)
+	assert.Equal(t, 3, cfg.NodeJS.RetryAttempts)
+	assert.Equal(t, int64(10*1024*1024), cfg.Report.MaxFileSize)
+	assert.True(t, cfg.Report.Cleanup)
+	assert.Equal(t, 15*time.Minute, cfg.Report.AnalyticsCacheTTL)
+	assert.Empty(t, cfg.Report.Retention)
+	assert.Equal(t, defaultEmailBody, cfg.SMTP.BodyTemplate)
+	assert.Equal(t, "info", cfg.Logging.Level)
+}
+
+func TestLoad_Layers(t *testing.T) {
+	dir := isolate(t)
+	path := writeConfigFile(t, dir, `
+server:
+  port: "9090"
+  read_timeout: 5s
+nodejs:
+  base_url: https://api.school.edu/api/v1
+  retry_attempts: 5
+report:
+  watermark: From file
+  retention:
+    student: 720h
+  leave_allowances:
+    Sick Leave: 12
+  required_fields: [dob, roll]
+logging:
+  level: debug
+`)
+	t.Setenv("NODEJS_RETRY_ATTEMPTS", "7")
+	t.Setenv("REPORT_RETENTION", "leave=168h, notice=24h")
+	t.Setenv("LOG_FORMAT", "text")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "9090", cfg.Server.Port, "file overrides default")
+	assert.Equal(t, 5*time.Second, cfg.Server.ReadTimeout)
+	assert.Equal(t, 10*time.Second, cfg.Server.WriteTimeout, "default kept when the file leaves it out")
+	assert.Equal(t, "https://api.school.edu/api/v1", cfg.NodeJS.BaseURL)
+	assert.Equal(t, 7, cfg.NodeJS.RetryAttempts, "env overrides file")
+	assert.Equal(t, "From file", cfg.Report.WatermarkText)
+	assert.Equal(t, map[string]time.Duration{"leave": 168 * time.Hour, "notice": 24 * time.Hour}, cfg.Report.Retention,
+		"env replaces the whole map")
+	assert.Equal(t, map[string]float64{"Sick Leave": 12}, cfg.Report.LeaveAllowances)
+	assert.Equal(t, []string{"dob", "roll"}, cfg.Report.RequiredFields)
+	assert.Equal(t, "debug", cfg.Logging.Level)
+	assert.Equal(t, "text", cfg.Logging.Format)
+}
+
+func TestLoad_ReportsAllProblems(t *testing.T) {
+	dir := isolate(t)
+	path := writeConfigFile(t, dir, `
+nodejs:
+  base_url: localhost:5007
+report:
+  max_file_size: -1
+  disk_quota: -5
+logging:
+  level: verbose
+`)
+	t.Setenv("READ_TIMEOUT", "ten seconds")
+	t.Setenv("REPORT_CLEANUP", "sometimes")
+	t.Setenv("LEAVE_ALLOWANCES", "Sick Leave")
+
+	_, err := Load(path)
+	require.Error(t, err)
+
+	var cfgErr *Error
+	require.True(t, errors.As(err, &cfgErr))
+	expected := []string{
+		`READ_TIMEOUT: invalid value "ten seconds"`,
+		`REPORT_CLEANUP: invalid value "sometimes"`,
+		`LEAVE_ALLOWANCES: invalid value "Sick Leave": expected key=value`,
+		`nodejs.base_url "localhost:5007" is not an http(s) URL`,
+		`report.max_file_size must be positive, got -1`,
+		`report.disk_quota must not be negative, got -5`,
+		`logging.level "verbose" is not a log level`,
+	}
+	require.Len(t, cfgErr.Problems, len(expected), err.Error())
+	for i, problem := range expected {
+		assert.Contains(t, cfgErr.Problems[i], problem)
+	}
+}
+
+func TestLoad_FileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		errorMsg string
+	}{
+		{name: "Unknown key", content: "server:\n  prot: \"9090\"\n", errorMsg: "field prot not found"},
+		{name: "Wrong type", content: "report:\n  max_file_size: lots\n", errorMsg: "cannot unmarshal"},
+		{name: "Bad duration", content: "nodejs:\n  timeout: soon\n", errorMsg: "failed to parse config file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := isolate(t)
+			_, err := Load(writeConfigFile(t, dir, tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+
+	t.Run("Missing file", func(t *testing.T) {
+		dir := isolate(t)
+		_, err := Load(filepath.Join(dir, "missing.yaml"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read config file")
+	})
+
+	t.Run("Empty file", func(t *testing.T) {
+		dir := isolate(t)
+		_, err := Load(writeConfigFile(t, dir, ""))
+		assert.NoError(t, err)
+	})
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		errorMsg string
+	}{
+		{name: "Port out of range", modify: func(c *Config) { c.Server.Port = "70000" }, errorMsg: "server.port"},
+		{name: "Negative rate limit", modify: func(c *Config) { c.Server.RateLimit = -1 }, errorMsg: "server.rate_limit must not be negative"},
+		{name: "Unsupported URL scheme", modify: func(c *Config) { c.NodeJS.BaseURL = "ftp://api" }, errorMsg: "nodejs.base_url"},
+		{name: "Zero backend timeout", modify: func(c *Config) { c.NodeJS.Timeout = 0 }, errorMsg: "nodejs.timeout must be positive"},
+		{name: "Negative retention", modify: func(c *Config) { c.Report.Retention = map[string]time.Duration{"leave": -time.Hour} }, errorMsg: "report.retention.leave"},
+		{name: "Output dir is a file", modify: func(c *Config) {
+			file := filepath.Join(filepath.Dir(c.Report.OutputDir), "file")
+			os.WriteFile(file, nil, 0644)
+			c.Report.OutputDir = file
+		}, errorMsg: "report.output_dir"},
+		{name: "Invalid logo URL", modify: func(c *Config) { c.Report.LogoPath = "https://" }, errorMsg: "report.logo"},
+		{name: "Schedules without interval", modify: func(c *Config) { c.Schedule.PollInterval = 0 }, errorMsg: "schedule.poll_interval"},
+		{name: "Invalid sender", modify: func(c *Config) { c.SMTP.Enabled = true; c.SMTP.From = "reports" }, errorMsg: "smtp.from"},
+		{name: "Invalid template", modify: func(c *Config) { c.SMTP.Enabled = true; c.SMTP.SubjectTemplate = "{{.Name" }, errorMsg: "smtp.subject_template"},
+		{name: "Unknown log format", modify: func(c *Config) { c.Logging.Format = "xml" }, errorMsg: "logging.format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolate(t)
+			cfg, err := Load("")
+			require.NoError(t, err)
+			require.NoError(t, cfg.Validate())
+
+			tt.modify(cfg)
+			err = cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestExampleConfigFile(t *testing.T) {
+	isolate(t)
+
+	_, err := Load(filepath.Join("..", "..", "config.example.yaml"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Error lists every problem found while loading or validating the configuration
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Load builds the configuration in layers: the default struct tags, then the
+// YAML file at path when it is not empty, then non-empty environment
+// variables. Secret settings may also be read from the file named by a
+// NAME_FILE variable or written as ${scheme:key} provider references. The
+// result is validated, and every parse or validation problem is reported
+// together in an *Error
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	var problems []string
+
+	settings := settingsOf(cfg)
+	for _, s := range settings {
+		if raw, ok := s.tag.Lookup("default"); ok {
+			if err := setValue(s.value, raw); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid default %q: %v", s.key, raw, err))
+			}
+		}
+	}
+	cfg.SMTP.BodyTemplate = defaultEmailBody
+
+	if path != "" {
+		if err := loadFile(cfg, path); err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	for _, s := range settings {
+		name := s.tag.Get("env")
+		if name == "" {
+			continue
+		}
+		if raw := os.Getenv(name); raw != "" {
+			if err := setValue(s.value, raw); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid value %q: %v", name, raw, err))
+			}
+		}
+	}
+
+	problems = append(problems, cfg.loadSecrets(settings)...)
+
+	problems = append(problems, cfg.problems()...)
+	if len(problems) > 0 {
+		return nil, &Error{Problems: problems}
+	}
+	return cfg, nil
+}
+
+// loadFile decodes the YAML file at path over cfg. Keys the file leaves out
+// keep their defaults; unknown keys are rejected
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+// setting is one configurable field of Config
+type setting struct {
+	key   string // dotted yaml key, e.g. server.port
+	tag   reflect.StructTag
+	value reflect.Value
+}
+
+// settingsOf lists the settings of cfg in declaration order
+func settingsOf(cfg *Config) []setting {
+	var settings []setting
+	root := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < root.NumField(); i++ {
+		if !root.Type().Field(i).IsExported() {
+			continue
+		}
+		section := root.Field(i)
+		sectionKey := root.Type().Field(i).Tag.Get("yaml")
+		for j := 0; j < section.NumField(); j++ {
+			field := section.Type().Field(j)
+			settings = append(settings, setting{
+				key:   sectionKey + "." + field.Tag.Get("yaml"),
+				tag:   field.Tag,
+				value: section.Field(j),
+			})
+		}
+	}
+	return settings
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// setValue parses raw into v. Lists are comma-separated and maps are
+// comma-separated key=value pairs, e.g. "student=720h,leave=168h"
+func setValue(v reflect.Value, raw string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	case reflect.Map:
+		m := reflect.MakeMap(v.Type())
+		for _, pair := range strings.Split(raw, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			key, value, found := strings.Cut(pair, "=")
+			if !found {
+				return fmt.Errorf("expected key=value, got %q", strings.TrimSpace(pair))
+			}
+			elem := reflect.New(v.Type().Elem()).Elem()
+			if err := setValue(elem, strings.TrimSpace(value)); err != nil {
+				return fmt.Errorf("%s: %w", strings.TrimSpace(key), err)
+			}
+			m.SetMapIndex(reflect.ValueOf(strings.TrimSpace(key)), elem)
+		}
+		v.Set(m)
+	default:
+		return fmt.Errorf("unsupported setting type %s", v.Type())
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"reflect"
+	"time"
+)
+
+// maskedValue replaces secrets when the configuration is shown
+const maskedValue = "********"
+
+// Changes lists the settings that differ between two configurations by key
+type Changes struct {
+	// Applied settings take effect immediately
+	Applied []string `json:"applied,omitempty"`
+	// Pending settings keep their current value until the service restarts
+	Pending []string `json:"pending,omitempty"`
+}
+
+// Empty reports whether nothing changed
+func (c Changes) Empty() bool {
+	return len(c.Applied) == 0 && len(c.Pending) == 0
+}
+
+// Reconcile returns the configuration to switch to when next replaces
+// current. Restart-only settings keep their current value, so the result
+// describes what is actually in effect. Neither argument is modified
+func Reconcile(current, next *Config) (*Config, Changes) {
+	effective := *next
+	var changes Changes
+
+	currentSettings := settingsOf(current)
+	effectiveSettings := settingsOf(&effective)
+	for i, s := range effectiveSettings {
+		old := currentSettings[i].value
+		if reflect.DeepEqual(old.Interface(), s.value.Interface()) {
+			continue
+		}
+		if s.tag.Get("reload") == "restart" {
+			s.value.Set(old)
+			changes.Pending = append(changes.Pending, s.key)
+			continue
+		}
+		changes.Applied = append(changes.Applied, s.key)
+	}
+	return &effective, changes
+}
+
+// Masked returns the settings by section and key with secrets masked, in
+// a form suitable for JSON. Durations are shown as strings such as "1h0m0s"
+func (c *Config) Masked() map[string]map[string]interface{} {
+	sections := make(map[string]map[string]interface{})
+	root := reflect.ValueOf(c).Elem()
+	for i := 0; i < root.NumField(); i++ {
+		if !root.Type().Field(i).IsExported() {
+			continue
+		}
+		section := root.Field(i)
+		values := make(map[string]interface{}, section.NumField())
+		for j := 0; j < section.NumField(); j++ {
+			field := section.Type().Field(j)
+			value := section.Field(j)
+			if field.Tag.Get("secret") == "true" {
+				if value.String() != "" {
+					values[field.Tag.Get("yaml")] = maskedValue
+				} else {
+					values[field.Tag.Get("yaml")] = ""
+				}
+				continue
+			}
+			values[field.Tag.Get("yaml")] = displayValue(value)
+		}
+		sections[root.Type().Field(i).Tag.Get("yaml")] = values
+	}
+	return sections
+}
+
+// displayValue formats durations, including those in maps, as strings
+func displayValue(v reflect.Value) interface{} {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+	if v.Kind() == reflect.Map && v.Type().Elem() == durationType {
+		result := make(map[string]string, v.Len())
+		for _, key := range v.MapKeys() {
+			result[key.String()] = time.Duration(v.MapIndex(key).Int()).String()
+		}
+		return result
+	}
+	return v.Interface()
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile(t *testing.T) {
+	isolate(t)
+	current, err := Load("")
+	require.NoError(t, err)
+
+	next := *current
+	next.Report.WatermarkText = "DRAFT"
+	next.Report.CleanupAfter = 48 * time.Hour
+	next.NodeJS.RetryAttempts = 5
+	next.Logging.Level = "debug"
+	next.Server.Port = "9090"
+	next.Report.OutputDir = "/elsewhere"
+
+	effective, changes := Reconcile(current, &next)
+
+	assert.ElementsMatch(t, []string{"nodejs.retry_attempts", "report.cleanup_after", "report.watermark", "logging.level"}, changes.Applied)
+	assert.ElementsMatch(t, []string{"server.port", "report.output_dir"}, changes.Pending)
+
+	assert.Equal(t, "DRAFT", effective.Report.WatermarkText)
+	assert.Equal(t, 5, effective.NodeJS.RetryAttempts)
+	assert.Equal(t, "debug", effective.Logging.Level)
+	assert.Equal(t, current.Server.Port, effective.Server.Port, "restart-only settings keep their value")
+	assert.Equal(t, current.Report.OutputDir, effective.Report.OutputDir)
+
+	assert.Equal(t, "9090", next.Server.Port, "next is not modified")
+	assert.NotEqual(t, "DRAFT", current.Report.WatermarkText, "current is not modified")
+
+	_, unchanged := Reconcile(current, current)
+	assert.True(t, unchanged.Empty())
+}
+
+func TestConfig_Masked(t *testing.T) {
+	isolate(t)
+	cfg, err := Load("")
+	require.NoError(t, err)
+	cfg.NodeJS.ServicePassword = "s3cret"
+	cfg.SMTP.Password = ""
+	cfg.Report.Retention = map[string]time.Duration{"leave": 168 * time.Hour}
+
+	masked := cfg.Masked()
+
+	assert.Equal(t, "********", masked["nodejs"]["service_password"])
+	assert.Equal(t, "", masked["smtp"]["password"], "unset secrets are shown as unset")
+	assert.Equal(t, "30s", masked["nodejs"]["timeout"])
+	assert.Equal(t, map[string]string{"leave": "168h0m0s"}, masked["report"]["retention"])
+	assert.Equal(t, cfg.NodeJS.BaseURL, masked["nodejs"]["base_url"])
+	assert.NotContains(t, masked, "")
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// SecretProvider looks up secrets kept outside the configuration, such as in
+// a vault. A secret setting written as ${scheme:key} is resolved by the
+// provider registered for scheme
+type SecretProvider interface {
+	Secret(key string) (string, error)
+}
+
+var (
+	secretProvidersMu sync.RWMutex
+	secretProviders   = map[string]SecretProvider{
+		"env":  envSecrets{},
+		"file": fileSecrets{},
+	}
+
+	// secretReference matches ${scheme:key}
+	secretReference = regexp.MustCompile(`^\$\{([a-z][a-z0-9]*):(.+)\}$`)
+)
+
+// RegisterSecretProvider makes a provider available for ${scheme:key}
+// references. The env and file schemes are built in
+func RegisterSecretProvider(scheme string, provider SecretProvider) {
+	secretProvidersMu.Lock()
+	defer secretProvidersMu.Unlock()
+	secretProviders[scheme] = provider
+}
+
+// ResolveSecret returns value unchanged, or the secret it references when it
+// has the form ${scheme:key}
+func ResolveSecret(value string) (string, error) {
+	match := secretReference.FindStringSubmatch(value)
+	if match == nil {
+		return value, nil
+	}
+
+	secretProvidersMu.RLock()
+	provider, ok := secretProviders[match[1]]
+	secretProvidersMu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("no secret provider registered for %q", match[1])
+	}
+
+	secret, err := provider.Secret(match[2])
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s secret: %w", match[1], err)
+	}
+	return secret, nil
+}
+
+// ReadSecretFile reads a secret such as a Docker or Kubernetes secret mount,
+// dropping the trailing newline editors add
+func ReadSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	secret := strings.TrimRight(string(data), "\r\n")
+	if secret == "" {
+		return "", fmt.Errorf("secret file %s is empty", path)
+	}
+	return secret, nil
+}
+
+// LookupSecretEnv returns the secret in the environment variable name, or
+// read from the file named by name_FILE. Setting both is an error
+func LookupSecretEnv(name string) (string, error) {
+	path := os.Getenv(name + "_FILE")
+	if path == "" {
+		return os.Getenv(name), nil
+	}
+	if os.Getenv(name) != "" {
+		return "", fmt.Errorf("set either %s or %s_FILE, not both", name, name)
+	}
+	secret, err := ReadSecretFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%s_FILE: %w", name, err)
+	}
+	return secret, nil
+}
+
+// Secrets returns the values of the secret settings that are set, so they
+// can be scrubbed from logs
+func (c *Config) Secrets() []string {
+	var secrets []string
+	for _, s := range settingsOf(c) {
+		if s.tag.Get("secret") == "true" && s.value.String() != "" {
+			secrets = append(secrets, s.value.String())
+		}
+	}
+	return secrets
+}
+
+// SecretFiles returns the files the secret settings were read from
+func (c *Config) SecretFiles() []string {
+	return c.secretFiles
+}
+
+// AddSecretFiles records further files secrets were read from, such as the
+// password files of tenants
+func (c *Config) AddSecretFiles(paths ...string) {
+	// Copy first, as configurations derived from one base share the slice
+	c.secretFiles = append(append([]string(nil), c.secretFiles...), paths...)
+}
+
+// SecretSourceFiles lists the files a secret is read from: the file named by
+// the name_FILE variable and the file a ${file:/path} value references
+func SecretSourceFiles(name, value string) []string {
+	var paths []string
+	if path := os.Getenv(name + "_FILE"); path != "" {
+		paths = append(paths, path)
+	}
+	if match := secretReference.FindStringSubmatch(value); match != nil && match[1] == "file" {
+		paths = append(paths, match[2])
+	}
+	return paths
+}
+
+// loadSecrets applies NAME_FILE variables and resolves ${scheme:key}
+// references for the secret settings, recording the files read
+func (c *Config) loadSecrets(settings []setting) []string {
+	var problems []string
+	for _, s := range settings {
+		if s.tag.Get("secret") != "true" {
+			continue
+		}
+
+		name := s.tag.Get("env")
+		if os.Getenv(name+"_FILE") != "" {
+			secret, err := LookupSecretEnv(name)
+			if err != nil {
+				problems = append(problems, err.Error())
+				continue
+			}
+			s.value.SetString(secret)
+		}
+		c.secretFiles = append(c.secretFiles, SecretSourceFiles(name, s.value.String())...)
+
+		secret, err := ResolveSecret(s.value.String())
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", s.key, err))
+			continue
+		}
+		s.value.SetString(secret)
+	}
+	return problems
+}
+
+// envSecrets resolves ${env:NAME} from the environment
+type envSecrets struct{}
+
+func (envSecrets) Secret(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
+
+// fileSecrets resolves ${file:/path} from a file
+type fileSecrets struct{}
+
+func (fileSecrets) Secret(path string) (string, error) {
+	return ReadSecretFile(path)
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSecretFile(t *testing.T, dir, content string) string {
+	path := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoad_RequiresCredentials(t *testing.T) {
+	isolate(t)
+	t.Setenv("NODEJS_SERVICE_USERNAME", "")
+	t.Setenv("NODEJS_SERVICE_PASSWORD", "")
+
+	_, err := Load("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nodejs.service_username is required")
+	assert.Contains(t, err.Error(), "nodejs.service_password is required")
+}
+
+func TestLoad_DemoPassword(t *testing.T) {
+	isolate(t)
+
+	// Stand in for the demo password, so the test does not repeat it
+	const demoPassword = "stand-in-demo-password"
+	sum := sha256.Sum256([]byte(demoPassword))
+	original := demoPasswordSHA256
+	demoPasswordSHA256 = hex.EncodeToString(sum[:])
+	t.Cleanup(func() { demoPasswordSHA256 = original })
+
+	t.Setenv("NODEJS_SERVICE_PASSWORD", demoPassword)
+
+	_, err := Load("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "demo account's published password")
+
+	t.Setenv("NODEJS_ALLOW_DEMO_CREDENTIALS", "true")
+	cfg, err := Load("")
+	require.NoError(t, err)
+	assert.Equal(t, demoPassword, cfg.NodeJS.ServicePassword)
+}
+
+func TestLoad_SecretFile(t *testing.T) {
+	dir := isolate(t)
+	t.Setenv("NODEJS_SERVICE_PASSWORD", "")
+	t.Setenv("NODEJS_SERVICE_PASSWORD_FILE", writeSecretFile(t, dir, "from-file\n"))
+
+	cfg, err := Load("")
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", cfg.NodeJS.ServicePassword, "trailing newline dropped")
+	assert.Equal(t, []string{filepath.Join(dir, "secret")}, cfg.SecretFiles())
+
+	t.Run("Both set", func(t *testing.T) {
+		t.Setenv("NODEJS_SERVICE_PASSWORD", "from-env")
+		_, err := Load("")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "set either NODEJS_SERVICE_PASSWORD or NODEJS_SERVICE_PASSWORD_FILE")
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		t.Setenv("NODEJS_SERVICE_PASSWORD_FILE", filepath.Join(dir, "missing"))
+		_, err := Load("")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "NODEJS_SERVICE_PASSWORD_FILE: failed to read secret file")
+	})
+
+	t.Run("Empty file", func(t *testing.T) {
+		t.Setenv("NODEJS_SERVICE_PASSWORD_FILE", writeSecretFile(t, t.TempDir(), "\n"))
+		_, err := Load("")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is empty")
+	})
+}
+
+type mapSecrets map[string]string
+
+func (m mapSecrets) Secret(key string) (string, error) {
+	if secret, ok := m[key]; ok {
+		return secret, nil
+	}
+	return "", errors.New("not found")
+}
+
+func TestResolveSecret(t *testing.T) {
+	RegisterSecretProvider("test", mapSecrets{"smtp": "from-provider"})
+	t.Setenv("REPORTS_SMTP_PASSWORD", "from-env")
+	path := writeSecretFile(t, t.TempDir(), "from-file")
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		errorMsg string
+	}{
+		{name: "Plain value", value: "plain", expected: "plain"},
+		{name: "Env reference", value: "${env:REPORTS_SMTP_PASSWORD}", expected: "from-env"},
+		{name: "File reference", value: "${file:" + path + "}", expected: "from-file"},
+		{name: "Registered provider", value: "${test:smtp}", expected: "from-provider"},
+		{name: "Unknown scheme", value: "${vault:smtp}", errorMsg: `no secret provider registered for "vault"`},
+		{name: "Unset variable", value: "${env:REPORTS_UNSET_PASSWORD}", errorMsg: "REPORTS_UNSET_PASSWORD is not set"},
+		{name: "Provider error", value: "${test:missing}", errorMsg: "failed to resolve test secret: not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := ResolveSecret(tt.value)
+			if tt.errorMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, secret)
+		})
+	}
+}
+
+func TestLoad_SecretReferenceFile(t *testing.T) {
+	dir := isolate(t)
+	secret := writeSecretFile(t, t.TempDir(), "smtp-secret")
+	path := writeConfigFile(t, dir, "smtp:\n  password: ${file:"+secret+"}\n")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "smtp-secret", cfg.SMTP.Password)
+	assert.Equal(t, []string{secret}, cfg.SecretFiles())
+}
+
+func TestLoad_SecretReference(t *testing.T) {
+	dir := isolate(t)
+	t.Setenv("REPORTS_SMTP_PASSWORD", "smtp-secret")
+	path := writeConfigFile(t, dir, "smtp:\n  password: ${env:REPORTS_SMTP_PASSWORD}\n")
+
+	cfg, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "smtp-secret", cfg.SMTP.Password)
+	assert.ElementsMatch(t, []string{"test-password", "smtp-secret"}, cfg.Secrets())
+	assert.Empty(t, cfg.SecretFiles(), "environment references are not files")
+}
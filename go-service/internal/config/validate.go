@@ -0,0 +1,193 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Validate checks the configuration and returns an *Error listing every
+// problem, or nil when it is usable. It creates the report output directory
+// to check that it is writable
+func (c *Config) Validate() error {
+	if problems := c.problems(); len(problems) > 0 {
+		return &Error{Problems: problems}
+	}
+	return nil
+}
+
+// problems returns a description of every invalid setting
+func (c *Config) problems() []string {
+	var p problemList
+
+	p.port("server.port", c.Server.Port)
+	p.nonNegativeDuration("server.read_timeout", c.Server.ReadTimeout)
+	p.nonNegativeDuration("server.write_timeout", c.Server.WriteTimeout)
+	p.nonNegativeDuration("server.idle_timeout", c.Server.IdleTimeout)
+	p.nonNegative("server.rate_limit", int64(c.Server.RateLimit))
+	p.nonNegative("server.rate_limit_burst", int64(c.Server.RateLimitBurst))
+	p.nonNegativeDuration("server.config_watch_interval", c.Server.ConfigWatchInterval)
+
+	p.httpURL("nodejs.base_url", c.NodeJS.BaseURL)
+	p.positiveDuration("nodejs.timeout", c.NodeJS.Timeout)
+	p.nonNegative("nodejs.retry_attempts", int64(c.NodeJS.RetryAttempts))
+	p.nonNegativeDuration("nodejs.retry_delay", c.NodeJS.RetryDelay)
+	p.required("nodejs.service_username", c.NodeJS.ServiceUsername)
+	p.required("nodejs.service_password", c.NodeJS.ServicePassword)
+	if isDemoPassword(c.NodeJS.ServicePassword) && !c.NodeJS.AllowDemoCredentials {
+		p.add("nodejs.service_password is the demo account's published password; " +
+			"use a real secret, or set NODEJS_ALLOW_DEMO_CREDENTIALS=true for local development")
+	}
+
+	p.writableDir("report.output_dir", c.Report.OutputDir)
+	if c.Report.MaxFileSize <= 0 {
+		p.add("report.max_file_size must be positive, got %d", c.Report.MaxFileSize)
+	}
+	p.nonNegative("report.disk_quota", c.Report.DiskQuota)
+	p.nonNegativeDuration("report.cleanup_after", c.Report.CleanupAfter)
+	p.nonNegativeDuration("report.cleanup_interval", c.Report.CleanupInterval)
+	for reportType, after := range c.Report.Retention {
+		p.nonNegativeDuration("report.retention."+reportType, after)
+	}
+	for tag, after := range c.Report.TagRetention {
+		p.nonNegativeDuration("report.tag_retention."+tag, after)
+	}
+	for policy, days := range c.Report.LeaveAllowances {
+		if days < 0 {
+			p.add("report.leave_allowances.%s must not be negative, got %g", policy, days)
+		}
+	}
+	p.nonNegativeDuration("report.analytics_cache_ttl", c.Report.AnalyticsCacheTTL)
+	if strings.HasPrefix(c.Report.LogoPath, "http://") || strings.HasPrefix(c.Report.LogoPath, "https://") {
+		p.httpURL("report.logo", c.Report.LogoPath)
+	}
+
+	p.nonNegative("report.max_jobs", int64(c.Report.MaxJobs))
+	p.nonNegative("report.max_queued_jobs", int64(c.Report.MaxQueuedJobs))
+
+	if c.Audit.Enabled {
+		p.required("audit.path", c.Audit.Path)
+	}
+
+	if c.Schedule.Enabled {
+		p.required("schedule.store_path", c.Schedule.StorePath)
+		p.positiveDuration("schedule.poll_interval", c.Schedule.PollInterval)
+	}
+
+	if c.SMTP.Enabled {
+		p.required("smtp.host", c.SMTP.Host)
+		p.port("smtp.port", strconv.Itoa(c.SMTP.Port))
+		if _, err := mail.ParseAddress(c.SMTP.From); err != nil {
+			p.add("smtp.from %q is not an email address", c.SMTP.From)
+		}
+		p.positiveDuration("smtp.timeout", c.SMTP.Timeout)
+		p.nonNegative("smtp.retry_attempts", int64(c.SMTP.RetryAttempts))
+		p.nonNegativeDuration("smtp.retry_delay", c.SMTP.RetryDelay)
+		p.template("smtp.subject_template", c.SMTP.SubjectTemplate)
+		p.template("smtp.body_template", c.SMTP.BodyTemplate)
+	}
+
+	p.nonNegativeDuration("health.cache_ttl", c.Health.CacheTTL)
+	p.positiveDuration("health.check_timeout", c.Health.CheckTimeout)
+	p.nonNegative("health.min_free_space", c.Health.MinFreeSpace)
+	if c.Health.MinFreePercent < 0 || c.Health.MinFreePercent > 100 {
+		p.add("health.min_free_percent must be between 0 and 100, got %g", c.Health.MinFreePercent)
+	}
+
+	if _, err := logrus.ParseLevel(c.Logging.Level); err != nil {
+		p.add("logging.level %q is not a log level (use trace, debug, info, warn, error, fatal or panic)", c.Logging.Level)
+	}
+	if c.Logging.Format != "json" && c.Logging.Format != "text" {
+		p.add("logging.format %q must be json or text", c.Logging.Format)
+	}
+
+	return p
+}
+
+// demoPasswordSHA256 is the hash of the seeded demo admin password, which
+// is published in the READMEs. Tests substitute the hash of their own value
+var demoPasswordSHA256 = "ad081fae03b081552ddb27aeb6b95934b698f5106ccf93fc975ab0f9917277cf"
+
+// isDemoPassword reports whether password is the published demo password
+func isDemoPassword(password string) bool {
+	sum := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(sum[:]) == demoPasswordSHA256
+}
+
+// problemList collects validation problems
+type problemList []string
+
+func (p *problemList) add(format string, args ...interface{}) {
+	*p = append(*p, fmt.Sprintf(format, args...))
+}
+
+func (p *problemList) required(key, value string) {
+	if strings.TrimSpace(value) == "" {
+		p.add("%s is required", key)
+	}
+}
+
+func (p *problemList) nonNegative(key string, value int64) {
+	if value < 0 {
+		p.add("%s must not be negative, got %d", key, value)
+	}
+}
+
+func (p *problemList) nonNegativeDuration(key string, value time.Duration) {
+	if value < 0 {
+		p.add("%s must not be negative, got %s", key, value)
+	}
+}
+
+func (p *problemList) positiveDuration(key string, value time.Duration) {
+	if value <= 0 {
+		p.add("%s must be positive, got %s", key, value)
+	}
+}
+
+func (p *problemList) port(key, value string) {
+	if port, err := strconv.Atoi(value); err != nil || port < 1 || port > 65535 {
+		p.add("%s %q is not a port between 1 and 65535", key, value)
+	}
+}
+
+func (p *problemList) httpURL(key, value string) {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		p.add("%s %q is not an http(s) URL", key, value)
+	}
+}
+
+func (p *problemList) template(key, value string) {
+	if _, err := template.New(key).Parse(value); err != nil {
+		p.add("%s is not a valid template: %v", key, err)
+	}
+}
+
+// writableDir creates dir if needed and checks a file can be written to it
+func (p *problemList) writableDir(key, dir string) {
+	if dir == "" {
+		p.add("%s is required", key)
+		return
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		p.add("%s %q cannot be created: %v", key, dir, err)
+		return
+	}
+	probe, err := os.CreateTemp(dir, ".write-check-*")
+	if err != nil {
+		p.add("%s %q is not writable: %v", key, dir, err)
+		return
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+}
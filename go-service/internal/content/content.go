@@ -0,0 +1,127 @@
+// Package content describes report content independently of how it is drawn,
+// so that the PDF and HTML renderers lay out the same sections and rows
+package content
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"student-report-service/internal/models"
+)
+
+// Document is the content of a report
+type Document struct {
+	Title    string
+	Metadata *models.ReportMetadata
+	Sections []Section
+
+	// Portrait marks a report about a person, which can show Photo (a JPEG)
+	// beside its first section
+	Portrait bool
+	Photo    []byte
+}
+
+// Section is a titled part of a report made of one or more groups of rows
+type Section struct {
+	Title  string
+	Groups []Group
+}
+
+// Group is a run of label/value rows, optionally under a subsection title
+type Group struct {
+	Title string
+	Rows  []Row
+}
+
+// Row is a single labelled value
+type Row struct {
+	Label string
+	Value string
+}
+
+// AddSection appends a section and returns it for adding groups. The pointer is
+// only valid until the next section is added.
+func (d *Document) AddSection(title string) *Section {
+	d.Sections = append(d.Sections, Section{Title: title})
+	return &d.Sections[len(d.Sections)-1]
+}
+
+// AddGroup appends a group and returns it for adding rows. The pointer is only
+// valid until the next group is added to the section.
+func (s *Section) AddGroup(title string) *Group {
+	s.Groups = append(s.Groups, Group{Title: title})
+	return &s.Groups[len(s.Groups)-1]
+}
+
+// AddRow appends a row to the group
+func (g *Group) AddRow(label, value string) {
+	g.Rows = append(g.Rows, Row{Label: label, Value: value})
+}
+
+// MetadataLines returns the report details printed below the title
+func MetadataLines(metadata *models.ReportMetadata) []string {
+	lines := []string{
+		fmt.Sprintf("Report ID: %s", metadata.ReportID),
+		fmt.Sprintf("Generated: %s", metadata.GeneratedAt.Format("January 2, 2006 at 15:04 MST")),
+		fmt.Sprintf("Generated by: %s", metadata.GeneratedBy),
+	}
+	if metadata.Profile != "" && metadata.Profile != "full" {
+		lines = append(lines, fmt.Sprintf("Redaction profile: %s", metadata.Profile))
+	}
+	return lines
+}
+
+// FooterLines returns the confidentiality notice closing every report
+func FooterLines(metadata *models.ReportMetadata) []string {
+	return []string{
+		"This report is confidential and intended for authorized personnel only.",
+		fmt.Sprintf("Generated on %s", metadata.GeneratedAt.Format("January 2, 2006")),
+		SchoolName(metadata),
+	}
+}
+
+// SchoolName returns the school a report was generated for, or the system
+// name when none is configured
+func SchoolName(metadata *models.ReportMetadata) string {
+	if metadata.School != "" {
+		return metadata.School
+	}
+	return "Student Management System"
+}
+
+// FormatBool renders an access flag
+func FormatBool(value bool) string {
+	if value {
+		return "Enabled"
+	}
+	return "Disabled"
+}
+
+// FileStamp returns the generation time and a random suffix for a report
+// filename, e.g. "20240115_103000_3f9a1c", so reports generated within the
+// same second do not overwrite each other
+func FileStamp() string {
+	suffix := make([]byte, 3)
+	rand.Read(suffix)
+	return time.Now().Format("20060102_150405") + "_" + hex.EncodeToString(suffix)
+}
+
+// SanitizeFilename makes a name safe for use in a report filename
+func SanitizeFilename(name string) string {
+	// Replace invalid characters with underscores
+	invalidChars := []string{"/", "\\", ":", "*", "?", "\"", "<", ">", "|", " "}
+	result := name
+	for _, char := range invalidChars {
+		result = strings.ReplaceAll(result, char, "_")
+	}
+
+	// Limit length
+	if len(result) > 30 {
+		result = result[:30]
+	}
+
+	return result
+}
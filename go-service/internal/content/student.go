@@ -0,0 +1,65 @@
+package content
+
+import (
+	"fmt"
+
+	"student-report-service/internal/models"
+)
+
+// StudentTitle is the title of the student information report
+const StudentTitle = "Student Information Report"
+
+// NewStudentDocument lays out the student information report
+func NewStudentDocument(student *models.Student, metadata *models.ReportMetadata) *Document {
+	doc := &Document{Title: StudentTitle, Metadata: metadata, Portrait: true, Photo: student.Photo}
+
+	basic := doc.AddSection("Basic Information").AddGroup("")
+	basic.AddRow("Student ID:", fmt.Sprintf("%d", student.ID))
+	basic.AddRow("Full Name:", student.FormatName())
+	basic.AddRow("Email Address:", student.FormatEmail())
+	basic.AddRow("System Access:", FormatBool(student.SystemAccess))
+	if student.Gender != nil {
+		basic.AddRow("Gender:", models.SafeString(student.Gender, "Not specified"))
+	}
+	if student.DOB != nil {
+		basic.AddRow("Date of Birth:", models.SafeString(student.DOB, "Not specified"))
+	}
+	if student.Phone != nil {
+		basic.AddRow("Phone Number:", models.SafeString(student.Phone, "Not provided"))
+	}
+
+	contact := doc.AddSection("Contact Information").AddGroup("")
+	contact.AddRow("Primary Email:", student.FormatEmail())
+	contact.AddRow("Phone Number:", models.SafeString(student.Phone, "Not provided"))
+
+	family := doc.AddSection("Family & Guardian Information")
+	father := family.AddGroup("Father's Information")
+	father.AddRow("Father's Name:", models.SafeString(student.FatherName, "Not provided"))
+	father.AddRow("Father's Phone:", models.SafeString(student.FatherPhone, "Not provided"))
+	mother := family.AddGroup("Mother's Information")
+	mother.AddRow("Mother's Name:", models.SafeString(student.MotherName, "Not provided"))
+	mother.AddRow("Mother's Phone:", models.SafeString(student.MotherPhone, "Not provided"))
+	guardian := family.AddGroup("Guardian Information")
+	guardian.AddRow("Guardian's Name:", models.SafeString(student.GuardianName, "Not provided"))
+	guardian.AddRow("Guardian's Phone:", models.SafeString(student.GuardianPhone, "Not provided"))
+	guardian.AddRow("Relation to Student:", models.SafeString(student.RelationOfGuardian, "Not specified"))
+
+	address := doc.AddSection("Address Information").AddGroup("")
+	address.AddRow("Current Address:", models.SafeString(student.CurrentAddress, "Not provided"))
+	address.AddRow("Permanent Address:", models.SafeString(student.PermanentAddress, "Not provided"))
+
+	academic := doc.AddSection("Academic Information").AddGroup("")
+	academic.AddRow("Class:", models.SafeString(student.Class, "Not assigned"))
+	academic.AddRow("Section:", models.SafeString(student.Section, "Not assigned"))
+	if student.Roll != nil {
+		academic.AddRow("Roll Number:", fmt.Sprintf("%d", *student.Roll))
+	} else {
+		academic.AddRow("Roll Number:", "Not assigned")
+	}
+	academic.AddRow("Admission Date:", models.SafeString(student.AdmissionDate, "Not recorded"))
+	if student.ReporterName != nil {
+		academic.AddRow("Reporter:", models.SafeString(student.ReporterName, "System"))
+	}
+
+	return doc
+}
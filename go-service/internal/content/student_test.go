@@ -0,0 +1,90 @@
+package content
+
+import (
+	"testing"
+	"time"
+
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestNewStudentDocument(t *testing.T) {
+	roll := 12
+	metadata := &models.ReportMetadata{ReportID: "RPT-1", GeneratedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name     string
+		student  *models.Student
+		basic    []string
+		academic map[string]string
+	}{
+		{
+			name:     "Optional fields omitted",
+			student:  &models.Student{ID: 1, Name: "John Doe"},
+			basic:    []string{"Student ID:", "Full Name:", "Email Address:", "System Access:"},
+			academic: map[string]string{"Roll Number:": "Not assigned", "Admission Date:": "Not recorded"},
+		},
+		{
+			name: "Optional fields present",
+			student: &models.Student{ID: 1, Name: "John Doe", Gender: stringPtr("Male"), DOB: stringPtr("2010-05-01"),
+				Phone: stringPtr("9800000000"), Roll: &roll, ReporterName: stringPtr("Admin")},
+			basic:    []string{"Student ID:", "Full Name:", "Email Address:", "System Access:", "Gender:", "Date of Birth:", "Phone Number:"},
+			academic: map[string]string{"Roll Number:": "12", "Reporter:": "Admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := NewStudentDocument(tt.student, metadata)
+
+			assert.Equal(t, StudentTitle, doc.Title)
+			assert.Same(t, metadata, doc.Metadata)
+
+			titles := make([]string, len(doc.Sections))
+			for i, section := range doc.Sections {
+				titles[i] = section.Title
+			}
+			assert.Equal(t, []string{"Basic Information", "Contact Information", "Family & Guardian Information",
+				"Address Information", "Academic Information"}, titles)
+
+			var labels []string
+			for _, row := range doc.Sections[0].Groups[0].Rows {
+				labels = append(labels, row.Label)
+			}
+			assert.Equal(t, tt.basic, labels)
+
+			require.Len(t, doc.Sections[2].Groups, 3)
+			assert.Equal(t, "Guardian Information", doc.Sections[2].Groups[2].Title)
+
+			academic := make(map[string]string)
+			for _, row := range doc.Sections[4].Groups[0].Rows {
+				academic[row.Label] = row.Value
+			}
+			for label, value := range tt.academic {
+				assert.Equal(t, value, academic[label], label)
+			}
+		})
+	}
+}
+
+func TestMetadataLines(t *testing.T) {
+	metadata := &models.ReportMetadata{
+		ReportID:    "RPT-1",
+		GeneratedBy: "alice",
+		GeneratedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+	}
+	assert.Equal(t, []string{
+		"Report ID: RPT-1",
+		"Generated: January 15, 2024 at 10:30 UTC",
+		"Generated by: alice",
+	}, MetadataLines(metadata))
+
+	metadata.Profile = "public-notice"
+	assert.Equal(t, "Redaction profile: public-notice", MetadataLines(metadata)[3])
+}
@@ -0,0 +1,156 @@
+// Package docx renders report content as editable Word (Office Open XML)
+// documents with the same sections, labels and watermark as the PDF reports
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+)
+
+// ContentType is the MIME type of DOCX files
+const ContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+// Renderer writes report content as DOCX
+type Renderer struct {
+	watermark string
+}
+
+// NewRenderer creates a DOCX renderer that adds the watermark text, if any, to every page
+func NewRenderer(watermark string) *Renderer {
+	return &Renderer{watermark: watermark}
+}
+
+// Render writes the document as a DOCX package
+func (r *Renderer) Render(w io.Writer, doc *content.Document) error {
+	if doc == nil || doc.Metadata == nil {
+		return fmt.Errorf("document and metadata cannot be nil")
+	}
+
+	data := struct {
+		Doc                               *content.Document
+		Metadata                          []string
+		Footer                            []string
+		Watermark                         string
+		Created                           string
+		PageWidth, PageHeight             int
+		Margin, HeaderMargin              int
+		TextWidth, LabelWidth, ValueWidth int
+	}{
+		Doc:          doc,
+		Metadata:     content.MetadataLines(doc.Metadata),
+		Footer:       content.FooterLines(doc.Metadata),
+		Watermark:    r.watermark,
+		Created:      doc.Metadata.GeneratedAt.UTC().Format(time.RFC3339),
+		PageWidth:    pageWidth,
+		PageHeight:   pageHeight,
+		Margin:       pageMargin,
+		HeaderMargin: headerMargin,
+		TextWidth:    textWidth,
+		LabelWidth:   labelWidth,
+		ValueWidth:   valueWidth,
+	}
+
+	archive := zip.NewWriter(w)
+	parts := []struct {
+		name     string
+		static   string
+		template *template.Template
+	}{
+		{name: "[Content_Types].xml", static: contentTypesXML},
+		{name: "_rels/.rels", static: rootRelsXML},
+		{name: "docProps/core.xml", template: coreTemplate},
+		{name: "word/_rels/document.xml.rels", static: documentRelsXML},
+		{name: "word/styles.xml", static: stylesXML},
+		{name: "word/header1.xml", template: headerTemplate},
+		{name: "word/document.xml", template: documentTemplate},
+	}
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", part.name, err)
+		}
+
+		if part.template != nil {
+			err = part.template.Execute(file, data)
+		} else {
+			_, err = io.WriteString(file, part.static)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+
+	return archive.Close()
+}
+
+// Generator writes DOCX reports to the report output directory
+type Generator struct {
+	config atomic.Pointer[config.ReportConfig]
+}
+
+// NewGenerator creates a DOCX generator using the report configuration
+func NewGenerator(cfg *config.ReportConfig) *Generator {
+	g := &Generator{}
+	g.config.Store(cfg)
+	return g
+}
+
+// UpdateConfig switches to a reloaded configuration
+func (g *Generator) UpdateConfig(cfg *config.ReportConfig) {
+	if cfg != nil {
+		g.config.Store(cfg)
+	}
+}
+
+// GenerateStudentReport generates an editable DOCX report for a student
+func (g *Generator) GenerateStudentReport(student *models.Student, metadata *models.ReportMetadata) (string, error) {
+	if student == nil {
+		return "", fmt.Errorf("student cannot be nil")
+	}
+
+	if metadata == nil {
+		metadata = &models.ReportMetadata{
+			GeneratedAt: time.Now(),
+			GeneratedBy: "System",
+			ReportID:    fmt.Sprintf("RPT-%d-%d", student.ID, time.Now().Unix()),
+		}
+	}
+
+	cfg := g.config.Load()
+	var buffer bytes.Buffer
+	if err := NewRenderer(cfg.WatermarkText).Render(&buffer, content.NewStudentDocument(student, metadata)); err != nil {
+		return "", err
+	}
+
+	filename := fmt.Sprintf("student_report_%d_%s_%s.docx",
+		student.ID,
+		content.SanitizeFilename(student.FormatName()),
+		content.FileStamp())
+
+	return saveReport(cfg, buffer.Bytes(), filename)
+}
+
+// saveReport writes the document to the output directory and enforces the size limit
+func saveReport(cfg *config.ReportConfig, data []byte, filename string) (string, error) {
+	if int64(len(data)) > cfg.MaxFileSize {
+		return "", fmt.Errorf("generated DOCX exceeds maximum file size limit")
+	}
+
+	path := filepath.Join(cfg.OutputDir, filename)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to save DOCX: %w", err)
+	}
+
+	return path, nil
+}
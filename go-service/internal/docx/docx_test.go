@@ -0,0 +1,199 @@
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// paragraph is a body paragraph reduced to its style and text
+type paragraph struct {
+	Style string
+	Text  string
+}
+
+func testMetadata() *models.ReportMetadata {
+	return &models.ReportMetadata{
+		ReportID:    "RPT-1",
+		GeneratedBy: "alice",
+		GeneratedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		Profile:     "parent-facing",
+	}
+}
+
+func testStudent() *models.Student {
+	name := "Ram & Sita's <Guardian>"
+	return &models.Student{ID: 1, Name: "John Doe", Email: "john@example.com", GuardianName: &name}
+}
+
+// unzipParts renders the document and returns every package part by name
+func unzipParts(t *testing.T, watermark string, doc *content.Document) map[string]string {
+	var buffer bytes.Buffer
+	require.NoError(t, NewRenderer(watermark).Render(&buffer, doc))
+
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		reader.Close()
+		parts[file.Name] = string(data)
+	}
+	return parts
+}
+
+// bodyParagraphs walks document.xml and returns its paragraphs in order,
+// including those inside table cells
+func bodyParagraphs(t *testing.T, document string) []paragraph {
+	decoder := xml.NewDecoder(strings.NewReader(document))
+
+	var paragraphs []paragraph
+	var current *paragraph
+	inText := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err, "document.xml must be well formed")
+
+		switch element := token.(type) {
+		case xml.StartElement:
+			switch element.Name.Local {
+			case "p":
+				paragraphs = append(paragraphs, paragraph{})
+				current = &paragraphs[len(paragraphs)-1]
+			case "pStyle":
+				for _, attr := range element.Attr {
+					if attr.Name.Local == "val" {
+						current.Style = attr.Value
+					}
+				}
+			case "t":
+				inText = true
+			}
+		case xml.EndElement:
+			if element.Name.Local == "t" {
+				inText = false
+			}
+		case xml.CharData:
+			if inText {
+				current.Text += string(element)
+			}
+		}
+	}
+	return paragraphs
+}
+
+func TestRenderer_Render(t *testing.T) {
+	doc := content.NewStudentDocument(testStudent(), testMetadata())
+	parts := unzipParts(t, "Confidential", doc)
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "docProps/core.xml",
+		"word/_rels/document.xml.rels", "word/styles.xml", "word/header1.xml", "word/document.xml"} {
+		require.Contains(t, parts, name)
+
+		decoder := xml.NewDecoder(strings.NewReader(parts[name]))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err, "%s must be well formed", name)
+		}
+	}
+	assert.Contains(t, parts["docProps/core.xml"], "<dc:title>Student Information Report</dc:title>")
+	assert.Contains(t, parts["docProps/core.xml"], "<dcterms:created xsi:type=\"dcterms:W3CDTF\">2024-01-15T10:30:00Z</dcterms:created>")
+
+	paragraphs := bodyParagraphs(t, parts["word/document.xml"])
+	require.NotEmpty(t, paragraphs)
+	assert.Equal(t, paragraph{Style: "Title", Text: content.StudentTitle}, paragraphs[0])
+
+	// Every section, group and row of the content appears in order with the matching style
+	var expected []paragraph
+	for _, line := range content.MetadataLines(doc.Metadata) {
+		expected = append(expected, paragraph{Style: "ReportDetails", Text: line})
+	}
+	for _, section := range doc.Sections {
+		expected = append(expected, paragraph{Style: "Heading1", Text: section.Title})
+		for _, group := range section.Groups {
+			if group.Title != "" {
+				expected = append(expected, paragraph{Style: "Heading2", Text: group.Title})
+			}
+			for _, row := range group.Rows {
+				expected = append(expected, paragraph{Style: "RowLabel", Text: row.Label}, paragraph{Style: "RowValue", Text: row.Value})
+			}
+			expected = append(expected, paragraph{})
+		}
+	}
+	for _, line := range content.FooterLines(doc.Metadata) {
+		expected = append(expected, paragraph{Style: "ReportFooter", Text: line})
+	}
+	assert.Equal(t, expected, paragraphs[1:])
+
+	assert.Contains(t, parts["word/document.xml"], "Ram &amp; Sita&#39;s &lt;Guardian&gt;")
+	assert.Contains(t, parts["word/document.xml"], `<w:headerReference w:type="default" r:id="rId2"/>`)
+	assert.Contains(t, parts["word/header1.xml"], `string="Confidential"`)
+	assert.Contains(t, parts["word/header1.xml"], "rotation:315")
+}
+
+func TestRenderer_RenderWithoutWatermark(t *testing.T) {
+	parts := unzipParts(t, "", content.NewStudentDocument(testStudent(), testMetadata()))
+
+	assert.NotContains(t, parts["word/document.xml"], "headerReference")
+	assert.NotContains(t, parts["word/header1.xml"], "v:shape")
+
+	var buffer bytes.Buffer
+	assert.Error(t, NewRenderer("").Render(&buffer, &content.Document{}))
+}
+
+func TestGenerator_GenerateStudentReport(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxFileSize int64
+		wantErr     string
+	}{
+		{name: "Saved to the output directory", maxFileSize: 10 * 1024 * 1024},
+		{name: "Size limit", maxFileSize: 100, wantErr: "exceeds maximum file size"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputDir := t.TempDir()
+			generator := NewGenerator(&config.ReportConfig{OutputDir: outputDir, MaxFileSize: tt.maxFileSize})
+
+			path, err := generator.GenerateStudentReport(testStudent(), testMetadata())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				entries, _ := os.ReadDir(outputDir)
+				assert.Empty(t, entries)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, outputDir, filepath.Dir(path))
+			assert.True(t, strings.HasPrefix(filepath.Base(path), "student_report_1_John_Doe_"))
+			assert.True(t, strings.HasSuffix(path, ".docx"))
+
+			_, err = zip.OpenReader(path)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strings"
+	"text/template"
+)
+
+// Page geometry in twentieths of a point: A4 with the PDF's 20mm margins and
+// its 50mm label column
+const (
+	pageWidth    = 11906
+	pageHeight   = 16838
+	pageMargin   = 1134
+	textWidth    = pageWidth - 2*pageMargin
+	labelWidth   = 2835
+	valueWidth   = textWidth - labelWidth
+	headerMargin = 567
+)
+
+const contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+	`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>` +
+	`<Override PartName="/word/header1.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.header+xml"/>` +
+	`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+	`</Types>`
+
+const rootRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>` +
+	`</Relationships>`
+
+const documentRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/header" Target="header1.xml"/>` +
+	`</Relationships>`
+
+// stylesXML mirrors the PDF fonts and colours: Arial throughout, dark blue
+// headings, grey report details and an italic grey footer
+const stylesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:docDefaults>` +
+	`<w:rPrDefault><w:rPr><w:rFonts w:ascii="Arial" w:hAnsi="Arial" w:cs="Arial"/><w:sz w:val="20"/><w:szCs w:val="20"/><w:lang w:val="en-US"/></w:rPr></w:rPrDefault>` +
+	`<w:pPrDefault><w:pPr><w:spacing w:after="0" w:line="240" w:lineRule="auto"/></w:pPr></w:pPrDefault>` +
+	`</w:docDefaults>` +
+	`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:spacing w:before="120" w:after="220"/><w:jc w:val="center"/></w:pPr><w:rPr><w:b/><w:color w:val="003366"/><w:sz w:val="40"/><w:szCs w:val="40"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:keepNext/><w:spacing w:before="240" w:after="100"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:color w:val="003366"/><w:sz w:val="28"/><w:szCs w:val="28"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+	`<w:pPr><w:keepNext/><w:spacing w:before="160" w:after="40"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:color w:val="333333"/><w:sz w:val="22"/><w:szCs w:val="22"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:customStyle="1" w:styleId="ReportDetails"><w:name w:val="Report Details"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:jc w:val="right"/></w:pPr><w:rPr><w:color w:val="646464"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:customStyle="1" w:styleId="RowLabel"><w:name w:val="Row Label"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:spacing w:before="30" w:after="30"/></w:pPr><w:rPr><w:b/><w:color w:val="000000"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:customStyle="1" w:styleId="RowValue"><w:name w:val="Row Value"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:spacing w:before="30" w:after="30"/></w:pPr><w:rPr><w:color w:val="333333"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:customStyle="1" w:styleId="ReportFooter"><w:name w:val="Report Footer"/><w:basedOn w:val="Normal"/>` +
+	`<w:pPr><w:jc w:val="center"/></w:pPr><w:rPr><w:i/><w:color w:val="969696"/><w:sz w:val="16"/><w:szCs w:val="16"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Header"><w:name w:val="header"/><w:basedOn w:val="Normal"/></w:style>` +
+	`</w:styles>`
+
+var templateFuncs = template.FuncMap{"xml": escapeXML}
+
+var coreTemplate = template.Must(template.New("core").Funcs(templateFuncs).Parse(
+	`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+		`<dc:title>{{xml .Doc.Title}}</dc:title>` +
+		`<dc:identifier>{{xml .Doc.Metadata.ReportID}}</dc:identifier>` +
+		`<dc:creator>{{xml .Doc.Metadata.GeneratedBy}}</dc:creator>` +
+		`<dcterms:created xsi:type="dcterms:W3CDTF">{{.Created}}</dcterms:created>` +
+		`</cp:coreProperties>`))
+
+var documentTemplate = template.Must(template.New("document").Funcs(templateFuncs).Parse(
+	`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<w:body>
+<w:p><w:pPr><w:pStyle w:val="Title"/></w:pPr><w:r><w:t xml:space="preserve">{{xml .Doc.Title}}</w:t></w:r></w:p>
+{{- range .Metadata}}
+<w:p><w:pPr><w:pStyle w:val="ReportDetails"/></w:pPr><w:r><w:t xml:space="preserve">{{xml .}}</w:t></w:r></w:p>
+{{- end}}
+{{- range .Doc.Sections}}
+<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t xml:space="preserve">{{xml .Title}}</w:t></w:r></w:p>
+{{- range .Groups}}
+{{- if .Title}}
+<w:p><w:pPr><w:pStyle w:val="Heading2"/></w:pPr><w:r><w:t xml:space="preserve">{{xml .Title}}</w:t></w:r></w:p>
+{{- end}}
+<w:tbl><w:tblPr><w:tblW w:w="{{$.TextWidth}}" w:type="dxa"/><w:tblLayout w:type="fixed"/></w:tblPr><w:tblGrid><w:gridCol w:w="{{$.LabelWidth}}"/><w:gridCol w:w="{{$.ValueWidth}}"/></w:tblGrid>
+{{- range .Rows}}
+<w:tr><w:tc><w:tcPr><w:tcW w:w="{{$.LabelWidth}}" w:type="dxa"/></w:tcPr><w:p><w:pPr><w:pStyle w:val="RowLabel"/></w:pPr><w:r><w:t xml:space="preserve">{{xml .Label}}</w:t></w:r></w:p></w:tc><w:tc><w:tcPr><w:tcW w:w="{{$.ValueWidth}}" w:type="dxa"/></w:tcPr><w:p><w:pPr><w:pStyle w:val="RowValue"/></w:pPr><w:r><w:t xml:space="preserve">{{xml .Value}}</w:t></w:r></w:p></w:tc></w:tr>
+{{- end}}
+</w:tbl>
+<w:p/>
+{{- end}}
+{{- end}}
+{{- range $i, $line := .Footer}}
+<w:p><w:pPr><w:pStyle w:val="ReportFooter"/>{{if eq $i 0}}<w:spacing w:before="480"/>{{end}}</w:pPr><w:r><w:t xml:space="preserve">{{xml $line}}</w:t></w:r></w:p>
+{{- end}}
+<w:sectPr>{{if .Watermark}}<w:headerReference w:type="default" r:id="rId2"/>{{end}}<w:pgSz w:w="{{.PageWidth}}" w:h="{{.PageHeight}}"/><w:pgMar w:top="{{.Margin}}" w:right="{{.Margin}}" w:bottom="{{.Margin}}" w:left="{{.Margin}}" w:header="{{.HeaderMargin}}" w:footer="{{.HeaderMargin}}" w:gutter="0"/></w:sectPr>
+</w:body>
+</w:document>
+`))
+
+// headerTemplate draws the watermark the way Word does: a rotated, light grey
+// WordArt text shape anchored behind the text of every page
+var headerTemplate = template.Must(template.New("header").Funcs(templateFuncs).Parse(
+	`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:v="urn:schemas-microsoft-com:vml" xmlns:o="urn:schemas-microsoft-com:office:office" xmlns:w10="urn:schemas-microsoft-com:office:word">
+<w:p><w:pPr><w:pStyle w:val="Header"/></w:pPr>
+{{- if .Watermark}}<w:r><w:pict>` +
+		`<v:shapetype id="_x0000_t136" coordsize="21600,21600" o:spt="136" adj="10800" path="m@7,l@8,m@5,21600l@6,21600e">` +
+		`<v:formulas><v:f eqn="sum #0 0 10800"/><v:f eqn="prod #0 2 1"/><v:f eqn="sum 21600 0 @1"/><v:f eqn="sum 0 0 @2"/><v:f eqn="sum 21600 0 @3"/>` +
+		`<v:f eqn="if @0 @3 0"/><v:f eqn="if @0 21600 @1"/><v:f eqn="if @0 0 @2"/><v:f eqn="if @0 @4 21600"/><v:f eqn="mid @5 @6"/>` +
+		`<v:f eqn="mid @8 @5"/><v:f eqn="mid @7 @8"/><v:f eqn="mid @6 @7"/><v:f eqn="sum @6 0 @5"/></v:formulas>` +
+		`<v:path textpathok="t" o:connecttype="custom" o:connectlocs="@9,0;@10,10800;@11,21600;@12,10800" o:connectangles="270,180,90,0"/>` +
+		`<v:textpath on="t" fitshape="t"/><o:lock v:ext="edit" text="t" shapetype="t"/></v:shapetype>` +
+		`<v:shape id="PowerPlusWaterMarkObject1" o:spid="_x0000_s1025" type="#_x0000_t136" ` +
+		`style="position:absolute;margin-left:0;margin-top:0;width:480pt;height:60pt;rotation:315;z-index:-251657216;` +
+		`mso-position-horizontal:center;mso-position-horizontal-relative:margin;mso-position-vertical:center;mso-position-vertical-relative:margin" ` +
+		`o:allowincell="f" fillcolor="#f0f0f0" stroked="f">` +
+		`<v:textpath style="font-family:&quot;Arial&quot;;font-size:1pt" string="{{xml .Watermark}}"/>` +
+		`<w10:wrap anchorx="margin" anchory="margin"/></v:shape>` +
+		`</w:pict></w:r>{{end}}</w:p>
+</w:hdr>
+`))
+
+func escapeXML(s string) string {
+	var builder strings.Builder
+	xml.EscapeText(&builder, []byte(s))
+	return builder.String()
+}
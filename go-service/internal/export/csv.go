@@ -0,0 +1,87 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// csvFlushRows is how many rows are buffered before they are flushed to the writer
+const csvFlushRows = 100
+
+// CSVWriter streams rows as RFC 4180 CSV with CRLF line endings, flushing
+// every csvFlushRows rows and whenever Flush is called
+type CSVWriter struct {
+	out     io.Writer
+	writer  *csv.Writer
+	record  []string
+	pending int
+}
+
+// NewCSVWriter writes the header row for the columns and returns a writer for the data rows
+func NewCSVWriter(w io.Writer, columns []Column) (*CSVWriter, error) {
+	writer := csv.NewWriter(w)
+	writer.UseCRLF = true
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Title
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &CSVWriter{out: w, writer: writer, record: make([]string, len(columns))}, nil
+}
+
+// WriteRow writes a data row; values beyond the columns are dropped and
+// missing values are left empty
+func (c *CSVWriter) WriteRow(values ...Value) error {
+	for i := range c.record {
+		c.record[i] = ""
+		if i < len(values) {
+			c.record[i] = values[i].cellText()
+		}
+	}
+	if err := c.writer.Write(c.record); err != nil {
+		return err
+	}
+
+	c.pending++
+	if c.pending >= csvFlushRows {
+		return c.Flush()
+	}
+	return nil
+}
+
+// Flush writes buffered rows to the underlying writer, and flushes that
+// writer too when it buffers, as an HTTP response does
+func (c *CSVWriter) Flush() error {
+	c.pending = 0
+	c.writer.Flush()
+	if err := c.writer.Error(); err != nil {
+		return err
+	}
+	if flusher, ok := c.out.(interface{ Flush() }); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// WriteCSV writes the sheet as RFC 4180 CSV with CRLF line endings
+func WriteCSV(w io.Writer, sheet *Sheet) error {
+	writer, err := NewCSVWriter(w, sheet.Columns)
+	if err != nil {
+		return err
+	}
+	for _, row := range sheet.Rows {
+		if err := writer.WriteRow(row...); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
@@ -0,0 +1,140 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Format identifies a spreadsheet file format
+type Format string
+
+const (
+	// FormatCSV is RFC 4180 comma-separated values
+	FormatCSV Format = "csv"
+	// FormatXLSX is an Office Open XML workbook
+	FormatXLSX Format = "xlsx"
+)
+
+// ParseFormat maps a format name to a Format, defaulting to CSV when empty
+func ParseFormat(name string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(name))) {
+	case "", FormatCSV:
+		return FormatCSV, nil
+	case FormatXLSX:
+		return FormatXLSX, nil
+	default:
+		return "", fmt.Errorf("invalid export format %q: expected csv or xlsx", name)
+	}
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+type kind int
+
+const (
+	kindBlank kind = iota
+	kindText
+	kindNumber
+	kindDate
+)
+
+// Value is a typed spreadsheet cell
+type Value struct {
+	kind   kind
+	text   string
+	number float64
+	date   time.Time
+}
+
+// Blank returns an empty cell
+func Blank() Value {
+	return Value{}
+}
+
+// Text returns a text cell
+func Text(s string) Value {
+	return Value{kind: kindText, text: s}
+}
+
+// Number returns a numeric cell
+func Number(n float64) Value {
+	return Value{kind: kindNumber, number: n}
+}
+
+// Date returns a calendar date cell; the time of day is dropped
+func Date(t time.Time) Value {
+	return Value{kind: kindDate, date: time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)}
+}
+
+// String renders the value as text, using ISO 8601 for dates
+func (v Value) String() string {
+	switch v.kind {
+	case kindText:
+		return v.text
+	case kindNumber:
+		return formatNumber(v.number)
+	case kindDate:
+		return v.date.Format("2006-01-02")
+	default:
+		return ""
+	}
+}
+
+// formulaPrefixes are the leading characters that make spreadsheet
+// applications evaluate a text cell as a formula
+const formulaPrefixes = "=+-@"
+
+// cellText renders the value as written to a file. Text that would be
+// evaluated as a formula is prefixed with an apostrophe, so exported values
+// cannot inject formulas.
+func (v Value) cellText() string {
+	if v.kind == kindText && v.text != "" && strings.ContainsRune(formulaPrefixes, rune(v.text[0])) {
+		return "'" + v.text
+	}
+	return v.String()
+}
+
+// Column describes a sheet column
+type Column struct {
+	Title string
+	// Width is the column width in characters, 0 sizes it from the title
+	Width float64
+}
+
+// Sheet is a table of typed rows with a header
+type Sheet struct {
+	Name    string
+	Columns []Column
+	Rows    [][]Value
+}
+
+// AddRow appends a row to the sheet
+func (s *Sheet) AddRow(values ...Value) {
+	s.Rows = append(s.Rows, values)
+}
+
+// WriteRow appends a row to the sheet, so a sheet can collect rows produced
+// for a RowWriter
+func (s *Sheet) WriteRow(values ...Value) error {
+	s.AddRow(values...)
+	return nil
+}
+
+// Flush does nothing; the sheet is written out as a whole
+func (s *Sheet) Flush() error {
+	return nil
+}
+
+// RowWriter receives the data rows of an export as they are produced
+type RowWriter interface {
+	WriteRow(values ...Value) error
+	// Flush passes buffered rows on to the client
+	Flush() error
+}
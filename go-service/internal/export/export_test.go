@@ -0,0 +1,150 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSheet() *Sheet {
+	sheet := &Sheet{
+		Name:    "Students",
+		Columns: []Column{{Title: "ID"}, {Title: "Name"}, {Title: "Date of Birth"}},
+	}
+	sheet.AddRow(Number(1), Text(`Asha "Ash" Rai`), Date(time.Date(2014, 6, 1, 18, 15, 0, 0, time.UTC)))
+	sheet.AddRow(Number(2), Text("Ravi, K & <Sons>"), Blank())
+	return sheet
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Format
+		wantErr  bool
+	}{
+		{input: "", expected: FormatCSV},
+		{input: "CSV", expected: FormatCSV},
+		{input: "xlsx", expected: FormatXLSX},
+		{input: "pdf", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			format, err := ParseFormat(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteCSV(&buffer, testSheet()))
+
+	expected := "ID,Name,Date of Birth\r\n" +
+		"1,\"Asha \"\"Ash\"\" Rai\",2014-06-01\r\n" +
+		"2,\"Ravi, K & <Sons>\",\r\n"
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestFormulaEscaping(t *testing.T) {
+	sheet := &Sheet{Name: "Students", Columns: []Column{{Title: "Name"}, {Title: "Phone"}, {Title: "Balance"}}}
+	sheet.AddRow(Text("=HYPERLINK(\"http://evil\")"), Text("+1 555 0100"), Number(-5))
+	sheet.AddRow(Text("@SUM(A1)"), Text("-2"), Text("a=b"))
+
+	var csvBuffer bytes.Buffer
+	require.NoError(t, WriteCSV(&csvBuffer, sheet))
+	expected := "Name,Phone,Balance\r\n" +
+		"\"'=HYPERLINK(\"\"http://evil\"\")\",'+1 555 0100,-5\r\n" +
+		"'@SUM(A1),'-2,a=b\r\n"
+	assert.Equal(t, expected, csvBuffer.String(), "numbers are not escaped")
+
+	var xlsxBuffer bytes.Buffer
+	require.NoError(t, WriteXLSX(&xlsxBuffer, sheet))
+	worksheet := readZipFile(t, xlsxBuffer.Bytes(), "xl/worksheets/sheet1.xml")
+	assert.Contains(t, worksheet, `<t xml:space="preserve">&#39;+1 555 0100</t>`)
+	assert.Contains(t, worksheet, `<t xml:space="preserve">&#39;@SUM(A1)</t>`)
+	assert.Contains(t, worksheet, `<c r="C2"><v>-5</v></c>`)
+}
+
+func readZipFile(t *testing.T, data []byte, name string) string {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	reader, err := archive.Open(name)
+	require.NoError(t, err)
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestCSVWriter_FlushesRows(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, err := NewCSVWriter(&buffer, []Column{{Title: "ID"}, {Title: "Name"}})
+	require.NoError(t, err)
+
+	require.NoError(t, writer.WriteRow(Number(1), Text("Asha"), Text("dropped")))
+	assert.Empty(t, buffer.String(), "rows are buffered until flushed")
+	require.NoError(t, writer.Flush())
+	assert.Equal(t, "ID,Name\r\n1,Asha\r\n", buffer.String())
+
+	for i := 0; i < csvFlushRows; i++ {
+		require.NoError(t, writer.WriteRow(Number(2)))
+	}
+	assert.Equal(t, 2+csvFlushRows, strings.Count(buffer.String(), "\r\n"), "full batches are flushed")
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buffer bytes.Buffer
+	require.NoError(t, WriteXLSX(&buffer, testSheet()))
+
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		reader.Close()
+		files[file.Name] = string(content)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		assert.Contains(t, files, name)
+	}
+	assert.Contains(t, files["xl/workbook.xml"], `<sheet name="Students"`)
+
+	worksheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, worksheet, `<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+	assert.Contains(t, worksheet, `<c r="A1" t="inlineStr" s="2"><is><t xml:space="preserve">ID</t></is></c>`)
+	assert.Contains(t, worksheet, `<c r="A2"><v>1</v></c>`)
+	assert.Contains(t, worksheet, `<c r="C2" s="1"><v>41791</v></c>`, "dates are serial numbers with the date style")
+	assert.Contains(t, worksheet, `Ravi, K &amp; &lt;Sons&gt;`)
+	assert.NotContains(t, worksheet, `r="C3"`, "blank values have no cell")
+	assert.Contains(t, worksheet, `<autoFilter ref="A1:C3"/>`)
+}
+
+func TestCellRef(t *testing.T) {
+	assert.Equal(t, "A1", cellRef(0, 1))
+	assert.Equal(t, "Z2", cellRef(25, 2))
+	assert.Equal(t, "AA3", cellRef(26, 3))
+	assert.Equal(t, "AZ4", cellRef(51, 4))
+}
+
+func TestSheetName(t *testing.T) {
+	assert.Equal(t, "Sheet1", sheetName(" "))
+	assert.Equal(t, "Grade 5 A", sheetName("Grade 5/ A"))
+	assert.Len(t, sheetName("A very long sheet name that Excel rejects"), 31)
+}
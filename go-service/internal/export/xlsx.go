@@ -0,0 +1,197 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Cell style indexes into cellXfs of xlsxStyles
+const (
+	styleDefault = 0
+	styleDate    = 1
+	styleHeader  = 2
+)
+
+// excelEpoch is day zero of the 1900 date system, allowing for its leap-year bug
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+// xlsxStyles defines the default, date (yyyy-mm-dd) and bold header cell styles
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="3">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`</styleSheet>`
+
+// WriteXLSX writes the sheet as a single-sheet workbook with a bold, frozen
+// header row. Numbers and dates are written as typed cells.
+func WriteXLSX(w io.Writer, sheet *Sheet) error {
+	archive := zip.NewWriter(w)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, escapeXML(sheetName(sheet.Name)))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return fmt.Errorf("failed to write %s: %w", part.name, err)
+		}
+	}
+
+	file, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return fmt.Errorf("failed to add worksheet: %w", err)
+	}
+	buffered := bufio.NewWriter(file)
+	writeWorksheet(buffered, sheet)
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("failed to write worksheet: %w", err)
+	}
+
+	return archive.Close()
+}
+
+// writeWorksheet writes the worksheet XML; write errors surface on Flush
+func writeWorksheet(w *bufio.Writer, sheet *Sheet) {
+	w.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	w.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	w.WriteString(`<sheetViews><sheetView workbookViewId="0">`)
+	w.WriteString(`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`)
+	w.WriteString(`<selection pane="bottomLeft" activeCell="A2" sqref="A2"/>`)
+	w.WriteString(`</sheetView></sheetViews>`)
+
+	if len(sheet.Columns) > 0 {
+		w.WriteString(`<cols>`)
+		for i, column := range sheet.Columns {
+			width := column.Width
+			if width <= 0 {
+				width = float64(max(len(column.Title)+2, 10))
+			}
+			fmt.Fprintf(w, `<col min="%d" max="%d" width="%s" customWidth="1"/>`, i+1, i+1, formatNumber(width))
+		}
+		w.WriteString(`</cols>`)
+	}
+
+	w.WriteString(`<sheetData>`)
+	w.WriteString(`<row r="1">`)
+	for i, column := range sheet.Columns {
+		writeCell(w, cellRef(i, 1), Text(column.Title), styleHeader)
+	}
+	w.WriteString(`</row>`)
+
+	for i, row := range sheet.Rows {
+		rowNumber := i + 2
+		fmt.Fprintf(w, `<row r="%d">`, rowNumber)
+		for j, value := range row {
+			writeCell(w, cellRef(j, rowNumber), value, styleDefault)
+		}
+		w.WriteString(`</row>`)
+	}
+	w.WriteString(`</sheetData>`)
+
+	if len(sheet.Columns) > 0 {
+		fmt.Fprintf(w, `<autoFilter ref="A1:%s"/>`, cellRef(len(sheet.Columns)-1, len(sheet.Rows)+1))
+	}
+	w.WriteString(`</worksheet>`)
+}
+
+func writeCell(w *bufio.Writer, ref string, value Value, style int) {
+	switch value.kind {
+	case kindText:
+		fmt.Fprintf(w, `<c r="%s" t="inlineStr"%s><is><t xml:space="preserve">%s</t></is></c>`,
+			ref, styleAttr(style), escapeXML(value.cellText()))
+	case kindNumber:
+		fmt.Fprintf(w, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr(style), formatNumber(value.number))
+	case kindDate:
+		serial := value.date.Sub(excelEpoch).Hours() / 24
+		fmt.Fprintf(w, `<c r="%s"%s><v>%s</v></c>`, ref, styleAttr(styleDate), formatNumber(serial))
+	}
+}
+
+func styleAttr(style int) string {
+	if style == styleDefault {
+		return ""
+	}
+	return fmt.Sprintf(` s="%d"`, style)
+}
+
+// cellRef returns the A1-style reference of a zero-based column and one-based row
+func cellRef(column, row int) string {
+	name := ""
+	for column >= 0 {
+		name = string(rune('A'+column%26)) + name
+		column = column/26 - 1
+	}
+	return fmt.Sprintf("%s%d", name, row)
+}
+
+// sheetName strips characters Excel rejects in sheet names and applies its 31 character limit
+func sheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(name))
+
+	if name == "" {
+		return "Sheet1"
+	}
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	return name
+}
+
+func escapeXML(s string) string {
+	var builder strings.Builder
+	xml.EscapeText(&builder, []byte(s))
+	return builder.String()
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"student-report-service/internal/audit"
+	"student-report-service/internal/config"
+	"student-report-service/internal/tenant"
+)
+
+// ReloadStatus describes where the configuration came from and how it has
+// been reloaded since the service started
+type ReloadStatus struct {
+	// Source is the config file path, empty when only the environment is used
+	Source     string         `json:"source"`
+	StartedAt  time.Time      `json:"started_at"`
+	Reloads    int            `json:"reloads"`
+	LastReload *ReloadAttempt `json:"last_reload,omitempty"`
+}
+
+// ReloadAttempt is the outcome of one reload
+type ReloadAttempt struct {
+	At time.Time `json:"at"`
+	// Trigger is "signal" or "file"
+	Trigger string `json:"trigger"`
+	// Error is set when the new configuration was rejected and the previous
+	// one kept
+	Error string `json:"error,omitempty"`
+	// Changes lists the changed settings by tenant ID
+	Changes map[string]config.Changes `json:"changes,omitempty"`
+}
+
+// SetReloadStatus sets the function reporting configuration reloads
+func (h *StudentPDFHandler) SetReloadStatus(status func() ReloadStatus) {
+	h.reloadStatus = status
+}
+
+// GetConfig handles GET /admin/config, showing the tenant's effective
+// configuration with secrets masked
+func (h *StudentPDFHandler) GetConfig(w http.ResponseWriter, r *http.Request) {
+	if !h.requireFullProfile(w, r, "Configuration details") {
+		return
+	}
+
+	data := map[string]interface{}{
+		"tenant": tenant.IDFromContext(r.Context()),
+		"config": h.pdfService(r).Config().Masked(),
+	}
+	if h.reloadStatus != nil {
+		data["reload"] = h.reloadStatus()
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Effective configuration retrieved successfully", data)
+}
+
+// AuditVerification is the outcome of checking the audit log's hash chain
+type AuditVerification struct {
+	Valid bool `json:"valid"`
+	// Error names the first broken record when the chain is not valid
+	Error string `json:"error,omitempty"`
+}
+
+// VerifyAuditLog handles GET /admin/audit/verify, checking the tenant's
+// audit log for edited or removed records
+func (h *StudentPDFHandler) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	if !h.requireFullProfile(w, r, "Audit verification") {
+		return
+	}
+
+	err := h.pdfService(r).VerifyAuditLog()
+	if errors.Is(err, audit.ErrNotChained) {
+		h.writeErrorResponse(w, http.StatusConflict, "Audit log cannot be verified", err)
+		return
+	}
+
+	result := AuditVerification{Valid: err == nil}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	h.writeSuccessResponse(w, http.StatusOK, "Audit log checked", result)
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// GetClassAnalytics handles GET /api/v1/analytics/classes?className=&section=&refresh=true
+func (h *StudentPDFHandler) GetClassAnalytics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filters := make(map[string]string)
+	for _, key := range []string{"className", "section"} {
+		if value := query.Get(key); value != "" {
+			filters[key] = value
+		}
+	}
+
+	refresh, _ := strconv.ParseBool(query.Get("refresh"))
+
+	report, err := h.pdfService(r).GetClassAnalytics(filters, refresh)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if isClientError(err) {
+			statusCode = http.StatusBadRequest
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to compute class analytics", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Class analytics computed successfully", report)
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// CreateDashboardPDF handles POST /api/v1/reports/dashboard?since=YYYY-MM-DD
+func (h *StudentPDFHandler) CreateDashboardPDF(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		var err error
+		if since, err = time.ParseInLocation("2006-01-02", value, time.Local); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid since date",
+				fmt.Errorf("invalid since date %q, expected YYYY-MM-DD", value))
+			return
+		}
+	}
+
+	if !h.requireFullProfile(w, r, "Dashboard reports") {
+		return
+	}
+
+	result, err := h.pdfService(r).CreateDashboardPDF(since, h.requestOptions(r))
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to generate dashboard report", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusCreated, "Dashboard report generated successfully", result)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"student-report-service/internal/export"
+	"student-report-service/internal/tenant"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ExportStudents handles GET /api/v1/students/export?format=csv|xlsx&detail=true
+// with the same filters as GetStudents
+func (h *StudentPDFHandler) ExportStudents(w http.ResponseWriter, r *http.Request) {
+	format, err := export.ParseFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid format", err)
+		return
+	}
+	detailed, _ := strconv.ParseBool(r.URL.Query().Get("detail"))
+
+	profile, ok := h.resolveProfile(w, r)
+	if !ok {
+		return
+	}
+
+	// CSV rows are streamed as students are fetched; workbooks are written
+	// once complete
+	var sheet *export.Sheet
+	streaming := false
+	err = h.pdfService(r).ExportStudents(studentFilters(r), detailed, profile, func(columns []export.Column) (export.RowWriter, error) {
+		if format == export.FormatXLSX {
+			sheet = &export.Sheet{Name: "Students", Columns: columns}
+			return sheet, nil
+		}
+		streaming = true
+		setExportHeaders(w, format)
+		return export.NewCSVWriter(w, columns)
+	})
+	if err != nil && streaming {
+		// The headers and some rows are sent, so the response can only be cut short
+		h.exportLogger(r, format).WithError(err).Error("Student export failed while streaming")
+		return
+	}
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if isClientError(err) {
+			statusCode = http.StatusNotFound
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to export students", err)
+		return
+	}
+
+	if sheet != nil {
+		setExportHeaders(w, format)
+		if err := export.WriteXLSX(w, sheet); err != nil {
+			h.exportLogger(r, format).WithError(err).Error("Failed to write student export")
+		}
+	}
+}
+
+// exportLogger returns a logger for failures after an export response has started
+func (h *StudentPDFHandler) exportLogger(r *http.Request, format export.Format) logrus.FieldLogger {
+	return h.logger.WithFields(logrus.Fields{
+		"request_id": r.Header.Get(RequestIDHeader),
+		"tenant":     tenant.IDFromContext(r.Context()),
+		"format":     format,
+	})
+}
+
+// setExportHeaders marks the response as a download of a student export
+func setExportHeaders(w http.ResponseWriter, format export.Format) {
+	filename := fmt.Sprintf("students_%s.%s", time.Now().Format("20060102_150405"), format)
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+}
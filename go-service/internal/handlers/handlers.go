@@ -2,28 +2,52 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"student-report-service/internal/audit"
+	"student-report-service/internal/docx"
+	"student-report-service/internal/mailer"
+	"student-report-service/internal/redaction"
 	"student-report-service/internal/service"
+	"student-report-service/internal/tenant"
 
 	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
 )
 
 // StudentPDFHandler handles HTTP requests for report generation
 type StudentPDFHandler struct {
-	pdfService *service.PDFReportService
+	responder
+	services     map[string]*service.PDFReportService
+	reloadStatus func() ReloadStatus
+	logger       logrus.FieldLogger
 }
 
-// NewStudentPDFHandler creates a new report handler
-func NewStudentPDFHandler(pdfService *service.PDFReportService) *StudentPDFHandler {
+// NewStudentPDFHandler creates a new report handler serving each tenant with
+// its own report service, keyed by tenant ID
+func NewStudentPDFHandler(services map[string]*service.PDFReportService) *StudentPDFHandler {
 	return &StudentPDFHandler{
-		pdfService: pdfService,
+		services: services,
+		logger:   logrus.StandardLogger(),
 	}
 }
 
+// SetLogger sets the logger for failures that cannot be reported in the response
+func (h *StudentPDFHandler) SetLogger(logger logrus.FieldLogger) {
+	h.logger = logger
+}
+
+// pdfService returns the report service of the request's tenant
+func (h *StudentPDFHandler) pdfService(r *http.Request) *service.PDFReportService {
+	return h.services[tenant.IDFromContext(r.Context())]
+}
+
 // CreateStudentPDF handles POST /api/v1/reports/student/{id}
 func (h *StudentPDFHandler) CreateStudentPDF(w http.ResponseWriter, r *http.Request) {
 	// Extract student ID from URL
@@ -40,14 +64,28 @@ func (h *StudentPDFHandler) CreateStudentPDF(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	// Get generated_by from query params or default to "API"
-	generatedBy := r.URL.Query().Get("generated_by")
-	if generatedBy == "" {
-		generatedBy = "API"
+	profile, ok := h.resolveProfile(w, r)
+	if !ok {
+		return
+	}
+
+	opts := h.requestOptions(r)
+	opts.Profile = profile
+
+	opts.Format = strings.ToLower(r.URL.Query().Get("format"))
+	if opts.Format != "" && opts.Format != service.FormatPDF && opts.Format != service.FormatDOCX {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid format",
+			fmt.Errorf("invalid format %q: expected pdf or docx", opts.Format))
+		return
+	}
+	if opts.Archival && opts.Format == service.FormatDOCX {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid format",
+			fmt.Errorf("archival output is only available as pdf"))
+		return
 	}
 
 	// Generate the report
-	result, err := h.pdfService.CreateStudentPDF(studentID, generatedBy)
+	result, err := h.pdfService(r).CreateStudentPDF(studentID, opts)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 
@@ -64,56 +102,180 @@ func (h *StudentPDFHandler) CreateStudentPDF(w http.ResponseWriter, r *http.Requ
 	h.writeSuccessResponse(w, http.StatusCreated, "Report generated successfully", result)
 }
 
-// HealthCheck handles GET /health
-func (h *StudentPDFHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
-	status := h.pdfService.HealthCheck()
+// EmailStudentPDF handles POST /api/v1/reports/student/{id}/email
+func (h *StudentPDFHandler) EmailStudentPDF(w http.ResponseWriter, r *http.Request) {
+	studentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || studentID <= 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid student ID format", err)
+		return
+	}
 
-	statusCode := http.StatusOK
-	if !status.Healthy {
-		statusCode = http.StatusServiceUnavailable
+	var body struct {
+		Recipients []string `json:"recipients"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid email request payload", err)
+		return
 	}
 
-	h.writeResponse(w, statusCode, status)
+	profile, ok := h.resolveProfile(w, r)
+	if !ok {
+		return
+	}
+
+	opts := h.requestOptions(r)
+	opts.Profile = profile
+
+	result, err := h.pdfService(r).EmailStudentReport(studentID, opts, body.Recipients)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case !h.pdfService(r).EmailEnabled():
+			statusCode = http.StatusServiceUnavailable
+		case isClientError(err):
+			statusCode = http.StatusBadRequest
+		}
+		h.writeErrorResponse(w, statusCode, "Failed to email report", err)
+		return
+	}
+
+	// The email is sent in the background; its status is in GET /api/v1/deliveries
+	h.writeSuccessResponse(w, http.StatusAccepted, "Report email queued", result)
+}
+
+// GetDeliveries handles GET /api/v1/deliveries
+func (h *StudentPDFHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	// Delivery records list recipient addresses
+	if !h.requireFullProfile(w, r, "Delivery records") {
+		return
+	}
+
+	limit := 50
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid limit", err)
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.pdfService(r).Deliveries(r.URL.Query().Get("report_id"), limit)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusServiceUnavailable, "Failed to fetch deliveries", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Deliveries retrieved successfully", deliveries)
 }
 
 // CleanupReports handles POST /api/v1/reports/cleanup
 func (h *StudentPDFHandler) CleanupReports(w http.ResponseWriter, r *http.Request) {
-	err := h.pdfService.CleanupOldReports()
+	if !h.requireFullProfile(w, r, "Report cleanup") {
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dry_run"))
+
+	summary, err := h.pdfService(r).CleanupOldReports(h.requestOptions(r), dryRun)
 	if err != nil {
 		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to cleanup reports", err)
 		return
 	}
 
-	response := map[string]interface{}{
-		"success":   true,
-		"message":   "Old reports cleaned up successfully",
-		"timestamp": time.Now(),
+	message := "Old reports cleaned up successfully"
+	if dryRun {
+		message = "Dry run completed, no reports were deleted"
 	}
 
-	h.writeResponse(w, http.StatusOK, response)
+	h.writeSuccessResponse(w, http.StatusOK, message, summary)
 }
 
-// GetStudents handles GET /api/v1/students
-func (h *StudentPDFHandler) GetStudents(w http.ResponseWriter, r *http.Request) {
-	// Extract query parameters for filtering
-	filters := make(map[string]string)
+// DownloadReport handles GET /api/v1/reports/files/{filename}
+func (h *StudentPDFHandler) DownloadReport(w http.ResponseWriter, r *http.Request) {
+	filename := mux.Vars(r)["filename"]
+
+	path, err := h.pdfService(r).OpenReport(filename, r.Header.Get("X-User-Role"), h.requestOptions(r))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, redaction.ErrProfileNotAllowed):
+			statusCode = http.StatusForbidden
+		case isClientError(err):
+			statusCode = http.StatusNotFound
+		}
+		h.writeErrorResponse(w, statusCode, "Failed to download report", err)
+		return
+	}
+
+	contentType := "application/pdf"
+	if strings.HasSuffix(filename, ".docx") {
+		contentType = docx.ContentType
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	http.ServeFile(w, r, path)
+}
+
+// GetAuditLog handles GET /api/v1/audit
+func (h *StudentPDFHandler) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	if !h.requireFullProfile(w, r, "Audit records") {
+		return
+	}
 
-	// Common filter parameters based on the Node.js API
-	if name := r.URL.Query().Get("name"); name != "" {
-		filters["name"] = name
+	query := r.URL.Query()
+	filter := audit.Filter{
+		Actor:    query.Get("actor"),
+		Action:   audit.Action(query.Get("action")),
+		ReportID: query.Get("report_id"),
+		Outcome:  query.Get("outcome"),
 	}
-	if className := r.URL.Query().Get("className"); className != "" {
-		filters["className"] = className
+
+	var err error
+	if value := query.Get("student_id"); value != "" {
+		if filter.StudentID, err = strconv.Atoi(value); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid student_id", err)
+			return
+		}
 	}
-	if section := r.URL.Query().Get("section"); section != "" {
-		filters["section"] = section
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid limit", err)
+			return
+		}
+	}
+	if value := query.Get("from"); value != "" {
+		if filter.From, err = time.Parse(time.RFC3339, value); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid from timestamp, expected RFC3339", err)
+			return
+		}
+	}
+	if value := query.Get("to"); value != "" {
+		if filter.To, err = time.Parse(time.RFC3339, value); err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid to timestamp, expected RFC3339", err)
+			return
+		}
+	}
+
+	records, err := h.pdfService(r).QueryAudit(filter)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to query audit log", err)
+		return
 	}
-	if roll := r.URL.Query().Get("roll"); roll != "" {
-		filters["roll"] = roll
+
+	h.writeSuccessResponse(w, http.StatusOK, "Audit records retrieved successfully", records)
+}
+
+// GetStudents handles GET /api/v1/students
+func (h *StudentPDFHandler) GetStudents(w http.ResponseWriter, r *http.Request) {
+	profile, ok := h.resolveProfile(w, r)
+	if !ok {
+		return
 	}
 
 	// Fetch students from the service
-	students, err := h.pdfService.GetAllStudents(filters)
+	students, err := h.pdfService(r).GetAllStudents(studentFilters(r), profile)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 
@@ -130,9 +292,78 @@ func (h *StudentPDFHandler) GetStudents(w http.ResponseWriter, r *http.Request)
 	h.writeSuccessResponse(w, http.StatusOK, "Students retrieved successfully", students)
 }
 
+// studentFilters extracts the student list filters supported by the Node.js API
+func studentFilters(r *http.Request) map[string]string {
+	filters := make(map[string]string)
+	for _, key := range []string{"name", "className", "section", "roll"} {
+		if value := r.URL.Query().Get(key); value != "" {
+			filters[key] = value
+		}
+	}
+	return filters
+}
+
+// requestOptions collects the caller details recorded with every report action
+func (h *StudentPDFHandler) requestOptions(r *http.Request) service.ReportOptions {
+	// Get generated_by from query params or default to "API"
+	generatedBy := r.URL.Query().Get("generated_by")
+	if generatedBy == "" {
+		generatedBy = "API"
+	}
+
+	archival, _ := strconv.ParseBool(r.URL.Query().Get("archival"))
+
+	var tags []string
+	for _, tag := range strings.Split(r.URL.Query().Get("tags"), ",") {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return service.ReportOptions{
+		GeneratedBy: generatedBy,
+		Archival:    archival,
+		Tags:        tags,
+		ClientIP:    clientIP(r),
+		RequestID:   r.Header.Get(RequestIDHeader),
+	}
+}
+
+// resolveProfile reads the redaction profile (?profile=) and caller role
+// (X-User-Role header) and writes an error response if they are not compatible
+func (h *StudentPDFHandler) resolveProfile(w http.ResponseWriter, r *http.Request) (redaction.Profile, bool) {
+	profile, err := h.pdfService(r).ResolveProfile(r.Header.Get("X-User-Role"), r.URL.Query().Get("profile"))
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if errors.Is(err, redaction.ErrProfileNotAllowed) {
+			statusCode = http.StatusForbidden
+		}
+		h.writeErrorResponse(w, statusCode, "Invalid redaction profile", err)
+		return "", false
+	}
+	return profile, true
+}
+
+// requireFullProfile rejects callers who cannot use the full profile. Staff and
+// leave data have no redacted variant.
+func (h *StudentPDFHandler) requireFullProfile(w http.ResponseWriter, r *http.Request, what string) bool {
+	profile, ok := h.resolveProfile(w, r)
+	if !ok {
+		return false
+	}
+	if profile != redaction.ProfileFull {
+		h.writeErrorResponse(w, http.StatusForbidden, what+" require the full profile", nil)
+		return false
+	}
+	return true
+}
+
 // Helper methods for consistent response formatting
 
-func (h *StudentPDFHandler) writeSuccessResponse(w http.ResponseWriter, statusCode int, message string, data interface{}) {
+// responder is embedded by every handler to share response formatting
+type responder struct{}
+
+func (h responder) writeSuccessResponse(w http.ResponseWriter, statusCode int, message string, data interface{}) {
 	response := APIResponse{
 		Success:   true,
 		Message:   message,
@@ -142,7 +373,13 @@ func (h *StudentPDFHandler) writeSuccessResponse(w http.ResponseWriter, statusCo
 	h.writeResponse(w, statusCode, response)
 }
 
-func (h *StudentPDFHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string, err error) {
+func (h responder) writeErrorResponse(w http.ResponseWriter, statusCode int, message string, err error) {
+	// A full report or email queue is temporary whichever report was requested
+	if errors.Is(err, service.ErrQueueFull) || errors.Is(err, mailer.ErrQueueFull) {
+		statusCode = http.StatusServiceUnavailable
+		w.Header().Set("Retry-After", queueRetryAfter)
+	}
+
 	response := ErrorResponse{
 		Success:   false,
 		Message:   message,
@@ -156,7 +393,7 @@ func (h *StudentPDFHandler) writeErrorResponse(w http.ResponseWriter, statusCode
 	h.writeResponse(w, statusCode, response)
 }
 
-func (h *StudentPDFHandler) writeResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+func (h responder) writeResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
@@ -166,6 +403,20 @@ func (h *StudentPDFHandler) writeResponse(w http.ResponseWriter, statusCode int,
 	}
 }
 
+// RequestIDHeader carries the per-request correlation ID
+const RequestIDHeader = "X-Request-ID"
+
+// clientIP returns the originating client address, honouring X-Forwarded-For
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
 // Helper function to determine if error is a client error
 func isClientError(err error) bool {
 	errorStr := err.Error()
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"student-report-service/internal/health"
+)
+
+// queueRetryAfter is the Retry-After seconds sent when the report queue is full
+const queueRetryAfter = "5"
+
+// ProbeHandler serves the liveness and readiness probes. Probes are served
+// before tenant resolution, so they need no tenant and readiness covers
+// every tenant
+type ProbeHandler struct {
+	responder
+	checker *health.Checker
+	started time.Time
+}
+
+// NewProbeHandler creates a probe handler running the given readiness checks
+func NewProbeHandler(checker *health.Checker) *ProbeHandler {
+	return &ProbeHandler{checker: checker, started: time.Now()}
+}
+
+// Livez handles GET /livez. It only shows that the process serves requests,
+// so a failing dependency never gets the service restarted
+func (h *ProbeHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	h.writeResponse(w, http.StatusOK, map[string]interface{}{
+		"alive":          true,
+		"uptime_seconds": int64(time.Since(h.started).Seconds()),
+		"timestamp":      time.Now(),
+	})
+}
+
+// Readyz handles GET /readyz, returning 503 while any readiness check fails
+func (h *ProbeHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	report := h.checker.Run(r.Context())
+
+	statusCode := http.StatusOK
+	if !report.Healthy {
+		statusCode = http.StatusServiceUnavailable
+	}
+	h.writeResponse(w, statusCode, report)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// CreateLeavePDF handles POST /api/v1/reports/leave/{userId}?from=YYYY-MM-DD&to=YYYY-MM-DD
+func (h *StudentPDFHandler) CreateLeavePDF(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(mux.Vars(r)["userId"])
+	if err != nil || userID <= 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid user ID format", err)
+		return
+	}
+
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid date range", err)
+		return
+	}
+
+	if !h.requireFullProfile(w, r, "Leave reports") {
+		return
+	}
+
+	result, err := h.pdfService(r).CreateLeavePDF(userID, from, to, h.requestOptions(r))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if isClientError(err) {
+			statusCode = http.StatusBadRequest
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to generate leave report", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusCreated, "Leave report generated successfully", result)
+}
+
+// parseDateRange reads optional from/to query parameters in YYYY-MM-DD format.
+// The to date is inclusive.
+func parseDateRange(r *http.Request) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+
+	if value := r.URL.Query().Get("from"); value != "" {
+		if from, err = time.Parse("2006-01-02", value); err != nil {
+			return from, to, fmt.Errorf("invalid from date %q, expected YYYY-MM-DD", value)
+		}
+	}
+	if value := r.URL.Query().Get("to"); value != "" {
+		if to, err = time.Parse("2006-01-02", value); err != nil {
+			return from, to, fmt.Errorf("invalid to date %q, expected YYYY-MM-DD", value)
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return from, to, fmt.Errorf("from date must not be after to date")
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"student-report-service/internal/models"
+	"student-report-service/internal/service"
+)
+
+// CreateNoticeDigestPDF handles POST /api/v1/reports/notices/digest?from=&to=&userId=&roleId=&field=&title=
+func (h *StudentPDFHandler) CreateNoticeDigestPDF(w http.ResponseWriter, r *http.Request) {
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid date range", err)
+		return
+	}
+
+	query := r.URL.Query()
+	req := service.NoticeDigestRequest{Title: query.Get("title"), From: from, To: to}
+
+	if value := query.Get("userId"); value != "" {
+		if req.UserID, err = strconv.Atoi(value); err != nil || req.UserID <= 0 {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid user ID format", err)
+			return
+		}
+	}
+	if value := query.Get("roleId"); value != "" {
+		roleID, err := strconv.Atoi(value)
+		if err != nil || roleID <= 0 {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid role ID format", err)
+			return
+		}
+		req.Group = &models.RecipientGroup{RoleID: roleID, Field: query.Get("field")}
+	}
+	if req.Unfiltered() && !h.requireFullProfile(w, r, "Digests of every notice") {
+		return
+	}
+
+	result, err := h.pdfService(r).CreateNoticeDigestPDF(req, h.requestOptions(r))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if isClientError(err) {
+			statusCode = http.StatusBadRequest
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to generate notice digest", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusCreated, "Notice digest generated successfully", result)
+}
+
+// GetNoticeRecipients handles GET /api/v1/notices/recipients
+func (h *StudentPDFHandler) GetNoticeRecipients(w http.ResponseWriter, r *http.Request) {
+	recipients, err := h.pdfService(r).GetNoticeRecipients()
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch notice recipients", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Notice recipients retrieved successfully", recipients)
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"student-report-service/internal/redaction"
+	"student-report-service/internal/service"
+)
+
+// packetItemPayload is one report in a packet request body
+type packetItemPayload struct {
+	Type      string `json:"type"`
+	ID        int    `json:"id"`
+	ClassName string `json:"class_name"`
+	Section   string `json:"section"`
+	SortBy    string `json:"sort_by"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+}
+
+// CreatePacketPDF handles POST /api/v1/reports/packet
+func (h *StudentPDFHandler) CreatePacketPDF(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Title string              `json:"title"`
+		Items []packetItemPayload `json:"items"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid packet request payload", err)
+		return
+	}
+
+	req := service.PacketRequest{Title: body.Title, Items: make([]service.PacketItem, len(body.Items))}
+	for i, payload := range body.Items {
+		item, err := payload.toItem()
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid packet request payload",
+				fmt.Errorf("item %d: %w", i+1, err))
+			return
+		}
+		req.Items[i] = item
+	}
+
+	opts := h.requestOptions(r)
+	if req.RequiresFullProfile() {
+		if !h.requireFullProfile(w, r, "Staff and leave reports") {
+			return
+		}
+		opts.Profile = redaction.ProfileFull
+	} else {
+		profile, ok := h.resolveProfile(w, r)
+		if !ok {
+			return
+		}
+		opts.Profile = profile
+	}
+
+	result, err := h.pdfService(r).CreatePacketPDF(req, opts)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "invalid"):
+			statusCode = http.StatusBadRequest
+		case isClientError(err):
+			statusCode = http.StatusNotFound
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to generate report packet", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusCreated, "Report packet generated successfully", result)
+}
+
+// toItem converts the payload, parsing the optional YYYY-MM-DD leave dates
+func (p packetItemPayload) toItem() (service.PacketItem, error) {
+	item := service.PacketItem{
+		Type:      strings.ToLower(p.Type),
+		ID:        p.ID,
+		ClassName: p.ClassName,
+		Section:   p.Section,
+		SortBy:    p.SortBy,
+	}
+
+	var err error
+	if p.From != "" {
+		if item.From, err = time.Parse("2006-01-02", p.From); err != nil {
+			return item, fmt.Errorf("invalid from date %q, expected YYYY-MM-DD", p.From)
+		}
+	}
+	if p.To != "" {
+		if item.To, err = time.Parse("2006-01-02", p.To); err != nil {
+			return item, fmt.Errorf("invalid to date %q, expected YYYY-MM-DD", p.To)
+		}
+	}
+	return item, nil
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// PreviewStudentReport handles GET /api/v1/reports/student/{id}/preview
+func (h *StudentPDFHandler) PreviewStudentReport(w http.ResponseWriter, r *http.Request) {
+	studentID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || studentID <= 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid student ID format", err)
+		return
+	}
+
+	profile, ok := h.resolveProfile(w, r)
+	if !ok {
+		return
+	}
+
+	opts := h.requestOptions(r)
+	opts.Profile = profile
+
+	page, err := h.pdfService(r).PreviewStudentHTML(studentID, opts)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if isClientError(err) {
+			statusCode = http.StatusNotFound
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to render report preview", err)
+		return
+	}
+
+	// The page is self-contained, so it may load nothing but its inline styles
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(page)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"student-report-service/internal/service"
+)
+
+// GetStudentQuality handles GET /api/v1/quality/students?className=&section=&fields=&format=json|pdf|csv
+func (h *StudentPDFHandler) GetStudentQuality(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	format := strings.ToLower(query.Get("format"))
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "pdf" && format != "csv" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid format",
+			fmt.Errorf("invalid format %q: expected json, pdf or csv", format))
+		return
+	}
+
+	var fields []string
+	if value := query.Get("fields"); value != "" {
+		fields = strings.Split(value, ",")
+	}
+
+	report, err := h.pdfService(r).CheckStudentQuality(query.Get("className"), query.Get("section"), fields)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if isClientError(err) {
+			statusCode = http.StatusBadRequest
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to check student records", err)
+		return
+	}
+
+	switch format {
+	case "csv":
+		// Buffer the checklist so a write failure can still be reported as an error
+		var buffer bytes.Buffer
+		if err := service.WriteQualityCSV(&buffer, report); err != nil {
+			h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to generate student data checklist", err)
+			return
+		}
+
+		filename := fmt.Sprintf("quality_report_%s.csv", time.Now().Format("20060102_150405"))
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+		w.WriteHeader(http.StatusOK)
+		w.Write(buffer.Bytes())
+
+	case "pdf":
+		result, err := h.pdfService(r).CreateQualityPDF(report, h.requestOptions(r))
+		if err != nil {
+			h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to generate student data checklist", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", "attachment; filename=\""+filepath.Base(result.FilePath)+"\"")
+		http.ServeFile(w, r, result.FilePath)
+
+	default:
+		h.writeSuccessResponse(w, http.StatusOK, "Student records checked successfully", report)
+	}
+}
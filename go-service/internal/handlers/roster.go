@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+)
+
+// CreateClassRosterPDF handles POST /api/v1/reports/roster?className=&section=&sort=roll|name
+func (h *StudentPDFHandler) CreateClassRosterPDF(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	className := query.Get("className")
+	if className == "" {
+		h.writeErrorResponse(w, http.StatusBadRequest, "className is required", nil)
+		return
+	}
+
+	profile, ok := h.resolveProfile(w, r)
+	if !ok {
+		return
+	}
+
+	opts := h.requestOptions(r)
+	opts.Profile = profile
+
+	result, err := h.pdfService(r).CreateClassRosterPDF(className, query.Get("section"), query.Get("sort"), opts)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case strings.Contains(err.Error(), "invalid sort order"):
+			statusCode = http.StatusBadRequest
+		case isClientError(err):
+			statusCode = http.StatusNotFound
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to generate class roster", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusCreated, "Class roster generated successfully", result)
+}
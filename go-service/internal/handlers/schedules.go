@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"student-report-service/internal/redaction"
+	"student-report-service/internal/schedule"
+	"student-report-service/internal/tenant"
+
+	"github.com/gorilla/mux"
+)
+
+// ScheduleHandler handles HTTP requests for recurring report schedules
+type ScheduleHandler struct {
+	responder
+	managers map[string]*schedule.Manager
+}
+
+// NewScheduleHandler creates a new schedule handler with each tenant's
+// schedule manager, keyed by tenant ID
+func NewScheduleHandler(managers map[string]*schedule.Manager) *ScheduleHandler {
+	return &ScheduleHandler{
+		managers: managers,
+	}
+}
+
+// manager returns the schedule manager of the request's tenant
+func (h *ScheduleHandler) manager(r *http.Request) *schedule.Manager {
+	return h.managers[tenant.IDFromContext(r.Context())]
+}
+
+// ListSchedules handles GET /api/v1/schedules
+func (h *ScheduleHandler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules, err := h.manager(r).List()
+	if err != nil {
+		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to list schedules", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Schedules retrieved successfully", schedules)
+}
+
+// CreateSchedule handles POST /api/v1/schedules
+func (h *ScheduleHandler) CreateSchedule(w http.ResponseWriter, r *http.Request) {
+	// Schedules run unless the definition says otherwise
+	definition := schedule.Schedule{Enabled: true}
+	if err := json.NewDecoder(r.Body).Decode(&definition); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid schedule payload", err)
+		return
+	}
+
+	if definition.CreatedBy == "" {
+		definition.CreatedBy = r.URL.Query().Get("generated_by")
+	}
+	definition.Role = r.Header.Get("X-User-Role")
+
+	created, err := h.manager(r).Create(definition)
+	if err != nil {
+		h.writeScheduleError(w, "Failed to create schedule", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusCreated, "Schedule created successfully", created)
+}
+
+// GetSchedule handles GET /api/v1/schedules/{id}
+func (h *ScheduleHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	found, err := h.manager(r).Get(mux.Vars(r)["id"])
+	if err != nil {
+		h.writeScheduleError(w, "Failed to fetch schedule", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Schedule retrieved successfully", found)
+}
+
+// UpdateSchedule handles PUT /api/v1/schedules/{id}
+func (h *ScheduleHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	existing, err := h.manager(r).Get(mux.Vars(r)["id"])
+	if err != nil {
+		h.writeScheduleError(w, "Failed to update schedule", err)
+		return
+	}
+
+	// A definition that leaves out enabled keeps the schedule's current state
+	definition := schedule.Schedule{Enabled: existing.Enabled}
+	if err := json.NewDecoder(r.Body).Decode(&definition); err != nil {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid schedule payload", err)
+		return
+	}
+	definition.Role = r.Header.Get("X-User-Role")
+
+	updated, err := h.manager(r).Update(mux.Vars(r)["id"], definition)
+	if err != nil {
+		h.writeScheduleError(w, "Failed to update schedule", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Schedule updated successfully", updated)
+}
+
+// DeleteSchedule handles DELETE /api/v1/schedules/{id}
+func (h *ScheduleHandler) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	if err := h.manager(r).Delete(mux.Vars(r)["id"], r.Header.Get("X-User-Role")); err != nil {
+		h.writeScheduleError(w, "Failed to delete schedule", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Schedule deleted successfully", nil)
+}
+
+// ListRuns handles GET /api/v1/schedules/{id}/runs
+func (h *ScheduleHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	limit := 20
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			h.writeErrorResponse(w, http.StatusBadRequest, "Invalid limit", err)
+			return
+		}
+		limit = parsed
+	}
+
+	runs, err := h.manager(r).Runs(mux.Vars(r)["id"], limit)
+	if err != nil {
+		h.writeScheduleError(w, "Failed to fetch schedule runs", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Schedule runs retrieved successfully", runs)
+}
+
+// TriggerSchedule handles POST /api/v1/schedules/{id}/run
+func (h *ScheduleHandler) TriggerSchedule(w http.ResponseWriter, r *http.Request) {
+	runID, err := h.manager(r).Trigger(mux.Vars(r)["id"], r.Header.Get("X-User-Role"))
+	if err != nil {
+		h.writeScheduleError(w, "Failed to run schedule", err)
+		return
+	}
+
+	// The outcome is added to the run history once the run finishes
+	h.writeSuccessResponse(w, http.StatusAccepted, "Schedule run started", map[string]string{"run_id": runID})
+}
+
+// writeScheduleError maps schedule errors to HTTP status codes
+func (h *ScheduleHandler) writeScheduleError(w http.ResponseWriter, message string, err error) {
+	var validationErr *schedule.ValidationError
+
+	statusCode := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, schedule.ErrNotFound):
+		statusCode = http.StatusNotFound
+	case errors.Is(err, schedule.ErrRunning):
+		statusCode = http.StatusConflict
+	case errors.Is(err, redaction.ErrProfileNotAllowed):
+		statusCode = http.StatusForbidden
+	case errors.As(err, &validationErr), isClientError(err):
+		statusCode = http.StatusBadRequest
+	}
+
+	h.writeErrorResponse(w, statusCode, message, err)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// CreateStaffPDF handles POST /api/v1/reports/staff/{id}
+func (h *StudentPDFHandler) CreateStaffPDF(w http.ResponseWriter, r *http.Request) {
+	staffID, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil || staffID <= 0 {
+		h.writeErrorResponse(w, http.StatusBadRequest, "Invalid staff ID format", err)
+		return
+	}
+
+	if !h.requireFullProfile(w, r, "Staff reports") {
+		return
+	}
+
+	result, err := h.pdfService(r).CreateStaffPDF(staffID, h.requestOptions(r))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if isClientError(err) {
+			statusCode = http.StatusNotFound
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to generate staff report", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusCreated, "Staff report generated successfully", result)
+}
+
+// GetStaff handles GET /api/v1/staff
+func (h *StudentPDFHandler) GetStaff(w http.ResponseWriter, r *http.Request) {
+	filters := make(map[string]string)
+	for _, key := range []string{"userId", "roleId", "name"} {
+		if value := r.URL.Query().Get(key); value != "" {
+			filters[key] = value
+		}
+	}
+
+	if !h.requireFullProfile(w, r, "Staff listings") {
+		return
+	}
+
+	staff, err := h.pdfService(r).GetAllStaff(filters)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if isClientError(err) {
+			statusCode = http.StatusNotFound
+		}
+
+		h.writeErrorResponse(w, statusCode, "Failed to fetch staff", err)
+		return
+	}
+
+	h.writeSuccessResponse(w, http.StatusOK, "Staff retrieved successfully", staff)
+}
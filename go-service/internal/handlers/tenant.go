@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"student-report-service/internal/tenant"
+)
+
+// TenantMiddleware resolves every request to its tenant, removes any /t/{id}
+// path prefix before routing and enforces the tenant's rate limit
+func TenantMiddleware(registry *tenant.Registry) func(http.Handler) http.Handler {
+	var h responder
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t, path, err := registry.Resolve(r)
+			if err != nil {
+				statusCode := http.StatusBadRequest
+				if errors.Is(err, tenant.ErrUnknownTenant) {
+					statusCode = http.StatusNotFound
+				}
+				h.writeErrorResponse(w, statusCode, "Failed to resolve tenant", err)
+				return
+			}
+
+			if allowed, wait := t.Limiter().Allow(); !allowed {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				h.writeErrorResponse(w, http.StatusTooManyRequests, "Rate limit exceeded",
+					fmt.Errorf("tenant %s allows %d requests per minute", t.ID, t.Config.Server.RateLimit))
+				return
+			}
+
+			r = r.WithContext(tenant.WithTenant(r.Context(), t))
+			if path != r.URL.Path {
+				stripped := *r.URL
+				stripped.Path = path
+				stripped.RawPath = ""
+				r.URL = &stripped
+			}
+			w.Header().Set(tenant.Header, t.ID)
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// errFreeSpaceUnknown is returned by freeSpace on platforms where it cannot
+// be measured
+var errFreeSpaceUnknown = errors.New("free space cannot be measured on this platform")
+
+// WritableDir checks that a file can be written to dir and that its file
+// system keeps at least minFreeBytes bytes and minFreePercent percent free
+func WritableDir(dir string, minFreeBytes int64, minFreePercent float64) error {
+	probe, err := os.CreateTemp(dir, ".ready-check-*")
+	if err != nil {
+		return fmt.Errorf("directory is not writable: %w", err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+
+	free, total, err := freeSpace(dir)
+	if errors.Is(err, errFreeSpaceUnknown) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to measure free space: %w", err)
+	}
+
+	if minFreeBytes > 0 && free < uint64(minFreeBytes) {
+		return fmt.Errorf("%s free, below the %s minimum", formatBytes(free), formatBytes(uint64(minFreeBytes)))
+	}
+	if total > 0 && minFreePercent > 0 {
+		if percent := float64(free) / float64(total) * 100; percent < minFreePercent {
+			return fmt.Errorf("%.1f%% free, below the %g%% minimum", percent, minFreePercent)
+		}
+	}
+	return nil
+}
+
+// formatBytes formats a size in binary units, e.g. 1.5 GiB
+func formatBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
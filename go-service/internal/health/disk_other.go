@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package health
+
+// freeSpace is not implemented on this platform, so only writability is checked
+func freeSpace(dir string) (free, total uint64, err error) {
+	return 0, 0, errFreeSpaceUnknown
+}
@@ -0,0 +1,146 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds checks that do not set their own timeout
+const DefaultTimeout = 5 * time.Second
+
+// Check is one readiness check of a dependency
+type Check struct {
+	Name string
+	// Timeout bounds one run of the check, DefaultTimeout when zero
+	Timeout time.Duration
+	// Run returns nil when the dependency is usable. It should return
+	// promptly once ctx is done
+	Run func(ctx context.Context) error
+}
+
+// Result is the outcome of a check
+type Result struct {
+	Name       string    `json:"name"`
+	Healthy    bool      `json:"healthy"`
+	Message    string    `json:"message,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	CheckedAt  time.Time `json:"checked_at"`
+	// Cached is set when the result of an earlier run was reused
+	Cached bool `json:"cached"`
+}
+
+// Report is the outcome of every check
+type Report struct {
+	Healthy   bool      `json:"healthy"`
+	Checks    []Result  `json:"checks"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Checker runs checks concurrently and caches their results, so frequent
+// probes do not load the dependencies and a slow dependency cannot hold a
+// probe for longer than its check's timeout
+type Checker struct {
+	ttl     time.Duration
+	entries []*entry
+}
+
+// entry caches the last result of a check. Its mutex lets one caller run
+// the check while concurrent callers wait for that result
+type entry struct {
+	check Check
+	mu    sync.Mutex
+	last  *Result
+}
+
+// NewChecker creates a checker reusing results for ttl; a ttl of 0 runs the
+// checks on every call
+func NewChecker(ttl time.Duration, checks ...Check) *Checker {
+	c := &Checker{ttl: ttl}
+	for _, check := range checks {
+		if check.Timeout <= 0 {
+			check.Timeout = DefaultTimeout
+		}
+		c.entries = append(c.entries, &entry{check: check})
+	}
+	return c
+}
+
+// Run returns the result of every check in the order they were given. The
+// report is healthy when every check passes
+func (c *Checker) Run(ctx context.Context) Report {
+	report := Report{
+		Healthy:   true,
+		Checks:    make([]Result, len(c.entries)),
+		Timestamp: time.Now(),
+	}
+
+	var wg sync.WaitGroup
+	for i, e := range c.entries {
+		wg.Add(1)
+		go func(i int, e *entry) {
+			defer wg.Done()
+			report.Checks[i] = e.result(ctx, c.ttl)
+		}(i, e)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if !result.Healthy {
+			report.Healthy = false
+		}
+	}
+	return report
+}
+
+// result returns the cached result while it is fresh, otherwise runs the check
+func (e *entry) result(ctx context.Context, ttl time.Duration) Result {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.last != nil && time.Since(e.last.CheckedAt) < ttl {
+		cached := *e.last
+		cached.Cached = true
+		return cached
+	}
+
+	result := e.run(ctx)
+	// A result cut short by the caller going away says nothing about the
+	// dependency, so it is not kept
+	if ctx.Err() == nil {
+		e.last = &result
+	}
+	return result
+}
+
+// run runs the check, giving up once its timeout passes. The check keeps
+// running in the background until it notices its context is done
+func (e *entry) run(ctx context.Context) Result {
+	ctx, cancel := context.WithTimeout(ctx, e.check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- e.check.Run(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("timed out after %s", e.check.Timeout)
+	}
+
+	result := Result{
+		Name:       e.check.Name,
+		Healthy:    err == nil,
+		DurationMS: time.Since(start).Milliseconds(),
+		CheckedAt:  start,
+	}
+	if err != nil {
+		result.Message = err.Error()
+	}
+	return result
+}
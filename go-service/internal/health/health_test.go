@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countingCheck(name string, calls *atomic.Int32, err error) Check {
+	return Check{Name: name, Run: func(ctx context.Context) error {
+		calls.Add(1)
+		return err
+	}}
+}
+
+func TestChecker_Run(t *testing.T) {
+	var okCalls, failCalls atomic.Int32
+	checker := NewChecker(time.Minute,
+		countingCheck("ok", &okCalls, nil),
+		countingCheck("failing", &failCalls, errors.New("backend refused login")),
+	)
+
+	report := checker.Run(context.Background())
+	assert.False(t, report.Healthy)
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, "ok", report.Checks[0].Name)
+	assert.True(t, report.Checks[0].Healthy)
+	assert.Equal(t, "failing", report.Checks[1].Name)
+	assert.Equal(t, "backend refused login", report.Checks[1].Message)
+	assert.False(t, report.Checks[1].Cached)
+
+	report = checker.Run(context.Background())
+	assert.True(t, report.Checks[0].Cached, "fresh results are reused")
+	assert.True(t, report.Checks[1].Cached, "failures are cached too")
+	assert.Equal(t, int32(1), okCalls.Load())
+	assert.Equal(t, int32(1), failCalls.Load())
+}
+
+func TestChecker_NoCache(t *testing.T) {
+	var calls atomic.Int32
+	checker := NewChecker(0, countingCheck("ok", &calls, nil))
+
+	assert.True(t, checker.Run(context.Background()).Healthy)
+	assert.True(t, checker.Run(context.Background()).Healthy)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	checker := NewChecker(time.Minute, Check{
+		Name:    "slow",
+		Timeout: 20 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			<-release
+			return nil
+		},
+	})
+
+	start := time.Now()
+	report := checker.Run(context.Background())
+	assert.Less(t, time.Since(start), time.Second, "a check ignoring its context does not hold the probe")
+	assert.False(t, report.Healthy)
+	assert.Equal(t, "timed out after 20ms", report.Checks[0].Message)
+}
+
+func TestChecker_CancelledCaller(t *testing.T) {
+	checker := NewChecker(time.Minute, Check{Name: "backend", Run: func(ctx context.Context) error {
+		return ctx.Err()
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, checker.Run(ctx).Healthy)
+
+	report := checker.Run(context.Background())
+	assert.True(t, report.Healthy, "results for a caller that went away are not cached")
+	assert.False(t, report.Checks[0].Cached)
+}
+
+func TestChecker_NoChecks(t *testing.T) {
+	assert.True(t, NewChecker(time.Minute).Run(context.Background()).Healthy)
+}
+
+func TestWritableDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, WritableDir(dir, 0, 0))
+
+	free, _, err := freeSpace(dir)
+	if errors.Is(err, errFreeSpaceUnknown) {
+		t.Skip(err)
+	}
+	require.NoError(t, err)
+
+	err = WritableDir(dir, int64(free)+1<<40, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "below the")
+
+	err = WritableDir(dir, 0, 100.0001)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "% minimum")
+
+	err = WritableDir(filepath.Join(dir, "missing"), 0, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not writable")
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0644))
+	assert.Error(t, WritableDir(file, 0, 0))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "100.0 MiB", formatBytes(100<<20))
+	assert.Equal(t, "2.0 GiB", formatBytes(2<<30))
+}
@@ -0,0 +1,204 @@
+// Package imaging validates and downscales the logo and photos embedded in
+// reports so that they render in every PDF reader and stay within the report
+// size limit
+package imaging
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// maxSourcePixels rejects images whose decoded size would exhaust memory
+	maxSourcePixels = 40_000_000
+	// maxDownloadBytes bounds a downloaded or read source image
+	maxDownloadBytes = 20 << 20
+	// minDimension stops downscaling before an image becomes unrecognisable
+	minDimension = 48
+	// jpegQuality is used for lossy output
+	jpegQuality = 85
+)
+
+// Image formats
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+// Options bound the prepared image
+type Options struct {
+	// MaxDimension is the largest width or height in pixels
+	MaxDimension int
+	// MaxBytes is the largest encoded size; the image is downscaled further
+	// until it fits
+	MaxBytes int64
+	// Lossless encodes as PNG, which keeps logos and line art sharp. Photos
+	// are encoded as JPEG
+	Lossless bool
+}
+
+// Image is a prepared, opaque RGB image
+type Image struct {
+	Data   []byte
+	Format string
+	Width  int
+	Height int
+}
+
+// Prepare decodes a PNG or JPEG image, flattens any transparency onto white,
+// downscales it to fit the options and re-encodes it. Transparency is removed
+// because PDF/A output may not use soft masks
+func Prepare(data []byte, opts Options) (*Image, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image: expected PNG or JPEG: %w", err)
+	}
+	if format != FormatJPEG && format != FormatPNG {
+		return nil, fmt.Errorf("invalid image: unsupported format %q, expected PNG or JPEG", format)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxSourcePixels {
+		return nil, fmt.Errorf("invalid image: %dx%d pixels is out of range", cfg.Width, cfg.Height)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid image: %w", err)
+	}
+
+	img := flatten(src)
+	if opts.MaxDimension > 0 {
+		img = fit(img, opts.MaxDimension)
+	}
+
+	for {
+		encoded, err := encode(img, opts.Lossless)
+		if err != nil {
+			return nil, err
+		}
+		bounds := img.Bounds()
+		if opts.MaxBytes <= 0 || int64(len(encoded)) <= opts.MaxBytes {
+			return &Image{Data: encoded, Format: outputFormat(opts.Lossless), Width: bounds.Dx(), Height: bounds.Dy()}, nil
+		}
+
+		longest := max(bounds.Dx(), bounds.Dy())
+		if longest/2 < minDimension {
+			return nil, fmt.Errorf("image does not fit in %d bytes", opts.MaxBytes)
+		}
+		img = fit(img, longest/2)
+	}
+}
+
+// Read loads an image from a file path or an http(s) URL
+func Read(location string, timeout time.Duration) ([]byte, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		client := &http.Client{Timeout: timeout}
+		resp, err := client.Get(location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download image: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to download image: %s", resp.Status)
+		}
+		return readLimited(resp.Body)
+	}
+
+	file, err := os.Open(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open image: %w", err)
+	}
+	defer file.Close()
+	return readLimited(file)
+}
+
+// readLimited reads at most maxDownloadBytes, failing on larger sources
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxDownloadBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) > maxDownloadBytes {
+		return nil, fmt.Errorf("image is larger than %d bytes", maxDownloadBytes)
+	}
+	return data, nil
+}
+
+// flatten draws the image onto a white background
+func flatten(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Over)
+	return dst
+}
+
+// fit downscales the image so neither side exceeds maxDimension, averaging
+// the source pixels covered by each output pixel
+func fit(src *image.RGBA, maxDimension int) *image.RGBA {
+	width, height := src.Bounds().Dx(), src.Bounds().Dy()
+	if width <= maxDimension && height <= maxDimension {
+		return src
+	}
+
+	scale := float64(maxDimension) / float64(max(width, height))
+	outWidth := max(1, int(float64(width)*scale+0.5))
+	outHeight := max(1, int(float64(height)*scale+0.5))
+
+	dst := image.NewRGBA(image.Rect(0, 0, outWidth, outHeight))
+	for y := 0; y < outHeight; y++ {
+		y0, y1 := y*height/outHeight, max((y+1)*height/outHeight, y*height/outHeight+1)
+		for x := 0; x < outWidth; x++ {
+			x0, x1 := x*width/outWidth, max((x+1)*width/outWidth, x*width/outWidth+1)
+
+			var r, g, b, n int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					r += int(row[sx*4])
+					g += int(row[sx*4+1])
+					b += int(row[sx*4+2])
+					n++
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+	return dst
+}
+
+// encode writes the opaque image as PNG or JPEG
+func encode(img *image.RGBA, lossless bool) ([]byte, error) {
+	var buffer bytes.Buffer
+	var err error
+	if lossless {
+		err = png.Encode(&buffer, img)
+	} else {
+		err = jpeg.Encode(&buffer, img, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+func outputFormat(lossless bool) string {
+	if lossless {
+		return FormatPNG
+	}
+	return FormatJPEG
+}
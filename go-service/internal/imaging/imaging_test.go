@@ -0,0 +1,142 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPNG returns a PNG whose left half is transparent and right half is
+// opaque noise, which compresses poorly
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	random := rand.New(rand.NewSource(1))
+	for y := 0; y < height; y++ {
+		for x := width / 2; x < width; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(random.Intn(256)), G: uint8(random.Intn(256)), B: uint8(random.Intn(256)), A: 255})
+		}
+	}
+	var buffer bytes.Buffer
+	require.NoError(t, png.Encode(&buffer, img))
+	return buffer.Bytes()
+}
+
+func TestPrepare(t *testing.T) {
+	source := testPNG(t, 800, 400)
+
+	tests := []struct {
+		name          string
+		data          []byte
+		opts          Options
+		format        string
+		width, height int
+		errorContains string
+	}{
+		{name: "Logo keeps format and size within bounds", data: source, opts: Options{Lossless: true}, format: FormatPNG, width: 800, height: 400},
+		{name: "Downscaled to the maximum dimension", data: source, opts: Options{MaxDimension: 200}, format: FormatJPEG, width: 200, height: 100},
+		{name: "Downscaled further to fit the byte limit", data: source, opts: Options{MaxDimension: 800, MaxBytes: 20 << 10}, format: FormatJPEG},
+		{name: "Byte limit too small", data: source, opts: Options{MaxBytes: 100}, errorContains: "does not fit"},
+		{name: "Not an image", data: []byte("<svg/>"), errorContains: "expected PNG or JPEG"},
+		{name: "Truncated image", data: source[:200], errorContains: "invalid image"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := Prepare(tt.data, tt.opts)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, img.Format)
+			if tt.opts.MaxBytes > 0 {
+				assert.LessOrEqual(t, int64(len(img.Data)), tt.opts.MaxBytes)
+			}
+
+			decoded, format, err := image.Decode(bytes.NewReader(img.Data))
+			require.NoError(t, err)
+			assert.Equal(t, tt.format, format)
+			assert.Equal(t, img.Width, decoded.Bounds().Dx())
+			assert.Equal(t, img.Height, decoded.Bounds().Dy())
+			if tt.width > 0 {
+				assert.Equal(t, tt.width, img.Width)
+				assert.Equal(t, tt.height, img.Height)
+			}
+			assert.Equal(t, 2*img.Height, img.Width, "aspect ratio is kept")
+
+			// Transparency is flattened onto white
+			r, g, b, a := decoded.At(0, 0).RGBA()
+			assert.Equal(t, uint32(0xffff), a)
+			assert.Greater(t, r+g+b, uint32(3*0xf000))
+		})
+	}
+}
+
+func TestPrepare_OutputIsOpaqueRGB(t *testing.T) {
+	img, err := Prepare(testPNG(t, 40, 40), Options{Lossless: true})
+	require.NoError(t, err)
+
+	decoded, err := png.Decode(bytes.NewReader(img.Data))
+	require.NoError(t, err)
+	_, ok := decoded.(*image.RGBA)
+	assert.True(t, ok, "PNG output has no alpha channel")
+
+	var buffer bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buffer, image.NewGray(image.Rect(0, 0, 10, 10)), nil))
+	img, err = Prepare(buffer.Bytes(), Options{})
+	require.NoError(t, err)
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(img.Data))
+	require.NoError(t, err)
+	assert.Equal(t, color.YCbCrModel, cfg.ColorModel, "greyscale input is converted to colour")
+}
+
+func TestRead(t *testing.T) {
+	data := testPNG(t, 10, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logo.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "logo.png")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+
+	tests := []struct {
+		name          string
+		location      string
+		errorContains string
+	}{
+		{name: "File", location: path},
+		{name: "URL", location: server.URL + "/logo.png"},
+		{name: "Missing file", location: path + ".missing", errorContains: "failed to open image"},
+		{name: "Missing URL", location: server.URL + "/missing.png", errorContains: "404"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			read, err := Read(tt.location, 0)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, data, read)
+		})
+	}
+}
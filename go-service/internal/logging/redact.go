@@ -0,0 +1,123 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Redacted replaces scrubbed values in log output
+const Redacted = "[REDACTED]"
+
+var (
+	// sensitiveField matches field names whose values are credentials
+	sensitiveField = regexp.MustCompile(`(?i)(password|passwd|secret|token|cookie|authorization|api_?key)`)
+
+	// tokenAssignment matches credentials written into text, such as
+	// Set-Cookie headers, JSON request bodies and X-CSRF-TOKEN headers
+	tokenAssignment = regexp.MustCompile(`(?i)((?:access|refresh|csrf|x-csrf|id|session)[-_]?token|password|secret)("?\s*[:=]\s*"?)([^\s;,"&]+)`)
+
+	// bearerToken matches Authorization header values
+	bearerToken = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]+=*`)
+)
+
+// RedactHook scrubs secrets from log entries before they are written: the
+// values of credential-like fields, token assignments in any text and the
+// configured secret values wherever they appear
+type RedactHook struct {
+	mu      sync.RWMutex
+	secrets []string
+}
+
+// NewRedactHook creates a hook scrubbing the given secret values
+func NewRedactHook(secrets ...string) *RedactHook {
+	h := &RedactHook{}
+	h.SetSecrets(secrets)
+	return h
+}
+
+// SetSecrets replaces the secret values to scrub, e.g. after the
+// configuration is reloaded
+func (h *RedactHook) SetSecrets(secrets []string) {
+	var kept []string
+	for _, secret := range secrets {
+		if secret != "" {
+			kept = append(kept, secret)
+		}
+	}
+	// Longest first, so a secret containing another is scrubbed whole
+	sort.Slice(kept, func(i, j int) bool { return len(kept[i]) > len(kept[j]) })
+
+	h.mu.Lock()
+	h.secrets = kept
+	h.mu.Unlock()
+}
+
+// Levels implements logrus.Hook
+func (h *RedactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook. logrus passes hooks a copy of the entry's
+// fields, so scrubbing them does not affect the caller's entry
+func (h *RedactHook) Fire(entry *logrus.Entry) error {
+	entry.Message = h.Scrub(entry.Message)
+	for key, value := range entry.Data {
+		if sensitiveField.MatchString(key) {
+			entry.Data[key] = redactValue(value)
+			continue
+		}
+		entry.Data[key] = h.scrubValue(value)
+	}
+	return nil
+}
+
+// Scrub removes secrets from text
+func (h *RedactHook) Scrub(text string) string {
+	h.mu.RLock()
+	for _, secret := range h.secrets {
+		text = strings.ReplaceAll(text, secret, Redacted)
+	}
+	h.mu.RUnlock()
+
+	text = tokenAssignment.ReplaceAllString(text, "${1}${2}"+Redacted)
+	return bearerToken.ReplaceAllString(text, "Bearer "+Redacted)
+}
+
+// scrubValue scrubs the text of string-like field values
+func (h *RedactHook) scrubValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return h.Scrub(v)
+	case []string:
+		scrubbed := make([]string, len(v))
+		for i, s := range v {
+			scrubbed[i] = h.Scrub(s)
+		}
+		return scrubbed
+	case error:
+		if message := v.Error(); h.Scrub(message) != message {
+			return errors.New(h.Scrub(message))
+		}
+	case fmt.Stringer:
+		if text := v.String(); h.Scrub(text) != text {
+			return h.Scrub(text)
+		}
+	}
+	return value
+}
+
+// redactValue hides the value of a credential field. Numbers and booleans
+// are kept, as fields such as access_token_length reveal nothing
+func redactValue(value interface{}) interface{} {
+	switch value.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return value
+	}
+	return Redacted
+}
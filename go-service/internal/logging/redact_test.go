@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLogger(hook *RedactHook) (*logrus.Logger, *bytes.Buffer) {
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.SetLevel(logrus.DebugLevel)
+	logger.AddHook(hook)
+	return logger, &out
+}
+
+func TestRedactHook_Fields(t *testing.T) {
+	logger, out := newTestLogger(NewRedactHook())
+
+	fields := logrus.Fields{
+		"csrf_token":          "3f1c9a2e-csrf",
+		"service_password":    "hunter2",
+		"set_cookie_headers":  []string{"accessToken=abc.def; Path=/"},
+		"access_token_length": 212,
+		"username":            "reports@school.edu",
+	}
+	logger.WithFields(fields).Debug("Authenticated")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	assert.Equal(t, Redacted, entry["csrf_token"])
+	assert.Equal(t, Redacted, entry["service_password"])
+	assert.Equal(t, Redacted, entry["set_cookie_headers"])
+	assert.Equal(t, float64(212), entry["access_token_length"], "lengths are kept")
+	assert.Equal(t, "reports@school.edu", entry["username"])
+
+	assert.Equal(t, "3f1c9a2e-csrf", fields["csrf_token"], "caller's fields are not modified")
+}
+
+func TestRedactHook_Text(t *testing.T) {
+	hook := NewRedactHook("s3cret-pass", "")
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{name: "Known secret", text: "dial failed for s3cret-pass", expected: "dial failed for [REDACTED]"},
+		{name: "Cookie header", text: "Set-Cookie: refreshToken=eyJhbGci.x.y; HttpOnly", expected: "Set-Cookie: refreshToken=[REDACTED]; HttpOnly"},
+		{name: "CSRF header", text: "X-Csrf-Token: 8b2e-41", expected: "X-Csrf-Token: [REDACTED]"},
+		{name: "JSON body", text: `{"username":"admin","password":"other"}`, expected: `{"username":"admin","password":"[REDACTED]"}`},
+		{name: "Bearer token", text: "Authorization: Bearer abc.def", expected: "Authorization: Bearer [REDACTED]"},
+		{name: "Bearer token alone", text: "sent bearer abc.def", expected: "sent Bearer [REDACTED]"},
+		{name: "Nothing to scrub", text: "Generated report 42", expected: "Generated report 42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, hook.Scrub(tt.text))
+		})
+	}
+}
+
+func TestRedactHook_MessageAndError(t *testing.T) {
+	hook := NewRedactHook("old-secret")
+	logger, out := newTestLogger(hook)
+
+	hook.SetSecrets([]string{"new-secret"})
+	logger.WithError(errors.New("login as new-secret refused")).Warn("retrying with old-secret")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &entry))
+	assert.Equal(t, "login as [REDACTED] refused", entry["error"])
+	assert.Equal(t, "retrying with old-secret", entry["msg"], "replaced secrets are no longer scrubbed")
+}
@@ -0,0 +1,259 @@
+package mailer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"student-report-service/internal/config"
+)
+
+// Delivery statuses
+const (
+	StatusQueued = "queued"
+	StatusSent   = "sent"
+	StatusFailed = "failed"
+)
+
+// queueSize is how many emails may wait to be sent
+const queueSize = 64
+
+// ErrQueueFull is returned when too many emails are already waiting to be sent
+var ErrQueueFull = errors.New("email queue is full, try again later")
+
+// ReportEmail describes a generated report to be emailed
+type ReportEmail struct {
+	ReportID    string
+	StudentID   int
+	StudentName string
+	GeneratedAt time.Time
+	GeneratedBy string
+	FilePath    string
+	To          []string
+}
+
+// DeliveryRecord is the status of one email delivery
+type DeliveryRecord struct {
+	ID          string    `json:"id"`
+	ReportID    string    `json:"report_id"`
+	StudentID   int       `json:"student_id"`
+	Recipients  []string  `json:"recipients"`
+	Subject     string    `json:"subject"`
+	Status      string    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// Mailer renders report emails from templates and delivers them with retries
+type Mailer struct {
+	config            *config.SMTPConfig
+	sender            Sender
+	maxAttachmentSize int64
+	subject           *template.Template
+	body              *template.Template
+	statusLog         *StatusLog
+
+	// queue holds emails sent one at a time in the background
+	queueMutex sync.Mutex
+	queue      chan queuedEmail
+	closed     bool
+	done       chan struct{}
+}
+
+// queuedEmail is an email waiting to be sent, with the callback told the outcome
+type queuedEmail struct {
+	email    ReportEmail
+	record   DeliveryRecord
+	complete func(DeliveryRecord, error)
+}
+
+// NewMailer creates a mailer; attachments larger than maxAttachmentSize are refused
+func NewMailer(cfg *config.SMTPConfig, maxAttachmentSize int64, sender Sender) (*Mailer, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("config cannot be nil")
+	}
+	if sender == nil {
+		return nil, fmt.Errorf("sender cannot be nil")
+	}
+
+	subject, err := template.New("subject").Parse(cfg.SubjectTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email subject template: %w", err)
+	}
+	body, err := template.New("body").Parse(cfg.BodyTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email body template: %w", err)
+	}
+
+	statusLog, err := NewStatusLog(cfg.StatusPath)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Mailer{
+		config:            cfg,
+		sender:            sender,
+		maxAttachmentSize: maxAttachmentSize,
+		subject:           subject,
+		body:              body,
+		statusLog:         statusLog,
+		queue:             make(chan queuedEmail, queueSize),
+		done:              make(chan struct{}),
+	}
+	go m.work()
+	return m, nil
+}
+
+// SendReport emails the report as an attachment and records the delivery status.
+// A record is returned even when delivery fails.
+func (m *Mailer) SendReport(email ReportEmail) (*DeliveryRecord, error) {
+	record := newDeliveryRecord(email)
+	err := m.deliver(email, record)
+	return record, err
+}
+
+// QueueReport records the email as queued and sends it in the background,
+// so callers need not wait for retries. complete, when not nil, is called
+// with the final record once delivery succeeds or fails
+func (m *Mailer) QueueReport(email ReportEmail, complete func(DeliveryRecord, error)) (*DeliveryRecord, error) {
+	m.queueMutex.Lock()
+	defer m.queueMutex.Unlock()
+	if m.closed || len(m.queue) == cap(m.queue) {
+		return nil, ErrQueueFull
+	}
+
+	record := newDeliveryRecord(email)
+	record.Status = StatusQueued
+	if err := m.statusLog.Append(*record); err != nil {
+		return nil, fmt.Errorf("failed to record queued email: %w", err)
+	}
+	m.queue <- queuedEmail{email: email, record: *record, complete: complete}
+	return record, nil
+}
+
+// Close stops accepting emails and returns once the queued ones are sent
+func (m *Mailer) Close() {
+	m.queueMutex.Lock()
+	if !m.closed {
+		m.closed = true
+		close(m.queue)
+	}
+	m.queueMutex.Unlock()
+	<-m.done
+}
+
+// work sends queued emails until the queue is closed
+func (m *Mailer) work() {
+	defer close(m.done)
+	for queued := range m.queue {
+		record := queued.record
+		err := m.deliver(queued.email, &record)
+		if queued.complete != nil {
+			queued.complete(record, err)
+		}
+	}
+}
+
+// newDeliveryRecord starts the status record of an email
+func newDeliveryRecord(email ReportEmail) *DeliveryRecord {
+	return &DeliveryRecord{
+		ID:         fmt.Sprintf("DLV-%d-%d", email.StudentID, time.Now().UnixNano()),
+		ReportID:   email.ReportID,
+		StudentID:  email.StudentID,
+		Recipients: email.To,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// deliver sends the email and records its final status
+func (m *Mailer) deliver(email ReportEmail, record *DeliveryRecord) error {
+	err := m.send(email, record)
+
+	record.CompletedAt = time.Now()
+	record.Status = StatusSent
+	if err != nil {
+		record.Status = StatusFailed
+		record.Error = err.Error()
+	}
+
+	if logErr := m.statusLog.Append(*record); logErr != nil && err == nil {
+		err = fmt.Errorf("email sent but failed to record delivery status: %w", logErr)
+	}
+
+	return err
+}
+
+// Deliveries returns recorded deliveries, optionally for a single report, newest first
+func (m *Mailer) Deliveries(reportID string, limit int) []DeliveryRecord {
+	return m.statusLog.Query(reportID, limit)
+}
+
+func (m *Mailer) send(email ReportEmail, record *DeliveryRecord) error {
+	info, err := os.Stat(email.FilePath)
+	if err != nil {
+		return fmt.Errorf("report file not found: %w", err)
+	}
+	if m.maxAttachmentSize > 0 && info.Size() > m.maxAttachmentSize {
+		return fmt.Errorf("invalid attachment: report is %d bytes, limit is %d", info.Size(), m.maxAttachmentSize)
+	}
+
+	data, err := os.ReadFile(email.FilePath)
+	if err != nil {
+		return fmt.Errorf("failed to read report file: %w", err)
+	}
+
+	subject, err := render(m.subject, email)
+	if err != nil {
+		return err
+	}
+	record.Subject = subject
+
+	body, err := render(m.body, email)
+	if err != nil {
+		return err
+	}
+
+	msg := &Message{
+		From:    m.config.From,
+		To:      email.To,
+		Subject: strings.TrimSpace(subject),
+		Body:    body,
+		Attachments: []Attachment{{
+			Filename:    filepath.Base(email.FilePath),
+			ContentType: "application/pdf",
+			Data:        data,
+		}},
+	}
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+
+	attempts := m.config.RetryAttempts + 1
+	for attempt := 1; attempt <= attempts; attempt++ {
+		record.Attempts = attempt
+		if err = m.sender.Send(msg); err == nil {
+			return nil
+		}
+		if attempt < attempts {
+			time.Sleep(m.config.RetryDelay)
+		}
+	}
+
+	return fmt.Errorf("email delivery failed after %d attempts: %w", attempts, err)
+}
+
+func render(tmpl *template.Template, email ReportEmail) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, email); err != nil {
+		return "", fmt.Errorf("failed to render email template %s: %w", tmpl.Name(), err)
+	}
+	return buf.String(), nil
+}
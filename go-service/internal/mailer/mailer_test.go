@@ -0,0 +1,259 @@
+package mailer
+
+import (
+	"bufio"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer is a minimal in-process SMTP sink. It rejects the first
+// failFirst DATA commands with a transient error to exercise retries.
+type fakeSMTPServer struct {
+	listener  net.Listener
+	failFirst int
+
+	mutex    sync.Mutex
+	attempts int
+	messages []string
+	rcpts    [][]string
+}
+
+func newFakeSMTPServer(t *testing.T, failFirst int) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &fakeSMTPServer{listener: listener, failFirst: failFirst}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost fake SMTP")
+	var rcpts []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM"):
+			rcpts = nil
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO"):
+			rcpts = append(rcpts, strings.Trim(strings.TrimSpace(line[8:]), "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			s.mutex.Lock()
+			s.attempts++
+			fail := s.attempts <= s.failFirst
+			s.mutex.Unlock()
+			if fail {
+				reply("451 Temporary failure")
+				continue
+			}
+
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.mutex.Lock()
+			s.messages = append(s.messages, data.String())
+			s.rcpts = append(s.rcpts, rcpts)
+			s.mutex.Unlock()
+			reply("250 OK queued")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func newTestConfig(port int) *config.SMTPConfig {
+	return &config.SMTPConfig{
+		Host:            "127.0.0.1",
+		Port:            port,
+		From:            "School Reports <reports@school.test>",
+		Timeout:         5 * time.Second,
+		RetryAttempts:   2,
+		RetryDelay:      10 * time.Millisecond,
+		SubjectTemplate: "Report {{.ReportID}} for {{.StudentName}}",
+		BodyTemplate:    "Attached is the report for {{.StudentName}}.",
+	}
+}
+
+func writeTestReport(t *testing.T, size int) string {
+	path := filepath.Join(t.TempDir(), "student_report_1_John_Doe_20240115_103000.pdf")
+	require.NoError(t, os.WriteFile(path, []byte("%PDF-"+strings.Repeat("x", size)), 0644))
+	return path
+}
+
+func TestMailer_SendReport(t *testing.T) {
+	server := newFakeSMTPServer(t, 0)
+	cfg := newTestConfig(server.port())
+	m, err := NewMailer(cfg, 1024, NewSMTPSender(cfg))
+	require.NoError(t, err)
+
+	record, err := m.SendReport(ReportEmail{
+		ReportID:    "RPT-1-1705312200",
+		StudentID:   1,
+		StudentName: "John Doe",
+		FilePath:    writeTestReport(t, 100),
+		To:          []string{"john@school.test", "Jane Doe <guardian@family.test>"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, StatusSent, record.Status)
+	assert.Equal(t, 1, record.Attempts)
+	assert.Equal(t, "Report RPT-1-1705312200 for John Doe", record.Subject)
+
+	require.Len(t, server.messages, 1)
+	assert.Equal(t, []string{"john@school.test", "guardian@family.test"}, server.rcpts[0], "the envelope has bare addresses")
+
+	// Parse the message and check the attachment survived intact
+	msg, err := mail.ReadMessage(strings.NewReader(server.messages[0]))
+	require.NoError(t, err)
+	assert.Equal(t, "Report RPT-1-1705312200 for John Doe", msg.Header.Get("Subject"))
+	assert.Equal(t, "john@school.test, Jane Doe <guardian@family.test>", msg.Header.Get("To"))
+
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+
+	body, err := reader.NextPart()
+	require.NoError(t, err)
+	text, _ := io.ReadAll(body)
+	assert.Contains(t, string(text), "Attached is the report for John Doe.")
+
+	attachment, err := reader.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "student_report_1_John_Doe_20240115_103000.pdf", attachment.FileName())
+	assert.Equal(t, "base64", attachment.Header.Get("Content-Transfer-Encoding"))
+
+	assert.Len(t, m.Deliveries("RPT-1-1705312200", 0), 1)
+	assert.Empty(t, m.Deliveries("RPT-other", 0))
+}
+
+func TestMailer_RetriesTransientFailures(t *testing.T) {
+	server := newFakeSMTPServer(t, 2)
+	cfg := newTestConfig(server.port())
+	m, err := NewMailer(cfg, 0, NewSMTPSender(cfg))
+	require.NoError(t, err)
+
+	record, err := m.SendReport(ReportEmail{ReportID: "RPT-1", StudentID: 1, FilePath: writeTestReport(t, 10), To: []string{"john@school.test"}})
+	require.NoError(t, err)
+	assert.Equal(t, 3, record.Attempts)
+	assert.Len(t, server.messages, 1)
+}
+
+func TestMailer_QueueReport(t *testing.T) {
+	server := newFakeSMTPServer(t, 1)
+	cfg := newTestConfig(server.port())
+	m, err := NewMailer(cfg, 0, NewSMTPSender(cfg))
+	require.NoError(t, err)
+
+	completed := make(chan DeliveryRecord, 1)
+	queued, err := m.QueueReport(ReportEmail{ReportID: "RPT-1", StudentID: 1, FilePath: writeTestReport(t, 10), To: []string{"john@school.test"}},
+		func(record DeliveryRecord, err error) {
+			assert.NoError(t, err)
+			completed <- record
+		})
+	require.NoError(t, err)
+	assert.Equal(t, StatusQueued, queued.Status)
+
+	// Close waits for the queued email, retry included
+	m.Close()
+	record := <-completed
+	assert.Equal(t, queued.ID, record.ID)
+	assert.Equal(t, StatusSent, record.Status)
+	assert.Equal(t, 2, record.Attempts)
+
+	deliveries := m.Deliveries("RPT-1", 0)
+	require.Len(t, deliveries, 1, "only the latest status of a delivery is listed")
+	assert.Equal(t, StatusSent, deliveries[0].Status)
+
+	_, err = m.QueueReport(ReportEmail{ReportID: "RPT-2", StudentID: 1, To: []string{"john@school.test"}}, nil)
+	assert.ErrorIs(t, err, ErrQueueFull, "a closed mailer accepts no more emails")
+}
+
+func TestMailer_FailureIsRecorded(t *testing.T) {
+	server := newFakeSMTPServer(t, 10)
+	cfg := newTestConfig(server.port())
+	cfg.StatusPath = filepath.Join(t.TempDir(), "deliveries.jsonl")
+	m, err := NewMailer(cfg, 0, NewSMTPSender(cfg))
+	require.NoError(t, err)
+
+	record, err := m.SendReport(ReportEmail{ReportID: "RPT-1", StudentID: 1, FilePath: writeTestReport(t, 10), To: []string{"john@school.test"}})
+	assert.Error(t, err)
+	assert.Equal(t, StatusFailed, record.Status)
+	assert.Equal(t, 3, record.Attempts)
+
+	// Status survives a restart
+	reloaded, err := NewStatusLog(cfg.StatusPath)
+	require.NoError(t, err)
+	assert.Len(t, reloaded.Query("RPT-1", 0), 1)
+}
+
+func TestMailer_RejectsOversizedAttachment(t *testing.T) {
+	server := newFakeSMTPServer(t, 0)
+	cfg := newTestConfig(server.port())
+	m, err := NewMailer(cfg, 50, NewSMTPSender(cfg))
+	require.NoError(t, err)
+
+	record, err := m.SendReport(ReportEmail{ReportID: "RPT-1", StudentID: 1, FilePath: writeTestReport(t, 100), To: []string{"john@school.test"}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "limit is 50")
+	assert.Equal(t, 0, record.Attempts)
+	assert.Empty(t, server.messages)
+}
+
+func TestMessage_Validate(t *testing.T) {
+	assert.NoError(t, (&Message{From: "a@b.test", To: []string{"c@d.test"}}).Validate())
+	assert.Error(t, (&Message{From: "a@b.test"}).Validate())
+	assert.Error(t, (&Message{From: "a@b.test", To: []string{"not-an-address"}}).Validate())
+}
@@ -0,0 +1,117 @@
+package mailer
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Attachment is a file attached to a message
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// Message is a plain-text email with optional attachments
+type Message struct {
+	From        string
+	To          []string
+	Subject     string
+	Body        string
+	Attachments []Attachment
+}
+
+// Validate checks the sender and recipient addresses
+func (m *Message) Validate() error {
+	if _, err := mail.ParseAddress(m.From); err != nil {
+		return fmt.Errorf("invalid sender address %q: %w", m.From, err)
+	}
+	if len(m.To) == 0 {
+		return fmt.Errorf("invalid message: no recipients")
+	}
+	for _, to := range m.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("invalid recipient address %q: %w", to, err)
+		}
+	}
+	return nil
+}
+
+// Bytes renders the message as RFC 5322 / MIME multipart content
+func (m *Message) Bytes() []byte {
+	var buf bytes.Buffer
+	boundary := newBoundary()
+
+	writeHeader(&buf, "From", m.From)
+	writeHeader(&buf, "To", strings.Join(m.To, ", "))
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&buf, "MIME-Version", "1.0")
+	writeHeader(&buf, "Content-Type", fmt.Sprintf("multipart/mixed; boundary=%q", boundary))
+	buf.WriteString("\r\n")
+
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	writeHeader(&buf, "Content-Type", "text/plain; charset=utf-8")
+	writeHeader(&buf, "Content-Transfer-Encoding", "8bit")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+	buf.WriteString("\r\n")
+
+	for _, attachment := range m.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		fmt.Fprintf(&buf, "--%s\r\n", boundary)
+		writeHeader(&buf, "Content-Type", fmt.Sprintf("%s; name=%q", contentType, attachment.Filename))
+		writeHeader(&buf, "Content-Transfer-Encoding", "base64")
+		writeHeader(&buf, "Content-Disposition", fmt.Sprintf("attachment; filename=%q", attachment.Filename))
+		buf.WriteString("\r\n")
+		writeBase64Lines(&buf, attachment.Data)
+	}
+
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+	return buf.Bytes()
+}
+
+// envelopeAddress returns the bare address of a header address such as
+// "Jane Doe <jane@school.edu>", as used in SMTP MAIL FROM and RCPT TO
+func envelopeAddress(address string) (string, error) {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Address, nil
+}
+
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	fmt.Fprintf(buf, "%s: %s\r\n", name, value)
+}
+
+// writeBase64Lines writes base64 content wrapped at 76 characters per line
+func writeBase64Lines(buf *bytes.Buffer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76])
+		buf.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	if encoded != "" {
+		buf.WriteString(encoded)
+		buf.WriteString("\r\n")
+	}
+}
+
+func newBoundary() string {
+	raw := make([]byte, 12)
+	rand.Read(raw)
+	return "report-" + hex.EncodeToString(raw)
+}
@@ -0,0 +1,95 @@
+package mailer
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"student-report-service/internal/config"
+)
+
+// Sender transmits a rendered message
+type Sender interface {
+	Send(msg *Message) error
+}
+
+// SMTPSender sends messages through an SMTP server, upgrading to TLS with
+// STARTTLS when the server offers it
+type SMTPSender struct {
+	config *config.SMTPConfig
+}
+
+// NewSMTPSender creates a sender for the configured SMTP server
+func NewSMTPSender(cfg *config.SMTPConfig) *SMTPSender {
+	return &SMTPSender{config: cfg}
+}
+
+// Send delivers the message in a single SMTP session
+func (s *SMTPSender) Send(msg *Message) error {
+	addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+
+	conn, err := net.DialTimeout("tcp", addr, s.config.Timeout)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	if s.config.Timeout > 0 {
+		conn.SetDeadline(time.Now().Add(s.config.Timeout))
+	}
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.config.Host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	} else if s.config.RequireTLS {
+		return fmt.Errorf("SMTP server does not support STARTTLS")
+	}
+
+	if s.config.Username != "" {
+		auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	// The envelope takes bare addresses; display names only go in the headers
+	from, err := envelopeAddress(msg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address %q: %w", msg.From, err)
+	}
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM rejected: %w", err)
+	}
+	for _, to := range msg.To {
+		rcpt, err := envelopeAddress(to)
+		if err != nil {
+			return fmt.Errorf("invalid recipient address %q: %w", to, err)
+		}
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("SMTP RCPT TO %s rejected: %w", rcpt, err)
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA rejected: %w", err)
+	}
+	if _, err := writer.Write(msg.Bytes()); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected message: %w", err)
+	}
+
+	return client.Quit()
+}
@@ -0,0 +1,99 @@
+package mailer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// StatusLog keeps delivery records in memory and, when a path is set,
+// appends them to a JSON-lines file so history survives restarts
+type StatusLog struct {
+	path    string
+	records []DeliveryRecord
+	mutex   sync.Mutex
+}
+
+// NewStatusLog loads existing records from path; an empty path keeps records in memory only
+func NewStatusLog(path string) (*StatusLog, error) {
+	log := &StatusLog{path: path}
+	if path == "" {
+		return log, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create delivery status directory: %w", err)
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return log, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open delivery status log: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record DeliveryRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err == nil {
+			log.records = append(log.records, record)
+		}
+	}
+
+	return log, scanner.Err()
+}
+
+// Append records a delivery
+func (l *StatusLog) Append(record DeliveryRecord) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.records = append(l.records, record)
+	if l.path == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// Query returns records for reportID (all reports when empty), newest first.
+// A queued delivery is recorded again once sent, and only its latest status
+// is returned
+func (l *StatusLog) Query(reportID string, limit int) []DeliveryRecord {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	result := make([]DeliveryRecord, 0)
+	seen := make(map[string]bool)
+	for i := len(l.records) - 1; i >= 0; i-- {
+		if reportID != "" && l.records[i].ReportID != reportID {
+			continue
+		}
+		if seen[l.records[i].ID] {
+			continue
+		}
+		seen[l.records[i].ID] = true
+		result = append(result, l.records[i])
+		if limit > 0 && len(result) == limit {
+			break
+		}
+	}
+	return result
+}
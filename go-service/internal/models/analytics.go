@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// AgeUnknown groups students without a valid date of birth
+const AgeUnknown = "unknown"
+
+// ClassAnalytics holds demographic aggregates for one class section
+type ClassAnalytics struct {
+	ClassName string `json:"class_name"`
+	Section   string `json:"section,omitempty"`
+	Headcount int    `json:"headcount"`
+
+	// Gender counts students per gender, with "Unspecified" for missing values
+	Gender map[string]int `json:"gender"`
+	// AgeDistribution counts students per age in whole years
+	AgeDistribution map[string]int `json:"age_distribution"`
+	// AdmissionsByTerm counts admissions per term, e.g. "2024-T1"
+	AdmissionsByTerm map[string]int `json:"admissions_by_term"`
+
+	// Shares are fractions of the headcount between 0 and 1
+	SystemAccessShare    float64 `json:"system_access_share"`
+	MissingGuardianShare float64 `json:"missing_guardian_share"`
+}
+
+// ClassAnalyticsReport is the response of GET /api/v1/analytics/classes
+type ClassAnalyticsReport struct {
+	GeneratedAt time.Time        `json:"generated_at"`
+	Cached      bool             `json:"cached"`
+	Students    int              `json:"students"`
+	Classes     []ClassAnalytics `json:"classes"`
+}
+
+// AdmissionTerm names the term a date falls in. Terms split the calendar
+// year into thirds: T1 is January-April, T2 May-August and T3 September-December.
+func AdmissionTerm(t time.Time) string {
+	return fmt.Sprintf("%d-T%d", t.Year(), (int(t.Month())-1)/4+1)
+}
+
+// AgeOn returns the student's age in whole years on the given day, or
+// AgeUnknown when the date of birth is missing or invalid
+func (s *Student) AgeOn(day time.Time) string {
+	if s.DOB == nil {
+		return AgeUnknown
+	}
+	dob, err := ParseDate(*s.DOB)
+	if err != nil || dob.After(day) {
+		return AgeUnknown
+	}
+
+	age := day.Year() - dob.Year()
+	if day.Month() < dob.Month() || (day.Month() == dob.Month() && day.Day() < dob.Day()) {
+		age--
+	}
+	return strconv.Itoa(age)
+}
+
+// HasGuardianDetails reports whether the guardian's name and phone are both recorded
+func (s *Student) HasGuardianDetails() bool {
+	return SafeString(s.GuardianName, "") != "" && SafeString(s.GuardianPhone, "") != ""
+}
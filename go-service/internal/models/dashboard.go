@@ -0,0 +1,104 @@
+package models
+
+import "time"
+
+// Dashboard metric keys stored in snapshots
+const (
+	MetricStudents  = "students"
+	MetricTeachers  = "teachers"
+	MetricParents   = "parents"
+	MetricOnLeave   = "on_leave"
+	MetricLeaveDays = "leave_days"
+)
+
+// DashboardCount is a headcount for the current year and its change from the previous year
+type DashboardCount struct {
+	CurrentYear   int     `json:"totalNumberCurrentYear"`
+	PercentChange float64 `json:"totalNumberPercInComparisonFromPrevYear"`
+	ValueChange   int     `json:"totalNumberValueInComparisonFromPrevYear"`
+}
+
+// DashboardLeavePolicy is approved leave usage for one policy
+type DashboardLeavePolicy struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	TotalDaysUsed Number `json:"totalDaysUsed"`
+}
+
+// Celebration is an upcoming birthday or anniversary
+type Celebration struct {
+	UserID    int    `json:"userId"`
+	User      string `json:"user"`
+	Event     string `json:"event"`
+	EventDate string `json:"eventDate"`
+}
+
+// UpcomingLeave is approved leave overlapping the next 30 days
+type UpcomingLeave struct {
+	UserID    int    `json:"userId"`
+	User      string `json:"user"`
+	FromDate  string `json:"fromDate"`
+	ToDate    string `json:"toDate"`
+	LeaveType string `json:"leaveType"`
+}
+
+// Dashboard represents the response of GET /dashboard (get_dashboard_data)
+type Dashboard struct {
+	Students      DashboardCount         `json:"students"`
+	Teachers      DashboardCount         `json:"teachers"`
+	Parents       DashboardCount         `json:"parents"`
+	Notices       []Notice               `json:"notices"`
+	LeavePolicies []DashboardLeavePolicy `json:"leavePolicies"`
+	LeaveHistory  []LeaveRequest         `json:"leaveHistory"`
+	Celebrations  []Celebration          `json:"celebrations"`
+	OneMonthLeave []UpcomingLeave        `json:"oneMonthLeave"`
+}
+
+// PeopleOnLeave counts the distinct users with approved leave in the next 30 days
+func (d *Dashboard) PeopleOnLeave() int {
+	users := make(map[int]bool)
+	for _, leave := range d.OneMonthLeave {
+		users[leave.UserID] = true
+	}
+	return len(users)
+}
+
+// LeaveDays sums approved leave days across policies
+func (d *Dashboard) LeaveDays() float64 {
+	total := 0.0
+	for _, policy := range d.LeavePolicies {
+		total += float64(policy.TotalDaysUsed)
+	}
+	return total
+}
+
+// Metrics returns the values compared between dashboard snapshots
+func (d *Dashboard) Metrics() map[string]float64 {
+	return map[string]float64{
+		MetricStudents:  float64(d.Students.CurrentYear),
+		MetricTeachers:  float64(d.Teachers.CurrentYear),
+		MetricParents:   float64(d.Parents.CurrentYear),
+		MetricOnLeave:   float64(d.PeopleOnLeave()),
+		MetricLeaveDays: d.LeaveDays(),
+	}
+}
+
+// KPI is a headline figure shown as a tile on the dashboard report
+type KPI struct {
+	Label string  `json:"label"`
+	Value float64 `json:"value"`
+
+	// YearChange is the percentage change from the previous year, when known
+	YearChange *float64 `json:"year_change,omitempty"`
+	// SnapshotChange is the difference from the baseline snapshot, when one exists
+	SnapshotChange *float64 `json:"snapshot_change,omitempty"`
+}
+
+// DashboardReport is the data rendered in the dashboard summary report
+type DashboardReport struct {
+	Dashboard *Dashboard `json:"dashboard"`
+	KPIs      []KPI      `json:"kpis"`
+
+	// BaselineAt is when the snapshot used for comparison was taken; zero when there is none
+	BaselineAt time.Time `json:"baseline_at,omitempty"`
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Leave status IDs as seeded in the leave_status table
+const (
+	LeaveStatusOnReview  = 1
+	LeaveStatusApproved  = 2
+	LeaveStatusCancelled = 3
+)
+
+// Number decodes JSON numbers that PostgreSQL aggregates may return as strings
+type Number float64
+
+// UnmarshalJSON accepts 3, 3.5, "3" and null
+func (n *Number) UnmarshalJSON(data []byte) error {
+	raw := strings.Trim(string(data), `"`)
+	if raw == "" || raw == "null" {
+		*n = 0
+		return nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s: %w", data, err)
+	}
+	*n = Number(value)
+	return nil
+}
+
+// LeaveRequest represents a leave request from the Node.js /leave API
+type LeaveRequest struct {
+	ID        int     `json:"id"`
+	Policy    string  `json:"policy"`
+	PolicyID  int     `json:"policyId"`
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Note      *string `json:"note"`
+	StatusID  int     `json:"statusId"`
+	Status    string  `json:"status"`
+	Submitted *string `json:"submitted"`
+	Updated   *string `json:"updated"`
+	Approved  *string `json:"approved"`
+	Approver  *string `json:"approver"`
+	User      string  `json:"user"`
+	Days      Number  `json:"days"`
+}
+
+// LeaveHistoryResponse represents the response of GET /leave/request
+type LeaveHistoryResponse struct {
+	LeaveHistory []LeaveRequest `json:"leaveHistory"`
+}
+
+// PendingLeavesResponse represents the response of GET /leave/pending
+type PendingLeavesResponse struct {
+	PendingLeaves []LeaveRequest `json:"pendingLeaves"`
+}
+
+// LeavePolicy represents a leave policy from the Node.js API
+type LeavePolicy struct {
+	ID                   int    `json:"id"`
+	Name                 string `json:"name"`
+	IsActive             bool   `json:"isActive"`
+	TotalUsersAssociated Number `json:"totalUsersAssociated"`
+}
+
+// LeavePoliciesResponse represents the response of GET /leave/policies
+type LeavePoliciesResponse struct {
+	LeavePolicies []LeavePolicy `json:"leavePolicies"`
+}
+
+// LeaveBalance summarises leave taken against a policy
+type LeaveBalance struct {
+	Policy    string   `json:"policy"`
+	DaysTaken float64  `json:"days_taken"`
+	Allowance *float64 `json:"allowance"`
+	Remaining *float64 `json:"remaining"`
+}
+
+// LeaveReport is the data rendered in a leave history report
+type LeaveReport struct {
+	UserID   int            `json:"user_id"`
+	UserName string         `json:"user_name"`
+	From     time.Time      `json:"from,omitempty"`
+	To       time.Time      `json:"to,omitempty"`
+	Requests []LeaveRequest `json:"requests"`
+	Balances []LeaveBalance `json:"balances"`
+}
+
+// ParseDate parses the date formats returned by the Node.js API
+// (RFC 3339 timestamps for DATE columns, or plain YYYY-MM-DD)
+func ParseDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// FormatDate renders an API date as YYYY-MM-DD, or defaultValue if it cannot be parsed
+func FormatDate(ptr *string, defaultValue string) string {
+	if ptr == nil || *ptr == "" {
+		return defaultValue
+	}
+	t, err := ParseDate(*ptr)
+	if err != nil {
+		return *ptr
+	}
+	return t.Format("2006-01-02")
+}
+
+// Overlaps reports whether the leave overlaps the [from, to] range; zero bounds are open
+func (l *LeaveRequest) Overlaps(from, to time.Time) bool {
+	start, err := ParseDate(l.From)
+	if err != nil {
+		return false
+	}
+	end, err := ParseDate(l.To)
+	if err != nil {
+		end = start
+	}
+
+	if !from.IsZero() && end.Before(from) {
+		return false
+	}
+	if !to.IsZero() && start.After(to) {
+		return false
+	}
+	return true
+}
+
+// MarshalJSON renders Number as a plain JSON number
+func (n Number) MarshalJSON() ([]byte, error) {
+	return json.Marshal(float64(n))
+}
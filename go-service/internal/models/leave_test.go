@@ -0,0 +1,52 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaveRequest_Unmarshal(t *testing.T) {
+	// node-postgres returns EXTRACT() results as strings
+	var leave LeaveRequest
+	err := json.Unmarshal([]byte(`{"id":1,"policy":"Sick Leave","from":"2024-03-10T00:00:00.000Z","to":"2024-03-11T00:00:00.000Z","statusId":2,"days":"2","approver":null}`), &leave)
+	require.NoError(t, err)
+	assert.Equal(t, Number(2), leave.Days)
+	assert.Nil(t, leave.Approver)
+
+	err = json.Unmarshal([]byte(`{"days":1.5}`), &leave)
+	require.NoError(t, err)
+	assert.Equal(t, Number(1.5), leave.Days)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"days":"two"}`), &leave))
+}
+
+func TestLeaveRequest_Overlaps(t *testing.T) {
+	leave := LeaveRequest{From: "2024-03-10T00:00:00.000Z", To: "2024-03-12T00:00:00.000Z"}
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		expected bool
+	}{
+		{"open range", time.Time{}, time.Time{}, true},
+		{"range contains leave", date("2024-03-01"), date("2024-03-31"), true},
+		{"range ends on first day", date("2024-03-01"), date("2024-03-10"), true},
+		{"range starts on last day", date("2024-03-12"), time.Time{}, true},
+		{"range before leave", time.Time{}, date("2024-03-09"), false},
+		{"range after leave", date("2024-03-13"), time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, leave.Overlaps(tt.from, tt.to))
+		})
+	}
+}
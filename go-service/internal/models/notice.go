@@ -0,0 +1,115 @@
+package models
+
+import "time"
+
+// Notice status IDs as seeded in the notice_status table
+const (
+	NoticeStatusDraft    = 1
+	NoticeStatusApproved = 5
+	NoticeStatusDeleted  = 6
+)
+
+// Notice recipient types stored in notices.recipient_type
+const (
+	NoticeRecipientEveryone = "EV"
+	NoticeRecipientSpecific = "SP"
+)
+
+// Notice represents a notice in the list returned by GET /notices
+type Notice struct {
+	ID           int     `json:"id"`
+	Title        string  `json:"title"`
+	Description  string  `json:"description"`
+	AuthorID     *int    `json:"authorId"`
+	CreatedDate  *string `json:"createdDate"`
+	UpdatedDate  *string `json:"updatedDate"`
+	Author       *string `json:"author"`
+	ReviewerName *string `json:"reviewerName"`
+	ReviewedDate *string `json:"reviewedDate"`
+	Status       string  `json:"status"`
+	StatusID     int     `json:"statusId"`
+}
+
+// NoticesResponse represents the response of GET /notices
+type NoticesResponse struct {
+	Notices []Notice `json:"notices"`
+}
+
+// NoticeDetail represents a notice returned by GET /notices/:id, including its audience
+type NoticeDetail struct {
+	ID            int     `json:"id"`
+	Title         string  `json:"title"`
+	Description   string  `json:"description"`
+	StatusID      int     `json:"status"`
+	AuthorID      *int    `json:"authorId"`
+	CreatedDate   *string `json:"createdDate"`
+	UpdatedDate   *string `json:"updatedDate"`
+	RecipientType string  `json:"recipientType"`
+	RecipientRole *int    `json:"recipientRole"`
+	FirstField    *string `json:"firstField"`
+	Author        *string `json:"author"`
+}
+
+// NoticeRecipient is a recipient group configured in notice_recipient_types
+type NoticeRecipient struct {
+	ID                   int     `json:"id"`
+	RoleID               int     `json:"roleId"`
+	RoleName             string  `json:"roleName"`
+	PrimaryDependentName *string `json:"primaryDependentName"`
+}
+
+// NoticeRecipientsResponse represents the response of GET /notices/recipients
+type NoticeRecipientsResponse struct {
+	NoticeRecipients []NoticeRecipient `json:"noticeRecipients"`
+}
+
+// RecipientGroup selects notice readers by role and, optionally, the role's
+// dependent field (department ID for teachers, class name for students)
+type RecipientGroup struct {
+	RoleID int    `json:"role_id"`
+	Field  string `json:"field,omitempty"`
+}
+
+// AddressedTo reports whether the notice is addressed to the group, following
+// the rules of the backend get_notices function
+func (n *NoticeDetail) AddressedTo(group RecipientGroup) bool {
+	switch n.RecipientType {
+	case NoticeRecipientEveryone:
+		return true
+	case NoticeRecipientSpecific:
+		if n.RecipientRole == nil || *n.RecipientRole != group.RoleID {
+			return false
+		}
+		field := SafeString(n.FirstField, "")
+		return field == "" || field == group.Field
+	default:
+		return false
+	}
+}
+
+// PublishedAt returns when the notice was approved, falling back to its creation date
+func (n *Notice) PublishedAt() time.Time {
+	for _, value := range []*string{n.ReviewedDate, n.CreatedDate} {
+		if value != nil {
+			if t, err := ParseDate(*value); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// DigestNotice is a notice as printed in a digest
+type DigestNotice struct {
+	Notice    Notice    `json:"notice"`
+	Published time.Time `json:"published"`
+}
+
+// NoticeDigest is the data rendered in a notice board digest
+type NoticeDigest struct {
+	Title    string         `json:"title"`
+	Audience string         `json:"audience"`
+	From     time.Time      `json:"from,omitempty"`
+	To       time.Time      `json:"to,omitempty"`
+	Notices  []DigestNotice `json:"notices"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import "strings"
+
+// ReportPacket is an ordered set of reports combined into a single PDF
+type ReportPacket struct {
+	Title string       `json:"title"`
+	Parts []PacketPart `json:"parts"`
+}
+
+// PacketPart is one report in a packet. Exactly one of the reports is set
+type PacketPart struct {
+	Student *Student     `json:"student,omitempty"`
+	Staff   *Staff       `json:"staff,omitempty"`
+	Leave   *LeaveReport `json:"leave,omitempty"`
+	Roster  *ClassRoster `json:"roster,omitempty"`
+}
+
+// Title names the report a part holds, as listed in the packet's contents
+func (p PacketPart) Title() string {
+	switch {
+	case p.Student != nil:
+		return "Student Profile: " + p.Student.FormatName()
+	case p.Staff != nil:
+		return "Staff Profile: " + p.Staff.FormatName()
+	case p.Leave != nil:
+		return "Leave History: " + p.Leave.UserName
+	case p.Roster != nil:
+		return "Class Roster: " + strings.TrimSpace(p.Roster.ClassName+" "+p.Roster.Section)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DefaultRequiredStudentFields are checked when no rules are configured
+var DefaultRequiredStudentFields = []string{"dob", "guardianPhone", "roll", "admissionDate", "currentAddress", "permanentAddress"}
+
+// StudentField is a student attribute a completeness rule can require
+type StudentField struct {
+	Key     string
+	Label   string
+	present func(*Student) bool
+}
+
+// Present reports whether the student has a value for the field
+func (f StudentField) Present(s *Student) bool {
+	return f.present(s)
+}
+
+func hasText(value *string) bool {
+	return value != nil && strings.TrimSpace(*value) != ""
+}
+
+// studentFields lists the checkable fields by their JSON key in Student
+var studentFields = []StudentField{
+	{"email", "Email", func(s *Student) bool { return strings.TrimSpace(s.Email) != "" }},
+	{"phone", "Phone", func(s *Student) bool { return hasText(s.Phone) }},
+	{"gender", "Gender", func(s *Student) bool { return hasText(s.Gender) }},
+	{"dob", "Date of Birth", func(s *Student) bool { return hasText(s.DOB) }},
+	{"class", "Class", func(s *Student) bool { return hasText(s.Class) }},
+	{"section", "Section", func(s *Student) bool { return hasText(s.Section) }},
+	{"roll", "Roll Number", func(s *Student) bool { return s.Roll != nil }},
+	{"admissionDate", "Admission Date", func(s *Student) bool { return hasText(s.AdmissionDate) }},
+	{"fatherName", "Father's Name", func(s *Student) bool { return hasText(s.FatherName) }},
+	{"fatherPhone", "Father's Phone", func(s *Student) bool { return hasText(s.FatherPhone) }},
+	{"motherName", "Mother's Name", func(s *Student) bool { return hasText(s.MotherName) }},
+	{"motherPhone", "Mother's Phone", func(s *Student) bool { return hasText(s.MotherPhone) }},
+	{"guardianName", "Guardian's Name", func(s *Student) bool { return hasText(s.GuardianName) }},
+	{"guardianPhone", "Guardian's Phone", func(s *Student) bool { return hasText(s.GuardianPhone) }},
+	{"relationOfGuardian", "Relation of Guardian", func(s *Student) bool { return hasText(s.RelationOfGuardian) }},
+	{"currentAddress", "Current Address", func(s *Student) bool { return hasText(s.CurrentAddress) }},
+	{"permanentAddress", "Permanent Address", func(s *Student) bool { return hasText(s.PermanentAddress) }},
+}
+
+// ResolveStudentFields maps field keys to checkable fields, rejecting unknown keys
+func ResolveStudentFields(keys []string) ([]StudentField, error) {
+	fields := make([]StudentField, 0, len(keys))
+	var unknown []string
+	for _, key := range keys {
+		found := false
+		for _, field := range studentFields {
+			if strings.EqualFold(field.Key, strings.TrimSpace(key)) {
+				fields = append(fields, field)
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("invalid required fields: %s", strings.Join(unknown, ", "))
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid required fields: none given")
+	}
+	return fields, nil
+}
+
+// StudentQuality lists the required fields a student is missing
+type StudentQuality struct {
+	StudentID int      `json:"student_id"`
+	Name      string   `json:"name"`
+	Class     string   `json:"class,omitempty"`
+	Section   string   `json:"section,omitempty"`
+	Roll      *int     `json:"roll,omitempty"`
+	Missing   []string `json:"missing"`
+}
+
+// QualityReport is the result of a student data completeness check
+type QualityReport struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	ClassName   string    `json:"class_name,omitempty"`
+	Section     string    `json:"section,omitempty"`
+
+	// RequiredFields are the labels of the fields checked
+	RequiredFields []string `json:"required_fields"`
+
+	Students int `json:"students"`
+	Complete int `json:"complete"`
+	// MissingByField counts students missing each required field
+	MissingByField map[string]int `json:"missing_by_field"`
+	// Incomplete lists students missing at least one field, ordered by class, section and roll
+	Incomplete []StudentQuality `json:"incomplete"`
+}
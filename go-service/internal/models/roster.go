@@ -0,0 +1,15 @@
+package models
+
+// Roster sort orders
+const (
+	RosterSortRoll = "roll"
+	RosterSortName = "name"
+)
+
+// ClassRoster is the data rendered in a class roster report
+type ClassRoster struct {
+	ClassName string    `json:"class_name"`
+	Section   string    `json:"section,omitempty"`
+	SortBy    string    `json:"sort_by"`
+	Students  []Student `json:"students"`
+}
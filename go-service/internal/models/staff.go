@@ -0,0 +1,57 @@
+package models
+
+// Staff represents the staff profile returned by GET /staffs/:id
+type Staff struct {
+	ID               int     `json:"id"`
+	Name             string  `json:"name"`
+	Email            string  `json:"email"`
+	SystemAccess     bool    `json:"systemAccess"`
+	Role             *int    `json:"role"`
+	RoleName         *string `json:"roleName"`
+	ReporterID       *int    `json:"reporterId"`
+	ReporterName     *string `json:"reporterName"`
+	Department       *string `json:"department"`
+	Gender           *string `json:"gender"`
+	MaritalStatus    *string `json:"maritalStatus"`
+	JoinDate         *string `json:"joinDate"`
+	Qualification    *string `json:"qualification"`
+	Experience       *string `json:"experience"`
+	DOB              *string `json:"dob"`
+	Phone            *string `json:"phone"`
+	FatherName       *string `json:"fatherName"`
+	MotherName       *string `json:"motherName"`
+	EmergencyPhone   *string `json:"emergencyPhone"`
+	CurrentAddress   *string `json:"currentAddress"`
+	PermanentAddress *string `json:"permanentAddress"`
+}
+
+// StaffListItem represents a staff member in the list view
+type StaffListItem struct {
+	ID           int     `json:"id"`
+	Name         string  `json:"name"`
+	Email        string  `json:"email"`
+	Role         *string `json:"role"`
+	SystemAccess bool    `json:"systemAccess"`
+	LastLogin    *string `json:"lastLogin"`
+}
+
+// StaffListResponse represents the response of GET /staffs
+type StaffListResponse struct {
+	Staffs []StaffListItem `json:"staffs"`
+}
+
+// FormatName safely returns the staff name or "N/A" if empty
+func (s *Staff) FormatName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return "N/A"
+}
+
+// FormatEmail safely returns the staff email or "N/A" if empty
+func (s *Staff) FormatEmail() string {
+	if s.Email != "" {
+		return s.Email
+	}
+	return "N/A"
+}
@@ -25,6 +25,10 @@ type Student struct {
 	PermanentAddress   *string `json:"permanentAddress"`
 	AdmissionDate      *string `json:"admissionDate"`
 	ReporterName       *string `json:"reporterName"`
+
+	// Photo is a prepared JPEG attached by the report service; the backend
+	// does not return it
+	Photo []byte `json:"-"`
 }
 
 // APIResponse represents the standardized API response from Node.js backend
@@ -46,6 +50,10 @@ type ReportMetadata struct {
 	GeneratedAt time.Time `json:"generated_at"`
 	GeneratedBy string    `json:"generated_by"`
 	ReportID    string    `json:"report_id"`
+	Profile     string    `json:"profile,omitempty"`
+	Archival    bool      `json:"archival,omitempty"`
+	// School names the school in report footers and covers
+	School string `json:"school,omitempty"`
 }
 
 // StudentListResponse represents the response for listing students
@@ -0,0 +1,38 @@
+package pdf
+
+import (
+	"fmt"
+	"time"
+
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+)
+
+// CheckAssets renders a sample archival report in memory with the report
+// template, embedded fonts and logo, so a broken asset is found by the
+// readiness check rather than by the next report
+func (g *Generator) CheckAssets() error {
+	metadata := &models.ReportMetadata{
+		GeneratedAt: time.Now(),
+		GeneratedBy: "readiness check",
+		ReportID:    "READY-CHECK",
+		Archival:    true,
+		School:      g.cfg().SchoolName,
+	}
+	doc := &content.Document{
+		Title:    "Readiness Check",
+		Metadata: metadata,
+		Sections: []content.Section{{
+			Title:  "Assets",
+			Groups: []content.Group{{Rows: []content.Row{{Label: "Fonts", Value: "DejaVu Sans Condensed"}}}},
+		}},
+		Portrait: true,
+	}
+
+	pdf := g.newDocument(metadata)
+	g.renderDocument(pdf, doc)
+	if _, err := g.renderReport(pdf, documentInfo{Title: doc.Title, Metadata: metadata}); err != nil {
+		return fmt.Errorf("report template failed to render: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package pdf
+
+import (
+	"os"
+	"testing"
+
+	"student-report-service/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_CheckAssets(t *testing.T) {
+	cfg := &config.ReportConfig{
+		OutputDir:     t.TempDir(),
+		MaxFileSize:   10 * 1024 * 1024,
+		WatermarkText: "CONFIDENTIAL",
+		LogoPath:      writeTestLogo(t),
+		SchoolName:    "Springfield Elementary",
+	}
+	generator, err := NewGenerator(cfg)
+	require.NoError(t, err)
+
+	assert.NoError(t, generator.CheckAssets())
+
+	entries, err := os.ReadDir(cfg.OutputDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the sample report is not saved")
+}
@@ -0,0 +1,211 @@
+package pdf
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"student-report-service/internal/chart"
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// dashboardListLimit is the number of notices and absences listed on the page
+const dashboardListLimit = 5
+
+// GenerateDashboardReport generates a one-page executive summary of the school dashboard
+func (g *Generator) GenerateDashboardReport(report *models.DashboardReport, metadata *models.ReportMetadata) (string, error) {
+	if report == nil || report.Dashboard == nil {
+		return "", fmt.Errorf("dashboard cannot be nil")
+	}
+
+	if metadata == nil {
+		metadata = &models.ReportMetadata{
+			GeneratedAt: time.Now(),
+			GeneratedBy: "System",
+			ReportID:    fmt.Sprintf("DSH-%d", time.Now().Unix()),
+		}
+	}
+
+	pdf := g.newDocument(metadata)
+
+	g.addHeader(pdf, "School Dashboard Summary", metadata)
+	g.addDashboardKPIs(pdf, report)
+	g.addDashboardCharts(pdf, report.Dashboard)
+	g.addDashboardLists(pdf, report.Dashboard)
+	g.addFooter(pdf, metadata)
+
+	filename := fmt.Sprintf("dashboard_report_%s.pdf", content.FileStamp())
+
+	return g.saveReport(pdf, filename, documentInfo{
+		Title:    "School Dashboard Summary",
+		Subject:  "Headcounts, leave and notices across the school",
+		Keywords: []string{"dashboard", "summary"},
+		Metadata: metadata,
+	})
+}
+
+// addDashboardKPIs adds a row of KPI tiles with their trends
+func (g *Generator) addDashboardKPIs(pdf *gofpdf.Fpdf, report *models.DashboardReport) {
+	pdf.SetFont("Arial", "I", 9)
+	pdf.SetTextColor(100, 100, 100)
+	baseline := "No earlier snapshot to compare with."
+	if !report.BaselineAt.IsZero() {
+		baseline = "Changes are compared with the snapshot of " + report.BaselineAt.Format("January 2, 2006") + "."
+	}
+	pdf.CellFormat(0, 5, baseline, "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	if len(report.KPIs) == 0 {
+		return
+	}
+
+	const gap, height = 4.0, 30.0
+	left, _, _, _ := pdf.GetMargins()
+	width := (printableWidth(pdf) - gap*float64(len(report.KPIs)-1)) / float64(len(report.KPIs))
+	y := pdf.GetY()
+
+	for i, kpi := range report.KPIs {
+		x := left + float64(i)*(width+gap)
+		pdf.SetFillColor(g.theme.Panel.R, g.theme.Panel.G, g.theme.Panel.B)
+		pdf.Rect(x, y, width, height, "F")
+
+		pdf.SetXY(x, y+2)
+		pdf.SetFont("Arial", "", 9)
+		pdf.SetTextColor(100, 100, 100)
+		pdf.CellFormat(width, 5, kpi.Label, "", 2, "C", false, 0, "")
+
+		pdf.SetFont("Arial", "B", 18)
+		pdf.SetTextColor(0, 51, 102)
+		pdf.CellFormat(width, 10, formatDays(kpi.Value), "", 2, "C", false, 0, "")
+
+		pdf.SetFont("Arial", "", 7.5)
+		g.addTrend(pdf, width, "vs last year: ", kpi.YearChange, "%")
+		g.addTrend(pdf, width, "vs snapshot: ", kpi.SnapshotChange, "")
+	}
+
+	pdf.SetXY(left, y+height+8)
+}
+
+// addTrend prints a signed change coloured by direction, or n/a when unknown
+func (g *Generator) addTrend(pdf *gofpdf.Fpdf, width float64, label string, change *float64, unit string) {
+	text := label + "n/a"
+	pdf.SetTextColor(120, 120, 120)
+	if change != nil {
+		text = label + formatChange(*change) + unit
+		switch {
+		case *change > 0:
+			pdf.SetTextColor(39, 130, 76)
+		case *change < 0:
+			pdf.SetTextColor(192, 57, 43)
+		}
+	}
+	pdf.CellFormat(width, 4, text, "", 2, "C", false, 0, "")
+}
+
+// addDashboardCharts adds the headcount pie chart and the leave usage bar chart side by side
+func (g *Generator) addDashboardCharts(pdf *gofpdf.Fpdf, dashboard *models.Dashboard) {
+	const gap, height = 6.0, 60.0
+	left, _, _, _ := pdf.GetMargins()
+	width := (printableWidth(pdf) - gap) / 2
+	y := pdf.GetY()
+
+	pdf.SetXY(left, y)
+	g.addSubsectionHeader(pdf, "New This Year")
+	pdf.SetXY(left+width+gap, y)
+	g.addSubsectionHeader(pdf, "Approved Leave Days by Policy")
+	y += 8
+
+	pie := chart.NewPieChart(
+		[]string{"Students", "Teachers", "Parents"},
+		[]float64{
+			float64(dashboard.Students.CurrentYear),
+			float64(dashboard.Teachers.CurrentYear),
+			float64(dashboard.Parents.CurrentYear),
+		})
+	pie.Style = g.theme.ChartStyle()
+	pie.Draw(pdf, left, y, width, height)
+
+	labels := make([]string, 0, len(dashboard.LeavePolicies))
+	values := make([]float64, 0, len(dashboard.LeavePolicies))
+	for _, policy := range dashboard.LeavePolicies {
+		labels = append(labels, policy.Name)
+		values = append(values, float64(policy.TotalDaysUsed))
+	}
+	bars := chart.NewBarChart(labels, values)
+	bars.ShowValues = true
+	bars.Style = g.theme.ChartStyle()
+	bars.Draw(pdf, left+width+gap, y, width, height)
+
+	pdf.SetXY(left, y+height+8)
+}
+
+// addDashboardLists adds recent notices and upcoming absences side by side
+func (g *Generator) addDashboardLists(pdf *gofpdf.Fpdf, dashboard *models.Dashboard) {
+	const gap = 6.0
+	left, _, _, _ := pdf.GetMargins()
+	width := (printableWidth(pdf) - gap) / 2
+	y := pdf.GetY()
+
+	notices := make([]string, 0, dashboardListLimit)
+	for _, notice := range dashboard.Notices {
+		published := notice.PublishedAt()
+		notices = append(notices, formatDate(published)+"  "+notice.Title)
+	}
+	absences := make([]string, 0, dashboardListLimit)
+	for _, leave := range dashboard.OneMonthLeave {
+		absences = append(absences, fmt.Sprintf("%s  %s (%s)",
+			formatPeriod(parseOptionalDate(leave.FromDate), parseOptionalDate(leave.ToDate)), leave.User, leave.LeaveType))
+	}
+
+	bottom := y
+	for i, list := range []struct {
+		title, empty string
+		items        []string
+	}{
+		{"Recent Notices", "No notices.", notices},
+		{"On Leave in the Next 30 Days", "Nobody is on leave.", absences},
+	} {
+		x := left + float64(i)*(width+gap)
+		pdf.SetXY(x, y)
+		g.addSubsectionHeader(pdf, list.title)
+
+		pdf.SetFont("Arial", "", 9)
+		pdf.SetTextColor(51, 51, 51)
+		if len(list.items) == 0 {
+			pdf.SetFont("Arial", "I", 9)
+			pdf.SetTextColor(100, 100, 100)
+			list.items = []string{list.empty}
+		}
+		for j, item := range list.items {
+			if j == dashboardListLimit {
+				pdf.SetX(x)
+				pdf.CellFormat(width, 5, fmt.Sprintf("and %d more", len(list.items)-j), "", 1, "L", false, 0, "")
+				break
+			}
+			pdf.SetX(x)
+			pdf.CellFormat(width, 5, truncateText(pdf, item, width), "", 1, "L", false, 0, "")
+		}
+		bottom = max(bottom, pdf.GetY())
+	}
+
+	pdf.SetXY(left, bottom+5)
+}
+
+func formatChange(change float64) string {
+	text := formatDays(math.Round(change*10) / 10)
+	if change > 0 {
+		return "+" + text
+	}
+	return text
+}
+
+func parseOptionalDate(value string) time.Time {
+	t, err := models.ParseDate(value)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
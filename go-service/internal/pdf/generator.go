@@ -1,22 +1,36 @@
 package pdf
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"sync/atomic"
 	"time"
 
 	"student-report-service/internal/config"
+	"student-report-service/internal/content"
+	"student-report-service/internal/imaging"
 	"student-report-service/internal/models"
+	"student-report-service/internal/retention"
 
 	"github.com/jung-kurt/gofpdf"
 )
 
 // Generator handles PDF report generation
 type Generator struct {
-	config    *config.ReportConfig
+	// config is shared with the copies made for nested packet reports, so
+	// UpdateConfig reaches them too
+	config    *atomic.Pointer[config.ReportConfig]
 	outputDir string
+	theme     Theme
+
+	// outlineLevel is the bookmark level of section headers. Reports in a
+	// packet nest their sections under the report's own bookmark
+	outlineLevel int
+
+	// logo is drawn in report headers when configured
+	logo *imaging.Image
 }
 
 // NewGenerator creates a new PDF generator
@@ -30,10 +44,22 @@ func NewGenerator(cfg *config.ReportConfig) (*Generator, error) {
 		return nil, fmt.Errorf("failed to create output directory: %w", err)
 	}
 
-	return &Generator{
-		config:    cfg,
+	generator := &Generator{
+		config:    &atomic.Pointer[config.ReportConfig]{},
 		outputDir: cfg.OutputDir,
-	}, nil
+		theme:     DefaultTheme,
+	}
+	generator.config.Store(cfg)
+
+	if cfg.LogoPath != "" {
+		logo, err := loadLogo(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load report logo: %w", err)
+		}
+		generator.logo = logo
+	}
+
+	return generator, nil
 }
 
 // GenerateStudentReport generates a comprehensive PDF report for a student
@@ -50,40 +76,55 @@ func (g *Generator) GenerateStudentReport(student *models.Student, metadata *mod
 		}
 	}
 
-	// Create PDF instance
-	pdf := gofpdf.New("P", "mm", "A4", "")
-	pdf.SetMargins(20, 20, 20)
-	pdf.SetAutoPageBreak(true, 20)
-
-	// Add page
-	pdf.AddPage()
-
-	// Generate the report content
-	g.addHeader(pdf, metadata)
-	g.addStudentBasicInfo(pdf, student)
-	g.addContactDetails(pdf, student)
-	g.addFamilyInformation(pdf, student)
-	g.addAddressInformation(pdf, student)
-	g.addAcademicInformation(pdf, student)
-	g.addFooter(pdf, metadata)
+	pdf := g.newDocument(metadata)
+	g.renderDocument(pdf, content.NewStudentDocument(student, metadata))
 
 	// Generate filename
-	sanitizedName := g.sanitizeFilename(student.FormatName())
 	filename := fmt.Sprintf("student_report_%d_%s_%s.pdf",
 		student.ID,
-		sanitizedName,
-		time.Now().Format("20060102_150405"))
+		g.sanitizeFilename(student.FormatName()),
+		content.FileStamp())
+
+	return g.saveReport(pdf, filename, documentInfo{
+		Title:    fmt.Sprintf("%s: %s", content.StudentTitle, student.FormatName()),
+		Subject:  fmt.Sprintf("Student record of %s (ID %d)", student.FormatName(), student.ID),
+		Keywords: []string{"student report", models.SafeString(student.Class, ""), models.SafeString(student.Section, "")},
+		Metadata: metadata,
+	})
+}
+
+// newDocument creates an A4 document with the standard margins and a first page.
+// Archival documents embed their fonts
+func (g *Generator) newDocument(metadata *models.ReportMetadata) *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	if g.archival(metadata) {
+		if err := embedArchivalFonts(pdf); err != nil {
+			pdf.SetError(err)
+		}
+	}
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AddPage()
+	return pdf
+}
 
+// saveReport writes the document to the output directory and enforces the size limit
+func (g *Generator) saveReport(pdf *gofpdf.Fpdf, filename string, info documentInfo) (string, error) {
 	filepath := filepath.Join(g.outputDir, filename)
 
+	data, err := g.renderReport(pdf, info)
+	if err != nil {
+		return "", err
+	}
+
 	// Save the PDF
-	if err := pdf.OutputFileAndClose(filepath); err != nil {
+	if err := os.WriteFile(filepath, data, 0644); err != nil {
 		return "", fmt.Errorf("failed to save PDF: %w", err)
 	}
 
 	// Check file size
 	if fileInfo, err := os.Stat(filepath); err == nil {
-		if fileInfo.Size() > g.config.MaxFileSize {
+		if fileInfo.Size() > g.cfg().MaxFileSize {
 			os.Remove(filepath) // Clean up oversized file
 			return "", fmt.Errorf("generated PDF exceeds maximum file size limit")
 		}
@@ -92,12 +133,39 @@ func (g *Generator) GenerateStudentReport(student *models.Student, metadata *mod
 	return filepath, nil
 }
 
-// addHeader adds the report header with title and metadata
-func (g *Generator) addHeader(pdf *gofpdf.Fpdf, metadata *models.ReportMetadata) {
+// renderReport renders the document with its document information and
+// language, converting it to PDF/A in archival mode
+func (g *Generator) renderReport(pdf *gofpdf.Fpdf, info documentInfo) ([]byte, error) {
+	setDocumentInfo(pdf, info)
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, fmt.Errorf("failed to render PDF: %w", err)
+	}
+
+	data, err := setLanguage(buffer.Bytes(), documentLanguage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set PDF language: %w", err)
+	}
+
+	if g.archival(info.Metadata) {
+		if data, err = convertToPDFA(data, info); err != nil {
+			return nil, fmt.Errorf("failed to convert PDF to PDF/A: %w", err)
+		}
+	}
+	return data, nil
+}
+
+// addHeader adds the report header with the logo, title and metadata
+func (g *Generator) addHeader(pdf *gofpdf.Fpdf, title string, metadata *models.ReportMetadata) {
+	if g.logo != nil {
+		g.addLogo(pdf, pdf.GetY())
+	}
+
 	// Title
 	pdf.SetFont("Arial", "B", 20)
 	pdf.SetTextColor(0, 51, 102) // Dark blue
-	pdf.CellFormat(0, 15, "Student Information Report", "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 15, title, "", 1, "C", false, 0, "")
 	pdf.Ln(5)
 
 	// Metadata section
@@ -105,126 +173,102 @@ func (g *Generator) addHeader(pdf *gofpdf.Fpdf, metadata *models.ReportMetadata)
 	pdf.SetTextColor(100, 100, 100) // Gray
 
 	// Report details
-	pdf.CellFormat(0, 5, fmt.Sprintf("Report ID: %s", metadata.ReportID), "", 1, "R", false, 0, "")
-	pdf.CellFormat(0, 5, fmt.Sprintf("Generated: %s", metadata.GeneratedAt.Format("January 2, 2006 at 15:04 MST")), "", 1, "R", false, 0, "")
-	pdf.CellFormat(0, 5, fmt.Sprintf("Generated by: %s", metadata.GeneratedBy), "", 1, "R", false, 0, "")
+	for _, line := range content.MetadataLines(metadata) {
+		pdf.CellFormat(0, 5, line, "", 1, "R", false, 0, "")
+	}
 
 	pdf.Ln(10)
 
 	// Add watermark
-	if g.config.WatermarkText != "" {
-		g.addWatermark(pdf, g.config.WatermarkText)
+	if watermark := g.cfg().WatermarkText; watermark != "" {
+		g.addWatermark(pdf, watermark)
 	}
 }
 
-// addStudentBasicInfo adds basic student information section
-func (g *Generator) addStudentBasicInfo(pdf *gofpdf.Fpdf, student *models.Student) {
-	g.addSectionHeader(pdf, "Basic Information")
+// renderDocument draws a report's header, sections and footer
+func (g *Generator) renderDocument(pdf *gofpdf.Fpdf, doc *content.Document) {
+	g.addHeader(pdf, doc.Title, doc.Metadata)
 
-	// Basic info table
-	g.addInfoRow(pdf, "Student ID:", fmt.Sprintf("%d", student.ID))
-	g.addInfoRow(pdf, "Full Name:", student.FormatName())
-	g.addInfoRow(pdf, "Email Address:", student.FormatEmail())
-	g.addInfoRow(pdf, "System Access:", g.formatBool(student.SystemAccess))
+	// The photo sits beside the first section, whose rows are narrowed to
+	// leave room for it
+	portrait := doc.Portrait && (len(doc.Photo) > 0 || g.cfg().PhotosEnabled())
 
-	if student.Gender != nil {
-		g.addInfoRow(pdf, "Gender:", models.SafeString(student.Gender, "Not specified"))
-	}
-
-	if student.DOB != nil {
-		g.addInfoRow(pdf, "Date of Birth:", models.SafeString(student.DOB, "Not specified"))
-	}
-
-	if student.Phone != nil {
-		g.addInfoRow(pdf, "Phone Number:", models.SafeString(student.Phone, "Not provided"))
-	}
-
-	pdf.Ln(5)
-}
-
-// addContactDetails adds contact information section
-func (g *Generator) addContactDetails(pdf *gofpdf.Fpdf, student *models.Student) {
-	g.addSectionHeader(pdf, "Contact Information")
+	for s, section := range doc.Sections {
+		g.addSectionHeader(pdf, section.Title)
 
-	g.addInfoRow(pdf, "Primary Email:", student.FormatEmail())
-	g.addInfoRow(pdf, "Phone Number:", models.SafeString(student.Phone, "Not provided"))
-
-	pdf.Ln(5)
-}
-
-// addFamilyInformation adds family and guardian information
-func (g *Generator) addFamilyInformation(pdf *gofpdf.Fpdf, student *models.Student) {
-	g.addSectionHeader(pdf, "Family & Guardian Information")
-
-	// Father's information
-	g.addSubsectionHeader(pdf, "Father's Information")
-	g.addInfoRow(pdf, "Father's Name:", models.SafeString(student.FatherName, "Not provided"))
-	g.addInfoRow(pdf, "Father's Phone:", models.SafeString(student.FatherPhone, "Not provided"))
-
-	pdf.Ln(3)
-
-	// Mother's information
-	g.addSubsectionHeader(pdf, "Mother's Information")
-	g.addInfoRow(pdf, "Mother's Name:", models.SafeString(student.MotherName, "Not provided"))
-	g.addInfoRow(pdf, "Mother's Phone:", models.SafeString(student.MotherPhone, "Not provided"))
-
-	pdf.Ln(3)
-
-	// Guardian information
-	g.addSubsectionHeader(pdf, "Guardian Information")
-	g.addInfoRow(pdf, "Guardian's Name:", models.SafeString(student.GuardianName, "Not provided"))
-	g.addInfoRow(pdf, "Guardian's Phone:", models.SafeString(student.GuardianPhone, "Not provided"))
-	g.addInfoRow(pdf, "Relation to Student:", models.SafeString(student.RelationOfGuardian, "Not specified"))
-
-	pdf.Ln(5)
-}
-
-// addAddressInformation adds address information
-func (g *Generator) addAddressInformation(pdf *gofpdf.Fpdf, student *models.Student) {
-	g.addSectionHeader(pdf, "Address Information")
-
-	g.addInfoRow(pdf, "Current Address:", models.SafeString(student.CurrentAddress, "Not provided"))
-	g.addInfoRow(pdf, "Permanent Address:", models.SafeString(student.PermanentAddress, "Not provided"))
-
-	pdf.Ln(5)
-}
-
-// addAcademicInformation adds academic information
-func (g *Generator) addAcademicInformation(pdf *gofpdf.Fpdf, student *models.Student) {
-	g.addSectionHeader(pdf, "Academic Information")
-
-	g.addInfoRow(pdf, "Class:", models.SafeString(student.Class, "Not assigned"))
-	g.addInfoRow(pdf, "Section:", models.SafeString(student.Section, "Not assigned"))
-
-	if student.Roll != nil {
-		g.addInfoRow(pdf, "Roll Number:", fmt.Sprintf("%d", models.SafeInt(student.Roll, 0)))
-	} else {
-		g.addInfoRow(pdf, "Roll Number:", "Not assigned")
-	}
+		var portraitBottom, rightMargin float64
+		if s == 0 && portrait {
+			portraitBottom = g.addPortrait(pdf, doc.Photo)
+			_, _, rightMargin, _ = pdf.GetMargins()
+			pdf.SetRightMargin(rightMargin + portraitWidth + portraitGap)
+		}
 
-	g.addInfoRow(pdf, "Admission Date:", models.SafeString(student.AdmissionDate, "Not recorded"))
+		for i, group := range section.Groups {
+			if i > 0 {
+				pdf.Ln(3)
+			}
+			if group.Title != "" {
+				g.addSubsectionHeader(pdf, group.Title)
+			}
+			for _, row := range group.Rows {
+				g.addInfoRow(pdf, row.Label, row.Value)
+			}
+		}
 
-	if student.ReporterName != nil {
-		g.addInfoRow(pdf, "Reporter:", models.SafeString(student.ReporterName, "System"))
+		if portraitBottom > 0 {
+			pdf.SetRightMargin(rightMargin)
+			if pdf.GetY() < portraitBottom {
+				pdf.SetY(portraitBottom)
+			}
+		}
+		pdf.Ln(5)
 	}
 
-	pdf.Ln(5)
+	g.addFooter(pdf, doc.Metadata)
 }
 
 // addFooter adds the report footer
 func (g *Generator) addFooter(pdf *gofpdf.Fpdf, metadata *models.ReportMetadata) {
+	// Start a new page rather than overlap content near the bottom
+	if pdf.GetY() > pageBreakY(pdf)-10 {
+		pdf.AddPage()
+	}
+
+	// The footer sits in the bottom margin, so suspend automatic page breaks
+	_, bottomMargin := pdf.GetAutoPageBreak()
+	pdf.SetAutoPageBreak(false, bottomMargin)
+	defer pdf.SetAutoPageBreak(true, bottomMargin)
+
 	pdf.SetY(-30)
 	pdf.SetFont("Arial", "I", 8)
 	pdf.SetTextColor(150, 150, 150)
 
-	pdf.CellFormat(0, 5, "This report is confidential and intended for authorized personnel only.", "", 1, "C", false, 0, "")
-	pdf.CellFormat(0, 5, fmt.Sprintf("Generated on %s", metadata.GeneratedAt.Format("January 2, 2006")), "", 1, "C", false, 0, "")
-	pdf.CellFormat(0, 5, "Student Management System", "", 1, "C", false, 0, "")
+	for _, line := range content.FooterLines(metadata) {
+		pdf.CellFormat(0, 5, line, "", 1, "C", false, 0, "")
+	}
+}
+
+// addPageNumbers prints "Page X of Y" at the bottom of every page
+func (g *Generator) addPageNumbers(pdf *gofpdf.Fpdf) {
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Arial", "I", 8)
+		pdf.SetTextColor(150, 150, 150)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
 }
 
 // Helper methods for consistent formatting
 
+// addSectionHeader starts a section and adds it to the document outline
 func (g *Generator) addSectionHeader(pdf *gofpdf.Fpdf, title string) {
+	// Break first so the bookmark points at the page the heading lands on
+	if pdf.GetY()+8 > pageBreakY(pdf) {
+		pdf.AddPage()
+	}
+	pdf.Bookmark(title, g.outlineLevel, -1)
+
 	pdf.SetFont("Arial", "B", 14)
 	pdf.SetTextColor(0, 51, 102)
 	pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
@@ -247,6 +291,8 @@ func (g *Generator) addInfoRow(pdf *gofpdf.Fpdf, label, value string) {
 	pdf.CellFormat(0, 6, value, "", 1, "L", false, 0, "")
 }
 
+// addWatermark draws the watermark in an opaque light grey rather than with
+// alpha blending, which keeps archival documents free of transparency
 func (g *Generator) addWatermark(pdf *gofpdf.Fpdf, text string) {
 	pdf.SetFont("Arial", "", 50)
 	pdf.SetTextColor(240, 240, 240) // Very light gray
@@ -259,44 +305,46 @@ func (g *Generator) addWatermark(pdf *gofpdf.Fpdf, text string) {
 }
 
 func (g *Generator) formatBool(value bool) string {
-	if value {
-		return "Enabled"
-	}
-	return "Disabled"
+	return content.FormatBool(value)
 }
 
 func (g *Generator) sanitizeFilename(name string) string {
-	// Replace invalid characters with underscores
-	invalidChars := []string{"/", "\\", ":", "*", "?", "\"", "<", ">", "|", " "}
-	result := name
-	for _, char := range invalidChars {
-		result = strings.ReplaceAll(result, char, "_")
-	}
+	return content.SanitizeFilename(name)
+}
 
-	// Limit length
-	if len(result) > 30 {
-		result = result[:30]
+// CleanupOldReports applies the retention policy to the output directory.
+// In dry-run mode the summary lists what would be deleted without touching files.
+func (g *Generator) CleanupOldReports(dryRun bool) (*retention.Summary, error) {
+	cfg := g.cfg()
+	if !cfg.Cleanup {
+		return &retention.Summary{DryRun: dryRun, Deleted: []retention.Entry{}, Failed: []retention.Entry{}}, nil
 	}
 
-	return result
+	cleaner := retention.NewCleaner(g.outputDir, retention.Policy{
+		MaxAge:   cfg.CleanupAfter,
+		Rules:    cfg.Retention,
+		TagRules: cfg.TagRetention,
+		Quota:    cfg.DiskQuota,
+	})
+
+	return cleaner.Run(dryRun)
 }
 
-// CleanupOldReports removes old report files based on configuration
-func (g *Generator) CleanupOldReports() error {
-	if !g.config.Cleanup {
-		return nil
-	}
+// cfg returns the configuration in effect
+func (g *Generator) cfg() *config.ReportConfig {
+	return g.config.Load()
+}
 
-	return filepath.Walk(g.outputDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+// UpdateConfig switches to a reloaded configuration. Reports already being
+// generated may finish with either configuration. The output directory and
+// logo are fixed when the generator is created
+func (g *Generator) UpdateConfig(cfg *config.ReportConfig) {
+	if cfg != nil {
+		g.config.Store(cfg)
+	}
+}
 
-		if !info.IsDir() && strings.HasSuffix(info.Name(), ".pdf") {
-			if time.Since(info.ModTime()) > g.config.CleanupAfter {
-				return os.Remove(path)
-			}
-		}
-		return nil
-	})
+// OutputDir returns the directory generated reports are written to
+func (g *Generator) OutputDir() string {
+	return g.outputDir
 }
@@ -0,0 +1,117 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+)
+
+// srgbProfile is the ICC profile embedded as the PDF/A output intent
+var srgbProfile = buildSRGBProfile()
+
+// srgbProfileName identifies the output condition described by srgbProfile
+const srgbProfileName = "sRGB IEC61966-2.1"
+
+// buildSRGBProfile builds an ICC v2 RGB display profile for sRGB: D50 adapted
+// primaries and the sRGB transfer curve sampled as a lookup table
+func buildSRGBProfile() []byte {
+	curve := iccCurve(1024)
+	tags := []struct {
+		signature string
+		data      []byte
+	}{
+		{"desc", iccDescription(srgbProfileName)},
+		{"cprt", iccText("No copyright, use freely")},
+		{"wtpt", iccXYZ(0.9642, 1.0, 0.8249)},
+		{"rXYZ", iccXYZ(0.4361, 0.2225, 0.0139)},
+		{"gXYZ", iccXYZ(0.3851, 0.7169, 0.0971)},
+		{"bXYZ", iccXYZ(0.1431, 0.0606, 0.7141)},
+		{"rTRC", curve},
+		{"gTRC", curve},
+		{"bTRC", curve},
+	}
+
+	// Tag data starts after the 128 byte header and the tag table
+	offset := 128 + 4 + 12*len(tags)
+	var table, data bytes.Buffer
+	binary.Write(&table, binary.BigEndian, uint32(len(tags)))
+	for _, tag := range tags {
+		table.WriteString(tag.signature)
+		binary.Write(&table, binary.BigEndian, uint32(offset+data.Len()))
+		binary.Write(&table, binary.BigEndian, uint32(len(tag.data)))
+		data.Write(tag.data)
+		for data.Len()%4 != 0 {
+			data.WriteByte(0)
+		}
+	}
+
+	size := offset + data.Len()
+	header := make([]byte, 128)
+	binary.BigEndian.PutUint32(header[0:], uint32(size))
+	binary.BigEndian.PutUint32(header[8:], 0x02100000) // version 2.1
+	copy(header[12:], "mntr")
+	copy(header[16:], "RGB ")
+	copy(header[20:], "XYZ ")
+	for i, v := range []uint16{2024, 1, 1, 0, 0, 0} {
+		binary.BigEndian.PutUint16(header[24+2*i:], v)
+	}
+	copy(header[36:], "acsp")
+	copy(header[68:], iccXYZ(0.9642, 1.0, 0.8249)[8:]) // D50 illuminant
+
+	profile := make([]byte, 0, size)
+	profile = append(profile, header...)
+	profile = append(profile, table.Bytes()...)
+	return append(profile, data.Bytes()...)
+}
+
+// iccDescription encodes a textDescriptionType with an ASCII description only
+func iccDescription(text string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("desc")
+	buf.Write(make([]byte, 4))
+	binary.Write(&buf, binary.BigEndian, uint32(len(text)+1))
+	buf.WriteString(text)
+	buf.WriteByte(0)
+	buf.Write(make([]byte, 4+4+2+1+67)) // empty Unicode and ScriptCode descriptions
+	return buf.Bytes()
+}
+
+// iccText encodes a textType
+func iccText(text string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("text")
+	buf.Write(make([]byte, 4))
+	buf.WriteString(text)
+	buf.WriteByte(0)
+	return buf.Bytes()
+}
+
+// iccXYZ encodes an XYZType holding a single colour
+func iccXYZ(x, y, z float64) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("XYZ ")
+	buf.Write(make([]byte, 4))
+	for _, v := range []float64{x, y, z} {
+		binary.Write(&buf, binary.BigEndian, int32(math.Round(v*65536)))
+	}
+	return buf.Bytes()
+}
+
+// iccCurve encodes the sRGB transfer function as a curveType with the given
+// number of samples
+func iccCurve(samples int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("curv")
+	buf.Write(make([]byte, 4))
+	binary.Write(&buf, binary.BigEndian, uint32(samples))
+	for i := 0; i < samples; i++ {
+		v := float64(i) / float64(samples-1)
+		if v <= 0.04045 {
+			v /= 12.92
+		} else {
+			v = math.Pow((v+0.055)/1.055, 2.4)
+		}
+		binary.Write(&buf, binary.BigEndian, uint16(math.Round(v*65535)))
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,120 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register the decoder used to check photos
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/imaging"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	// logoMaxDimension keeps the logo sharp at the printed size
+	logoMaxDimension = 600
+	// logoMaxBytes bounds the prepared logo, which every report embeds
+	logoMaxBytes = 512 << 10
+	// logoDownloadTimeout bounds fetching a logo URL at startup
+	logoDownloadTimeout = 10 * time.Second
+
+	// Printed sizes in mm
+	logoMaxWidth   = 40.0
+	logoMaxHeight  = 15.0
+	portraitWidth  = 30.0
+	portraitHeight = 36.0
+	portraitGap    = 5.0
+)
+
+// loadLogo reads and prepares the configured logo. The logo is kept lossless
+// and limited to an eighth of the maximum report size
+func loadLogo(cfg *config.ReportConfig) (*imaging.Image, error) {
+	data, err := imaging.Read(cfg.LogoPath, logoDownloadTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	maxBytes := int64(logoMaxBytes)
+	if cfg.MaxFileSize > 0 && cfg.MaxFileSize/8 < maxBytes {
+		maxBytes = cfg.MaxFileSize / 8
+	}
+	return imaging.Prepare(data, imaging.Options{
+		MaxDimension: logoMaxDimension,
+		MaxBytes:     maxBytes,
+		Lossless:     true,
+	})
+}
+
+// addLogo draws the logo at the top left of the header, scaled to fit
+func (g *Generator) addLogo(pdf *gofpdf.Fpdf, y float64) {
+	imageType := "JPG"
+	if g.logo.Format == imaging.FormatPNG {
+		imageType = "PNG"
+	}
+
+	name := registerImage(pdf, g.logo.Data, imageType)
+	width, height := fitBox(float64(g.logo.Width), float64(g.logo.Height), logoMaxWidth, logoMaxHeight)
+	left, _, _, _ := pdf.GetMargins()
+	pdf.ImageOptions(name, left, y+(logoMaxHeight-height)/2, width, height, false,
+		gofpdf.ImageOptions{ImageType: imageType}, 0, "")
+}
+
+// addPortrait draws the photo, or a placeholder avatar when there is no
+// usable photo, at the top right of the current position and returns the
+// bottom of the frame
+func (g *Generator) addPortrait(pdf *gofpdf.Fpdf, photo []byte) float64 {
+	pageWidth, _ := pdf.GetPageSize()
+	_, _, right, _ := pdf.GetMargins()
+	x := pageWidth - right - portraitWidth
+	y := pdf.GetY()
+
+	if cfg, format, err := image.DecodeConfig(bytes.NewReader(photo)); err == nil && format == imaging.FormatJPEG {
+		name := registerImage(pdf, photo, "JPG")
+		width, height := fitBox(float64(cfg.Width), float64(cfg.Height), portraitWidth, portraitHeight)
+		pdf.ImageOptions(name, x+(portraitWidth-width)/2, y+(portraitHeight-height)/2, width, height, false,
+			gofpdf.ImageOptions{ImageType: "JPG"}, 0, "")
+	} else {
+		g.addPlaceholderAvatar(pdf, x, y)
+	}
+
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.SetLineWidth(0.3)
+	pdf.Rect(x, y, portraitWidth, portraitHeight, "D")
+	return y + portraitHeight
+}
+
+// addPlaceholderAvatar draws a head and shoulders silhouette on a panel
+func (g *Generator) addPlaceholderAvatar(pdf *gofpdf.Fpdf, x, y float64) {
+	panel := g.theme.Panel
+	pdf.SetFillColor(panel.R, panel.G, panel.B)
+	pdf.Rect(x, y, portraitWidth, portraitHeight, "F")
+
+	centre := x + portraitWidth/2
+	pdf.SetFillColor(190, 198, 208)
+	pdf.ClipRect(x, y, portraitWidth, portraitHeight, false)
+	pdf.Circle(centre, y+portraitHeight*0.38, portraitWidth*0.2, "F")
+	pdf.Ellipse(centre, y+portraitHeight, portraitWidth*0.38, portraitHeight*0.3, 0, "F")
+	pdf.ClipEnd()
+}
+
+// registerImage adds image data to the document once, named by its content
+func registerImage(pdf *gofpdf.Fpdf, data []byte, imageType string) string {
+	name := fmt.Sprintf("img-%x", md5.Sum(data))
+	if pdf.GetImageInfo(name) == nil {
+		pdf.RegisterImageOptionsReader(name, gofpdf.ImageOptions{ImageType: imageType}, bytes.NewReader(data))
+	}
+	return name
+}
+
+// fitBox scales width and height to fit within the box, keeping the aspect ratio
+func fitBox(width, height, maxWidth, maxHeight float64) (float64, float64) {
+	scale := maxWidth / width
+	if maxHeight/height < scale {
+		scale = maxHeight / height
+	}
+	return width * scale, height * scale
+}
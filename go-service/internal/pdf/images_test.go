@@ -0,0 +1,109 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var imagePattern = regexp.MustCompile(`/Subtype /Image\n/Width (\d+)\n/Height (\d+)`)
+
+// writeTestLogo writes a 300x100 PNG with a transparent background
+func writeTestLogo(t *testing.T) string {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, 300, 100))
+	for x := 100; x < 200; x++ {
+		for y := 20; y < 80; y++ {
+			img.Set(x, y, color.NRGBA{R: 0, G: 51, B: 102, A: 255})
+		}
+	}
+	var buffer bytes.Buffer
+	require.NoError(t, png.Encode(&buffer, img))
+
+	path := filepath.Join(t.TempDir(), "logo.png")
+	require.NoError(t, os.WriteFile(path, buffer.Bytes(), 0644))
+	return path
+}
+
+func testPhoto(t *testing.T) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buffer, image.NewRGBA(image.Rect(0, 0, 120, 150)), nil))
+	return buffer.Bytes()
+}
+
+func TestGenerator_LogoAndPhoto(t *testing.T) {
+	logo := writeTestLogo(t)
+	photo := testPhoto(t)
+
+	tests := []struct {
+		name     string
+		logo     string
+		photoDir string
+		photo    []byte
+		archival bool
+		images   [][2]string
+	}{
+		{name: "No logo or photos configured", images: nil},
+		{name: "Logo and photo", logo: logo, photo: photo, images: [][2]string{{"300", "100"}, {"120", "150"}}},
+		{name: "Placeholder when photos are enabled but missing", logo: logo, photoDir: t.TempDir(), images: [][2]string{{"300", "100"}}},
+		{name: "Invalid photo falls back to the placeholder", photo: []byte("not a photo"), images: nil},
+		{name: "Archival report with logo and photo", logo: logo, photo: photo, archival: true, images: [][2]string{{"300", "100"}, {"120", "150"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			generator, err := NewGenerator(&config.ReportConfig{
+				OutputDir:   t.TempDir(),
+				MaxFileSize: 10 * 1024 * 1024,
+				LogoPath:    tt.logo,
+				PhotoDir:    tt.photoDir,
+			})
+			require.NoError(t, err)
+
+			student := &models.Student{ID: 7, Name: "Ana", Photo: tt.photo}
+			path, err := generator.GenerateStudentReport(student, archivalMetadata(tt.archival))
+			require.NoError(t, err)
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			var images [][2]string
+			for _, match := range imagePattern.FindAllStringSubmatch(string(data), -1) {
+				images = append(images, [2]string{match[1], match[2]})
+			}
+			assert.ElementsMatch(t, tt.images, images)
+			assert.NotContains(t, string(data), "/SMask", "logo transparency is flattened")
+
+			if tt.archival {
+				validatePDFA(t, data, documentInfo{
+					Title:    "Student Information Report: Ana",
+					Subject:  "Student record of Ana (ID 7)",
+					Keywords: []string{"student report"},
+					Metadata: archivalMetadata(true),
+				})
+			}
+		})
+	}
+}
+
+func TestNewGenerator_InvalidLogo(t *testing.T) {
+	notImage := filepath.Join(t.TempDir(), "logo.png")
+	require.NoError(t, os.WriteFile(notImage, []byte("<svg/>"), 0644))
+
+	for _, logo := range []string{notImage, notImage + ".missing"} {
+		_, err := NewGenerator(&config.ReportConfig{OutputDir: t.TempDir(), LogoPath: logo})
+		assert.ErrorContains(t, err, "failed to load report logo")
+	}
+}
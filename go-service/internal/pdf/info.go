@@ -0,0 +1,89 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+const (
+	// documentLanguage is the language of the report templates
+	documentLanguage = "en"
+
+	documentCreator  = "Student Management System"
+	documentProducer = "Student Report Service"
+)
+
+// documentInfo describes a report to PDF readers and document management
+// systems. It fills the document information dictionary and, for archival
+// output, the XMP metadata
+type documentInfo struct {
+	Title    string
+	Subject  string
+	Keywords []string
+	Metadata *models.ReportMetadata
+}
+
+// keywords returns the report's keywords followed by its report ID
+func (d documentInfo) keywords() string {
+	keywords := make([]string, 0, len(d.Keywords)+1)
+	for _, keyword := range d.Keywords {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	if d.Metadata.ReportID != "" {
+		keywords = append(keywords, d.Metadata.ReportID)
+	}
+	return strings.Join(keywords, ", ")
+}
+
+// created returns the generation time as recorded in the document, in UTC and
+// to the second
+func (d documentInfo) created() time.Time {
+	return d.Metadata.GeneratedAt.UTC().Truncate(time.Second)
+}
+
+// setDocumentInfo writes the document information dictionary entries
+func setDocumentInfo(pdf *gofpdf.Fpdf, info documentInfo) {
+	pdf.SetTitle(info.Title, true)
+	pdf.SetSubject(info.Subject, true)
+	pdf.SetAuthor(info.Metadata.GeneratedBy, true)
+	pdf.SetKeywords(info.keywords(), true)
+	pdf.SetCreator(documentCreator, true)
+	pdf.SetProducer(documentProducer, true)
+	pdf.SetCreationDate(info.created())
+	pdf.SetModificationDate(info.created())
+}
+
+// setLanguage adds the document language to the catalog, which gofpdf has no
+// setting for. The catalog is the last object before the cross-reference
+// table, so only the startxref offset moves
+func setLanguage(data []byte, lang string) ([]byte, error) {
+	offsets, xrefOffset, err := parseXref(data)
+	if err != nil {
+		return nil, err
+	}
+
+	catalogObj := len(offsets) - 1
+	catalogPrefix := fmt.Sprintf("%d 0 obj\n<<\n", catalogObj)
+	if !bytes.HasPrefix(data[offsets[catalogObj]:xrefOffset], []byte(catalogPrefix)) {
+		return nil, fmt.Errorf("unexpected catalog object")
+	}
+
+	entry := fmt.Sprintf("/Lang %s\n", pdfTextString(lang))
+	insertAt := offsets[catalogObj] + len(catalogPrefix)
+	startxref := bytes.LastIndex(data, []byte("startxref\n"))
+
+	var out bytes.Buffer
+	out.Write(data[:insertAt])
+	out.WriteString(entry)
+	out.Write(data[insertAt:startxref])
+	fmt.Fprintf(&out, "startxref\n%d\n%%%%EOF\n", xrefOffset+len(entry))
+	return out.Bytes(), nil
+}
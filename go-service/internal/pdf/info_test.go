@@ -0,0 +1,220 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_DocumentInfo(t *testing.T) {
+	class, section := "Grade 5", "B"
+	student := &models.Student{ID: 7, Name: "Ana Müller", Class: &class, Section: &section}
+
+	for _, archival := range []bool{false, true} {
+		t.Run(fmt.Sprintf("archival=%t", archival), func(t *testing.T) {
+			path, err := newTestGenerator(t, false).GenerateStudentReport(student, archivalMetadata(archival))
+			require.NoError(t, err)
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			doc := readPDF(t, data)
+			assert.Equal(t, "Student Information Report: Ana Müller", doc.infoString(t, "Title"))
+			assert.Equal(t, "Student record of Ana Müller (ID 7)", doc.infoString(t, "Subject"))
+			assert.Equal(t, "Zoë (registrar)", doc.infoString(t, "Author"))
+			assert.Equal(t, "student report, Grade 5, B, RPT-7-1709631015", doc.infoString(t, "Keywords"))
+			assert.Equal(t, documentCreator, doc.infoString(t, "Creator"))
+			assert.Contains(t, doc.infoString(t, "CreationDate"), "D:20240305093015")
+
+			catalog := doc.object(doc.trailer, "/Root")
+			assert.Contains(t, catalog, "/Lang (en)")
+		})
+	}
+}
+
+func TestGenerator_UniqueFilenames(t *testing.T) {
+	generator := newTestGenerator(t, false)
+	student := &models.Student{ID: 7, Name: "Ana Müller"}
+
+	// Both reports are generated within the same second
+	first, err := generator.GenerateStudentReport(student, archivalMetadata(false))
+	require.NoError(t, err)
+	second, err := generator.GenerateStudentReport(student, archivalMetadata(false))
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+	assert.Regexp(t, `^student_report_7_Ana_Müller_\d{8}_\d{6}_[0-9a-f]{6}\.pdf$`, filepath.Base(first))
+	assert.FileExists(t, first)
+	assert.FileExists(t, second)
+}
+
+func TestGenerator_Outline(t *testing.T) {
+	class := "Grade 5"
+	student := &models.Student{ID: 7, Name: "Ana", Class: &class}
+
+	var sections []string
+	for _, section := range content.NewStudentDocument(student, archivalMetadata(false)).Sections {
+		sections = append(sections, section.Title)
+	}
+
+	tests := []struct {
+		name     string
+		generate func(*Generator) (string, error)
+		expected []string
+	}{
+		{
+			name: "Student report bookmarks every section",
+			generate: func(g *Generator) (string, error) {
+				return g.GenerateStudentReport(student, archivalMetadata(false))
+			},
+			expected: sections,
+		},
+		{
+			name: "Roster bookmarks its class and student sections",
+			generate: func(g *Generator) (string, error) {
+				return g.GenerateClassRoster(&models.ClassRoster{ClassName: class, Students: []models.Student{*student}}, archivalMetadata(false))
+			},
+			expected: []string{"Class", "Students"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := tt.generate(newTestGenerator(t, false))
+			require.NoError(t, err)
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			doc := readPDF(t, data)
+			catalog := doc.object(doc.trailer, "/Root")
+			assert.Contains(t, catalog, "/PageMode /UseOutlines")
+
+			var titles []string
+			for _, item := range doc.outline(doc.object(catalog, "/Outlines")) {
+				titles = append(titles, item.title)
+			}
+			assert.Equal(t, tt.expected, titles)
+		})
+	}
+}
+
+// parsedPDF holds the objects of a generated file by object number
+type parsedPDF struct {
+	objects map[int]string
+	trailer string
+	t       *testing.T
+}
+
+// readPDF splits a PDF into its objects using the cross-reference table and
+// checks that every entry points at its object
+func readPDF(t *testing.T, data []byte) *parsedPDF {
+	t.Helper()
+	offsets, xrefOffset, err := parseXref(data)
+	require.NoError(t, err)
+
+	doc := &parsedPDF{objects: make(map[int]string, len(offsets)), trailer: string(data[xrefOffset:]), t: t}
+	for n := 1; n < len(offsets); n++ {
+		header := fmt.Sprintf("%d 0 obj\n", n)
+		require.True(t, bytes.HasPrefix(data[offsets[n]:], []byte(header)), "xref entry %d does not point at its object", n)
+		end := bytes.Index(data[offsets[n]:], []byte("endobj"))
+		require.Positive(t, end)
+		doc.objects[n] = string(data[offsets[n]+len(header) : offsets[n]+end])
+	}
+	return doc
+}
+
+// object follows the indirect reference after key in dict
+func (p *parsedPDF) object(dict, key string) string {
+	p.t.Helper()
+	match := regexp.MustCompile(key + `\s*(\d+) 0 R`).FindStringSubmatch(dict)
+	require.NotNil(p.t, match, "missing %s", key)
+	n, _ := strconv.Atoi(match[1])
+	require.Contains(p.t, p.objects, n)
+	return p.objects[n]
+}
+
+// outlineItem is a bookmark and the bookmarks nested under it
+type outlineItem struct {
+	title    string
+	children []outlineItem
+}
+
+var outlineTitlePattern = regexp.MustCompile(`/Title (\((?s:.*?)[^\\]\)|<[0-9A-Fa-f]*>)\n`)
+
+// outline reads the bookmarks below parent, checking that each one jumps to a page
+func (p *parsedPDF) outline(parent string) []outlineItem {
+	p.t.Helper()
+	var items []outlineItem
+	if !strings.Contains(parent, "/First ") {
+		return items
+	}
+	for item := p.object(parent, "/First"); ; item = p.object(item, "/Next") {
+		match := outlineTitlePattern.FindStringSubmatch(item)
+		require.NotNil(p.t, match, "bookmark without a title")
+		assert.Contains(p.t, p.object(item, `/Dest \[`), "/Type /Page")
+
+		items = append(items, outlineItem{title: decodePDFString(p.t, match[1]), children: p.outline(item)})
+		if !strings.Contains(item, "/Next ") {
+			return items
+		}
+	}
+}
+
+// infoString returns a decoded entry of the document information dictionary
+func (p *parsedPDF) infoString(t *testing.T, key string) string {
+	t.Helper()
+	info := p.object(p.trailer, "/Info")
+	match := regexp.MustCompile(`/` + key + ` (\((?s:.*?)[^\\]\)|<[0-9A-Fa-f]*>)\n`).FindStringSubmatch(info)
+	require.NotNil(t, match, "missing /%s", key)
+	return decodePDFString(t, match[1])
+}
+
+// decodePDFString decodes a literal or hex string, including UTF-16BE text
+func decodePDFString(t *testing.T, s string) string {
+	t.Helper()
+	var raw []byte
+	if s[0] == '<' {
+		for i := 1; i+2 <= len(s)-1; i += 2 {
+			b, err := strconv.ParseUint(s[i:i+2], 16, 8)
+			require.NoError(t, err)
+			raw = append(raw, byte(b))
+		}
+	} else {
+		body := s[1 : len(s)-1]
+		for i := 0; i < len(body); i++ {
+			if body[i] == '\\' && i+1 < len(body) {
+				i++
+				switch body[i] {
+				case 'n':
+					raw = append(raw, '\n')
+				case 'r':
+					raw = append(raw, '\r')
+				default:
+					raw = append(raw, body[i])
+				}
+				continue
+			}
+			raw = append(raw, body[i])
+		}
+	}
+
+	if !bytes.HasPrefix(raw, []byte{0xFE, 0xFF}) {
+		return string(raw)
+	}
+	units := make([]uint16, 0, len(raw)/2)
+	for i := 2; i+1 < len(raw); i += 2 {
+		units = append(units, uint16(raw[i])<<8|uint16(raw[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,167 @@
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+var leaveBalanceColumns = []Column{
+	{Title: "Policy"},
+	{Title: "Days Taken", Align: "R"},
+	{Title: "Allowance", Align: "R"},
+	{Title: "Remaining", Align: "R"},
+}
+
+var leaveHistoryColumns = []Column{
+	{Title: "Policy"},
+	{Title: "From", MinWidth: 20},
+	{Title: "To", MinWidth: 20},
+	{Title: "Days", Align: "R"},
+	{Title: "Status"},
+	{Title: "Approver"},
+	{Title: "Submitted", MinWidth: 20},
+}
+
+// GenerateLeaveReport generates a leave history report for a student or staff member
+func (g *Generator) GenerateLeaveReport(report *models.LeaveReport, metadata *models.ReportMetadata) (string, error) {
+	if report == nil {
+		return "", fmt.Errorf("leave report cannot be nil")
+	}
+
+	if metadata == nil {
+		metadata = &models.ReportMetadata{
+			GeneratedAt: time.Now(),
+			GeneratedBy: "System",
+			ReportID:    fmt.Sprintf("LVE-%d-%d", report.UserID, time.Now().Unix()),
+		}
+	}
+
+	pdf := g.newDocument(metadata)
+	g.addPageNumbers(pdf)
+	g.renderLeaveReport(pdf, report, metadata)
+
+	filename := fmt.Sprintf("leave_report_%d_%s_%s.pdf",
+		report.UserID,
+		g.sanitizeFilename(report.UserName),
+		content.FileStamp())
+
+	return g.saveReport(pdf, filename, documentInfo{
+		Title:    "Leave History Report: " + report.UserName,
+		Subject:  fmt.Sprintf("Leave requests and balances of %s (user %d)", report.UserName, report.UserID),
+		Keywords: []string{"leave history", "leave balance"},
+		Metadata: metadata,
+	})
+}
+
+// renderLeaveReport draws the leave history report
+func (g *Generator) renderLeaveReport(pdf *gofpdf.Fpdf, report *models.LeaveReport, metadata *models.ReportMetadata) {
+	g.addHeader(pdf, "Leave History Report", metadata)
+	g.addLeaveSummary(pdf, report)
+	g.addLeaveBalances(pdf, report)
+	g.addLeaveHistory(pdf, report)
+	g.addFooter(pdf, metadata)
+}
+
+// addLeaveSummary adds the user and period covered by the report
+func (g *Generator) addLeaveSummary(pdf *gofpdf.Fpdf, report *models.LeaveReport) {
+	g.addSectionHeader(pdf, "Summary")
+
+	g.addInfoRow(pdf, "User ID:", fmt.Sprintf("%d", report.UserID))
+	g.addInfoRow(pdf, "Name:", report.UserName)
+	g.addInfoRow(pdf, "Period:", formatPeriod(report.From, report.To))
+	g.addInfoRow(pdf, "Leave Requests:", fmt.Sprintf("%d", len(report.Requests)))
+
+	pdf.Ln(5)
+}
+
+// addLeaveBalances adds approved days taken and remaining balance per policy
+func (g *Generator) addLeaveBalances(pdf *gofpdf.Fpdf, report *models.LeaveReport) {
+	g.addSectionHeader(pdf, "Balances by Policy")
+
+	if len(report.Balances) == 0 {
+		g.addEmptyNote(pdf, "No leave policies in this period.")
+		return
+	}
+
+	table := NewTable(leaveBalanceColumns)
+	for _, balance := range report.Balances {
+		table.AddRow(
+			balance.Policy,
+			formatDays(balance.DaysTaken),
+			formatOptionalDays(balance.Allowance),
+			formatOptionalDays(balance.Remaining),
+		)
+	}
+	table.Render(pdf)
+
+	pdf.SetFont("Arial", "I", 8)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 5, "Only approved leave counts towards days taken. N/A means no allowance is configured.", "", 1, "L", false, 0, "")
+
+	pdf.Ln(5)
+}
+
+// addLeaveHistory adds one row per leave request
+func (g *Generator) addLeaveHistory(pdf *gofpdf.Fpdf, report *models.LeaveReport) {
+	g.addSectionHeader(pdf, "Leave History")
+
+	if len(report.Requests) == 0 {
+		g.addEmptyNote(pdf, "No leave requests in this period.")
+		return
+	}
+
+	table := NewTable(leaveHistoryColumns)
+	table.Striped = true
+	for _, leave := range report.Requests {
+		table.AddRow(
+			leave.Policy,
+			models.FormatDate(&leave.From, "-"),
+			models.FormatDate(&leave.To, "-"),
+			formatDays(float64(leave.Days)),
+			leave.Status,
+			models.SafeString(leave.Approver, "-"),
+			models.FormatDate(leave.Submitted, "-"),
+		)
+	}
+	table.Render(pdf)
+
+	pdf.Ln(5)
+}
+
+// addEmptyNote adds an italic placeholder line for empty sections
+func (g *Generator) addEmptyNote(pdf *gofpdf.Fpdf, text string) {
+	pdf.SetFont("Arial", "I", 10)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 6, text, "", 1, "L", false, 0, "")
+	pdf.Ln(5)
+}
+
+func formatPeriod(from, to time.Time) string {
+	switch {
+	case from.IsZero() && to.IsZero():
+		return "All dates"
+	case from.IsZero():
+		return "Up to " + to.Format("2006-01-02")
+	case to.IsZero():
+		return "From " + from.Format("2006-01-02")
+	default:
+		return from.Format("2006-01-02") + " to " + to.Format("2006-01-02")
+	}
+}
+
+func formatDays(days float64) string {
+	return strconv.FormatFloat(days, 'f', -1, 64)
+}
+
+func formatOptionalDays(days *float64) string {
+	if days == nil {
+		return "N/A"
+	}
+	return formatDays(*days)
+}
@@ -0,0 +1,170 @@
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// GenerateNoticeDigest compiles notices into a printable bulletin with a title
+// page and a table of contents
+func (g *Generator) GenerateNoticeDigest(digest *models.NoticeDigest, metadata *models.ReportMetadata) (string, error) {
+	if digest == nil {
+		return "", fmt.Errorf("digest cannot be nil")
+	}
+
+	if metadata == nil {
+		metadata = &models.ReportMetadata{
+			GeneratedAt: time.Now(),
+			GeneratedBy: "System",
+			ReportID:    fmt.Sprintf("NTC-%d", time.Now().Unix()),
+		}
+	}
+
+	pdf := g.newDocument(metadata)
+	g.addPageNumbers(pdf)
+
+	g.addDigestTitlePage(pdf, digest, metadata)
+
+	// Links and page-number aliases are filled in once each notice is placed
+	links := make([]int, len(digest.Notices))
+	for i := range digest.Notices {
+		links[i] = pdf.AddLink()
+	}
+	pdf.AddPage()
+	g.addDigestContents(pdf, digest, links)
+
+	pdf.AddPage()
+	for i, item := range digest.Notices {
+		g.addDigestNotice(pdf, item, links[i], tocAlias(i))
+	}
+	if len(digest.Notices) == 0 {
+		g.addEmptyNote(pdf, "No approved notices in this period.")
+	}
+
+	filename := fmt.Sprintf("notices_report_%s_%s.pdf",
+		g.sanitizeFilename(digest.Audience),
+		content.FileStamp())
+
+	return g.saveReport(pdf, filename, documentInfo{
+		Title:    orDefault(digest.Title, "Notice Board"),
+		Subject:  fmt.Sprintf("Approved notices for %s, %s", orDefault(digest.Audience, "everyone"), formatPeriod(digest.From, digest.To)),
+		Keywords: []string{"notice digest", digest.Audience},
+		Metadata: metadata,
+	})
+}
+
+// addDigestTitlePage adds the cover page
+func (g *Generator) addDigestTitlePage(pdf *gofpdf.Fpdf, digest *models.NoticeDigest, metadata *models.ReportMetadata) {
+	pdf.SetY(90)
+	pdf.SetFont("Arial", "B", 28)
+	pdf.SetTextColor(0, 51, 102)
+	pdf.MultiCell(0, 12, orDefault(digest.Title, "Notice Board"), "", "C", false)
+	pdf.Ln(6)
+
+	pdf.SetFont("Arial", "", 14)
+	pdf.SetTextColor(51, 51, 51)
+	pdf.CellFormat(0, 8, formatPeriod(digest.From, digest.To), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 8, "For: "+orDefault(digest.Audience, "Everyone"), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 8, fmt.Sprintf("%d notices", len(digest.Notices)), "", 1, "C", false, 0, "")
+
+	g.addCoverDetails(pdf, metadata)
+}
+
+// addCoverDetails prints the report ID and generation time at the foot of a cover page
+func (g *Generator) addCoverDetails(pdf *gofpdf.Fpdf, metadata *models.ReportMetadata) {
+	pdf.SetY(-50)
+	pdf.SetFont("Arial", "", 9)
+	pdf.SetTextColor(100, 100, 100)
+	pdf.CellFormat(0, 5, fmt.Sprintf("Report ID: %s", metadata.ReportID), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 5, fmt.Sprintf("Generated: %s", metadata.GeneratedAt.Format("January 2, 2006 at 15:04 MST")), "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 5, content.SchoolName(metadata), "", 1, "C", false, 0, "")
+}
+
+// addDigestContents adds one clickable line per notice with its page number
+func (g *Generator) addDigestContents(pdf *gofpdf.Fpdf, digest *models.NoticeDigest, links []int) {
+	g.addSectionHeader(pdf, "Contents")
+
+	if len(digest.Notices) == 0 {
+		g.addEmptyNote(pdf, "No approved notices in this period.")
+		return
+	}
+
+	const numberWidth, dateWidth, pageWidth = 10.0, 25.0, 15.0
+	titleWidth := printableWidth(pdf) - numberWidth - dateWidth - pageWidth
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.SetTextColor(51, 51, 51)
+	for i, item := range digest.Notices {
+		title := truncateText(pdf, item.Notice.Title, titleWidth-2)
+		pdf.CellFormat(numberWidth, 7, strconv.Itoa(i+1)+".", "", 0, "L", false, links[i], "")
+		pdf.CellFormat(titleWidth, 7, title, "", 0, "L", false, links[i], "")
+		pdf.CellFormat(dateWidth, 7, formatDate(item.Published), "", 0, "L", false, links[i], "")
+		pdf.CellFormat(pageWidth, 7, tocAlias(i), "", 1, "L", false, links[i], "")
+	}
+}
+
+// addDigestNotice prints one notice and records its page for the contents
+func (g *Generator) addDigestNotice(pdf *gofpdf.Fpdf, item models.DigestNotice, link int, alias string) {
+	// Keep a notice's heading with the start of its text
+	if pdf.GetY() > pageBreakY(pdf)-40 {
+		pdf.AddPage()
+	}
+
+	pdf.SetLink(link, -1, -1)
+	pdf.RegisterAlias(alias, strconv.Itoa(pdf.PageNo()))
+
+	pdf.SetFont("Arial", "B", 14)
+	pdf.SetTextColor(0, 51, 102)
+	pdf.MultiCell(0, 7, item.Notice.Title, "", "L", false)
+
+	pdf.SetFont("Arial", "I", 9)
+	pdf.SetTextColor(100, 100, 100)
+	meta := "Published " + formatDate(item.Published)
+	if item.Notice.Author != nil {
+		meta += " by " + *item.Notice.Author
+	}
+	pdf.CellFormat(0, 6, meta, "", 1, "L", false, 0, "")
+	pdf.Ln(1)
+
+	pdf.SetFont("Arial", "", 11)
+	pdf.SetTextColor(51, 51, 51)
+	pdf.MultiCell(0, 6, item.Notice.Description, "", "L", false)
+
+	pdf.Ln(3)
+	left, _, right, _ := pdf.GetMargins()
+	pageWidth, _ := pdf.GetPageSize()
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.Line(left, pdf.GetY(), pageWidth-right, pdf.GetY())
+	pdf.SetDrawColor(0, 0, 0)
+	pdf.Ln(6)
+}
+
+// tocAlias is the placeholder replaced with a notice's page number on output
+func tocAlias(i int) string {
+	return fmt.Sprintf("{toc%d}", i)
+}
+
+// truncateText shortens text with an ellipsis so it fits in the given width
+func truncateText(pdf *gofpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 && pdf.GetStringWidth(string(runes)+"...") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
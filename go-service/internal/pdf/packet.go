@@ -0,0 +1,172 @@
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// packetReport is a packet part resolved to its contents title and renderer
+type packetReport struct {
+	title  string
+	render func(g *Generator, pdf *gofpdf.Fpdf, metadata *models.ReportMetadata)
+}
+
+// GeneratePacket combines several reports into one PDF with a cover page, a
+// clickable table of contents, continuous page numbers and a bookmark per report
+func (g *Generator) GeneratePacket(packet *models.ReportPacket, metadata *models.ReportMetadata) (string, error) {
+	if packet == nil || len(packet.Parts) == 0 {
+		return "", fmt.Errorf("packet must contain at least one report")
+	}
+
+	reports := make([]packetReport, len(packet.Parts))
+	for i, part := range packet.Parts {
+		report, err := newPacketReport(part)
+		if err != nil {
+			return "", fmt.Errorf("packet part %d: %w", i+1, err)
+		}
+		reports[i] = report
+	}
+
+	if metadata == nil {
+		metadata = &models.ReportMetadata{
+			GeneratedAt: time.Now(),
+			GeneratedBy: "System",
+			ReportID:    fmt.Sprintf("PKT-%d", time.Now().Unix()),
+		}
+	}
+	title := orDefault(packet.Title, "Report Packet")
+
+	pdf := g.newDocument(metadata)
+	g.addPageNumbers(pdf)
+	g.addPacketCover(pdf, title, reports, metadata)
+
+	// Links and page-number aliases are filled in as each report starts
+	links := make([]int, len(reports))
+	for i := range reports {
+		links[i] = pdf.AddLink()
+	}
+	pdf.AddPage()
+	g.addPacketContents(pdf, reports, links)
+
+	// Sections are bookmarked one level below their report
+	nested := *g
+	nested.outlineLevel = g.outlineLevel + 1
+	for i, report := range reports {
+		pdf.AddPage()
+		pdf.SetLink(links[i], -1, -1)
+		pdf.RegisterAlias(packetAlias(i), strconv.Itoa(pdf.PageNo()))
+		pdf.Bookmark(report.title, g.outlineLevel, -1)
+		report.render(&nested, pdf, metadata)
+	}
+
+	filename := fmt.Sprintf("packet_report_%s_%s.pdf",
+		g.sanitizeFilename(title),
+		content.FileStamp())
+
+	titles := make([]string, len(reports))
+	for i, report := range reports {
+		titles[i] = report.title
+	}
+	return g.saveReport(pdf, filename, documentInfo{
+		Title:    title,
+		Subject:  strings.Join(titles, "; "),
+		Keywords: []string{"report packet"},
+		Metadata: metadata,
+	})
+}
+
+// newPacketReport picks the title and renderer for the report a part holds
+func newPacketReport(part models.PacketPart) (packetReport, error) {
+	set := 0
+	var report packetReport
+	if part.Student != nil {
+		set++
+		report = packetReport{
+			title: part.Title(),
+			render: func(g *Generator, pdf *gofpdf.Fpdf, metadata *models.ReportMetadata) {
+				g.renderDocument(pdf, content.NewStudentDocument(part.Student, metadata))
+			},
+		}
+	}
+	if part.Staff != nil {
+		set++
+		report = packetReport{
+			title: part.Title(),
+			render: func(g *Generator, pdf *gofpdf.Fpdf, metadata *models.ReportMetadata) {
+				g.renderStaffReport(pdf, part.Staff, metadata)
+			},
+		}
+	}
+	if part.Leave != nil {
+		set++
+		report = packetReport{
+			title: part.Title(),
+			render: func(g *Generator, pdf *gofpdf.Fpdf, metadata *models.ReportMetadata) {
+				g.renderLeaveReport(pdf, part.Leave, metadata)
+			},
+		}
+	}
+	if part.Roster != nil {
+		set++
+		report = packetReport{
+			title: part.Title(),
+			render: func(g *Generator, pdf *gofpdf.Fpdf, metadata *models.ReportMetadata) {
+				g.renderClassRoster(pdf, part.Roster, metadata)
+			},
+		}
+	}
+
+	if set != 1 {
+		return packetReport{}, fmt.Errorf("expected exactly one report, got %d", set)
+	}
+	return report, nil
+}
+
+// addPacketCover adds the cover page
+func (g *Generator) addPacketCover(pdf *gofpdf.Fpdf, title string, reports []packetReport, metadata *models.ReportMetadata) {
+	pdf.SetY(90)
+	pdf.SetFont("Arial", "B", 28)
+	pdf.SetTextColor(0, 51, 102)
+	pdf.MultiCell(0, 12, title, "", "C", false)
+	pdf.Ln(6)
+
+	pdf.SetFont("Arial", "", 14)
+	pdf.SetTextColor(51, 51, 51)
+	count := fmt.Sprintf("%d reports", len(reports))
+	if len(reports) == 1 {
+		count = "1 report"
+	}
+	pdf.CellFormat(0, 8, count, "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 8, "Prepared by "+metadata.GeneratedBy, "", 1, "C", false, 0, "")
+
+	g.addCoverDetails(pdf, metadata)
+}
+
+// addPacketContents adds one clickable line per report with its first page
+func (g *Generator) addPacketContents(pdf *gofpdf.Fpdf, reports []packetReport, links []int) {
+	g.addSectionHeader(pdf, "Contents")
+
+	const numberWidth, pageWidth = 10.0, 15.0
+	titleWidth := printableWidth(pdf) - numberWidth - pageWidth
+
+	pdf.SetFont("Arial", "", 10)
+	pdf.SetTextColor(51, 51, 51)
+	for i, report := range reports {
+		title := truncateText(pdf, report.title, titleWidth-2)
+		pdf.CellFormat(numberWidth, 7, strconv.Itoa(i+1)+".", "", 0, "L", false, links[i], "")
+		pdf.CellFormat(titleWidth, 7, title, "", 0, "L", false, links[i], "")
+		pdf.CellFormat(pageWidth, 7, packetAlias(i), "", 1, "L", false, links[i], "")
+	}
+}
+
+// packetAlias is the placeholder replaced with a report's first page number on output
+func packetAlias(i int) string {
+	return fmt.Sprintf("{part%d}", i)
+}
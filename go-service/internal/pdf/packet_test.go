@@ -0,0 +1,153 @@
+package pdf
+
+import (
+	"compress/zlib"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPacket() *models.ReportPacket {
+	class := "Grade 5"
+	ana := models.Student{ID: 7, Name: "Ana", Class: &class}
+	ben := models.Student{ID: 8, Name: "Ben", Class: &class}
+
+	return &models.ReportPacket{
+		Title: "Parent-Teacher Meeting",
+		Parts: []models.PacketPart{
+			{Student: &ana},
+			{Leave: &models.LeaveReport{UserID: 7, UserName: "Ana"}},
+			{Roster: &models.ClassRoster{ClassName: class, Students: []models.Student{ana, ben}}},
+		},
+	}
+}
+
+func TestGenerator_GeneratePacket(t *testing.T) {
+	path, err := newTestGenerator(t, false).GeneratePacket(testPacket(), archivalMetadata(false))
+	require.NoError(t, err)
+	assert.Contains(t, path, "packet_report_Parent-Teacher_Meeting_")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	doc := readPDF(t, data)
+	catalog := doc.object(doc.trailer, "/Root")
+
+	// One bookmark per report, with the report's sections nested below it
+	outline := doc.outline(doc.object(catalog, "/Outlines"))
+	var titles []string
+	for _, item := range outline {
+		titles = append(titles, item.title)
+	}
+	assert.Equal(t, []string{"Contents", "Student Profile: Ana", "Leave History: Ana", "Class Roster: Grade 5"}, titles)
+	assert.Empty(t, outline[0].children)
+	assert.Len(t, outline[1].children, 5)
+	assert.Equal(t, "Basic Information", outline[1].children[0].title)
+	assert.Equal(t, []outlineItem{{title: "Class"}, {title: "Students"}}, outline[3].children)
+
+	// Every page is numbered out of the packet total and each contents
+	// entry links to the page its report starts on
+	pages := pageTexts(t, doc)
+	require.Len(t, pages, 6)
+	for i, text := range pages {
+		assert.Contains(t, text, "Page "+strconv.Itoa(i+1)+" of 6")
+		assert.NotContains(t, text, "{nb}")
+		assert.NotContains(t, text, "{part")
+	}
+	assert.Contains(t, pages[0], "Parent-Teacher Meeting")
+	assert.Contains(t, pages[0], "3 reports")
+
+	contents := doc.objects[pageObject(t, doc, 2)]
+	links := regexp.MustCompile(`/Subtype /Link .*?/Dest \[(\d+) 0 R`).FindAllStringSubmatch(contents, -1)
+	require.Len(t, links, 9, "number, title and page cells link to each report")
+	for i, first := range []int{3, 5, 6} {
+		target, _ := strconv.Atoi(links[3*i][1])
+		assert.Equal(t, pageObject(t, doc, first), target)
+		assert.Contains(t, pages[1], "("+strconv.Itoa(first)+")")
+	}
+}
+
+func TestGenerator_GeneratePacketArchival(t *testing.T) {
+	path, err := newTestGenerator(t, false).GeneratePacket(testPacket(), archivalMetadata(true))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	validatePDFA(t, data, documentInfo{
+		Title:    "Parent-Teacher Meeting",
+		Subject:  "Student Profile: Ana; Leave History: Ana; Class Roster: Grade 5",
+		Keywords: []string{"report packet"},
+		Metadata: archivalMetadata(true),
+	})
+}
+
+func TestGenerator_GeneratePacketRejectsInvalidParts(t *testing.T) {
+	staff := &models.Staff{ID: 1, Name: "Sam"}
+
+	tests := []struct {
+		name   string
+		packet *models.ReportPacket
+		errMsg string
+	}{
+		{"Nil packet", nil, "at least one report"},
+		{"No parts", &models.ReportPacket{}, "at least one report"},
+		{"Empty part", &models.ReportPacket{Parts: []models.PacketPart{{Staff: staff}, {}}}, "packet part 2: expected exactly one report, got 0"},
+		{"Two reports in one part", &models.ReportPacket{Parts: []models.PacketPart{{Staff: staff, Student: &models.Student{}}}}, "got 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestGenerator(t, false).GeneratePacket(tt.packet, nil)
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+// pageTexts returns the decompressed content stream of each page in order
+func pageTexts(t *testing.T, doc *parsedPDF) []string {
+	t.Helper()
+	kids := doc.pageObjects()
+	texts := make([]string, len(kids))
+	for i, kid := range kids {
+		stream := doc.object(doc.objects[kid], "/Contents")
+		start := strings.Index(stream, "stream\n") + len("stream\n")
+		end := strings.LastIndex(stream, "\nendstream")
+		reader, err := zlib.NewReader(strings.NewReader(stream[start:end]))
+		require.NoError(t, err)
+		text, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		texts[i] = string(text)
+	}
+	return texts
+}
+
+// pageObject returns the object number of a 1-based page
+func pageObject(t *testing.T, doc *parsedPDF, page int) int {
+	t.Helper()
+	kids := doc.pageObjects()
+	require.GreaterOrEqual(t, len(kids), page)
+	return kids[page-1]
+}
+
+// pageObjects lists page object numbers from the page tree in order
+func (p *parsedPDF) pageObjects() []int {
+	p.t.Helper()
+	catalog := p.object(p.trailer, "/Root")
+	pages := p.object(catalog, "/Pages")
+	match := regexp.MustCompile(`/Kids \[([^\]]*)\]`).FindStringSubmatch(pages)
+	require.NotNil(p.t, match)
+
+	var kids []int
+	for _, ref := range regexp.MustCompile(`(\d+) 0 R`).FindAllStringSubmatch(match[1], -1) {
+		n, _ := strconv.Atoi(ref[1])
+		kids = append(kids, n)
+	}
+	return kids
+}
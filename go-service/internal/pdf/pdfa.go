@@ -0,0 +1,292 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto/md5"
+	"embed"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Archival (PDF/A-2b) output. gofpdf writes the page content, embedded fonts
+// and document structure; convertToPDFA then adds what gofpdf cannot produce:
+// the XMP metadata stream, the sRGB output intent and a trailer ID.
+
+//go:embed fonts/*.ttf
+var archivalFonts embed.FS
+
+// archivalFontFiles replaces the core Arial (Helvetica) styles used by the
+// templates with embedded TrueType fonts, since PDF/A requires every font
+// program to be embedded
+var archivalFontFiles = []struct {
+	style string
+	file  string
+}{
+	{"", "fonts/DejaVuSansCondensed.ttf"},
+	{"B", "fonts/DejaVuSansCondensed-Bold.ttf"},
+	{"I", "fonts/DejaVuSansCondensed-Oblique.ttf"},
+}
+
+// pdfBinaryMarker follows the header so file transfers treat the document as
+// binary
+const pdfBinaryMarker = "%\xe2\xe3\xcf\xd3\n"
+
+// archival reports whether a document should be written as PDF/A
+func (g *Generator) archival(metadata *models.ReportMetadata) bool {
+	return g.cfg().Archival || (metadata != nil && metadata.Archival)
+}
+
+// embedArchivalFonts registers the embedded fonts under the "Arial" family so
+// the existing drawing code uses them unchanged
+func embedArchivalFonts(pdf *gofpdf.Fpdf) error {
+	for _, font := range archivalFontFiles {
+		data, err := archivalFonts.ReadFile(font.file)
+		if err != nil {
+			return fmt.Errorf("failed to read font %s: %w", font.file, err)
+		}
+		pdf.AddUTF8FontFromBytes("Arial", font.style, data)
+	}
+	return pdf.Error()
+}
+
+// convertToPDFA rewrites a gofpdf document as PDF/A-2b. gofpdf always writes
+// the Info and Catalog dictionaries as the last two objects, so everything
+// before them is kept as is and only the tail of the file is rebuilt. The Info
+// dictionary is rewritten from info so that it matches the XMP metadata
+func convertToPDFA(data []byte, info documentInfo) ([]byte, error) {
+	offsets, xrefOffset, err := parseXref(data)
+	if err != nil {
+		return nil, err
+	}
+
+	size := len(offsets)
+	catalogObj, infoObj := size-1, size-2
+	trailer := data[xrefOffset:]
+	if !bytes.Contains(trailer, []byte(fmt.Sprintf("/Root %d 0 R", catalogObj))) ||
+		!bytes.Contains(trailer, []byte(fmt.Sprintf("/Info %d 0 R", infoObj))) {
+		return nil, fmt.Errorf("unexpected document structure")
+	}
+	if bytes.Contains(trailer, []byte("/Encrypt")) {
+		return nil, fmt.Errorf("encrypted documents cannot be archived")
+	}
+
+	catalogPrefix := fmt.Sprintf("%d 0 obj\n<<\n", catalogObj)
+	catalog := data[offsets[catalogObj]:xrefOffset]
+	if !bytes.HasPrefix(catalog, []byte(catalogPrefix)) {
+		return nil, fmt.Errorf("unexpected catalog object")
+	}
+
+	headerEnd := bytes.IndexByte(data, '\n') + 1
+	var out bytes.Buffer
+	out.Write(data[:headerEnd])
+	out.WriteString(pdfBinaryMarker)
+
+	// Copy the objects before Info in file order, flagging link annotations
+	// as printable since PDF/A requires every annotation to have flags
+	order := make([]int, 0, infoObj-1)
+	for n := 1; n < infoObj; n++ {
+		order = append(order, n)
+	}
+	sort.Slice(order, func(i, j int) bool { return offsets[order[i]] < offsets[order[j]] })
+	if len(order) > 0 {
+		out.Write(data[headerEnd:offsets[order[0]]])
+	}
+	for i, n := range order {
+		end := offsets[infoObj]
+		if i+1 < len(order) {
+			end = offsets[order[i+1]]
+		}
+		object := data[offsets[n]:end]
+		offsets[n] = out.Len()
+		out.Write(flagLinks(object))
+	}
+
+	iccObj, intentObj, metadataObj := size, size+1, size+2
+	offsets = append(offsets, 0, 0, 0)
+	startObject := func(n int) {
+		offsets[n] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n", n)
+	}
+
+	created := "D:" + info.created().Format("20060102150405") + "Z"
+	startObject(infoObj)
+	out.WriteString("<<\n")
+	for _, entry := range []struct{ key, value string }{
+		{"Producer", documentProducer},
+		{"Title", info.Title},
+		{"Subject", info.Subject},
+		{"Author", info.Metadata.GeneratedBy},
+		{"Keywords", info.keywords()},
+		{"Creator", documentCreator},
+	} {
+		if entry.value != "" {
+			fmt.Fprintf(&out, "/%s %s\n", entry.key, pdfTextString(entry.value))
+		}
+	}
+	fmt.Fprintf(&out, "/CreationDate (%s)\n/ModDate (%s)\n", created, created)
+	out.WriteString(">>\nendobj\n")
+
+	offsets[catalogObj] = out.Len()
+	out.WriteString(catalogPrefix)
+	fmt.Fprintf(&out, "/Metadata %d 0 R\n/OutputIntents [%d 0 R]\n", metadataObj, intentObj)
+	out.Write(catalog[len(catalogPrefix):])
+
+	startObject(iccObj)
+	fmt.Fprintf(&out, "<<\n/N 3\n/Length %d\n>>\nstream\n", len(srgbProfile))
+	out.Write(srgbProfile)
+	out.WriteString("\nendstream\nendobj\n")
+
+	startObject(intentObj)
+	fmt.Fprintf(&out, "<<\n/Type /OutputIntent\n/S /GTS_PDFA1\n/OutputConditionIdentifier %s\n/Info %s\n/DestOutputProfile %d 0 R\n>>\nendobj\n",
+		pdfTextString(srgbProfileName), pdfTextString(srgbProfileName), iccObj)
+
+	xmp := xmpMetadata(info)
+	startObject(metadataObj)
+	fmt.Fprintf(&out, "<<\n/Type /Metadata\n/Subtype /XML\n/Length %d\n>>\nstream\n", len(xmp))
+	out.Write(xmp)
+	out.WriteString("\nendstream\nendobj\n")
+
+	sum := md5.Sum(data)
+	id := hex.EncodeToString(sum[:])
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets))
+	for _, offset := range offsets[1:] {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<<\n/Size %d\n/Root %d 0 R\n/Info %d 0 R\n/ID [<%s> <%s>]\n>>\nstartxref\n%d\n%%%%EOF\n",
+		len(offsets), catalogObj, infoObj, id, id, xref)
+
+	return out.Bytes(), nil
+}
+
+// flagLinks marks the link annotations in an object's dictionary as
+// printable. A stream following the dictionary is copied unchanged, since
+// its bytes may contain the same text
+func flagLinks(object []byte) []byte {
+	dictionary, stream := object, []byte(nil)
+	if i := bytes.Index(object, []byte("\nstream")); i >= 0 {
+		dictionary, stream = object[:i], object[i:]
+	}
+	flagged := bytes.ReplaceAll(dictionary, []byte("/Subtype /Link "), []byte("/Subtype /Link /F 4 "))
+	return append(flagged, stream...)
+}
+
+// parseXref reads the cross-reference table gofpdf writes at the end of the
+// file. The returned offsets are indexed by object number; entry 0 is unused
+func parseXref(data []byte) ([]int, int, error) {
+	start := bytes.LastIndex(data, []byte("startxref\n"))
+	if start < 0 {
+		return nil, 0, fmt.Errorf("missing startxref")
+	}
+	fields := strings.Fields(string(data[start+len("startxref\n"):]))
+	if len(fields) == 0 {
+		return nil, 0, fmt.Errorf("missing xref offset")
+	}
+	xrefOffset, err := strconv.Atoi(fields[0])
+	if err != nil || xrefOffset <= 0 || xrefOffset >= start {
+		return nil, 0, fmt.Errorf("invalid xref offset %q", fields[0])
+	}
+
+	lines := strings.Split(string(data[xrefOffset:start]), "\n")
+	var first, size int
+	if len(lines) < 2 || lines[0] != "xref" {
+		return nil, 0, fmt.Errorf("missing xref table")
+	}
+	if _, err := fmt.Sscanf(lines[1], "%d %d", &first, &size); err != nil || first != 0 || size < 3 || len(lines) < size+2 {
+		return nil, 0, fmt.Errorf("invalid xref subsection %q", lines[1])
+	}
+
+	offsets := make([]int, size)
+	for i := 1; i < size; i++ {
+		entry := lines[2+i]
+		if len(entry) < 10 {
+			return nil, 0, fmt.Errorf("invalid xref entry %q", entry)
+		}
+		if offsets[i], err = strconv.Atoi(entry[:10]); err != nil {
+			return nil, 0, fmt.Errorf("invalid xref entry %q: %w", entry, err)
+		}
+	}
+	return offsets, xrefOffset, nil
+}
+
+// xmpMetadata builds the XMP packet identifying the document as PDF/A-2b
+func xmpMetadata(info documentInfo) []byte {
+	date := info.created().Format("2006-01-02T15:04:05Z")
+
+	var buf bytes.Buffer
+	buf.WriteString("<?xpacket begin=\"\ufeff\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	buf.WriteString("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n")
+	buf.WriteString("<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n")
+	buf.WriteString("<rdf:Description rdf:about=\"\"" +
+		" xmlns:pdfaid=\"http://www.aiim.org/pdfa/ns/id/\"" +
+		" xmlns:dc=\"http://purl.org/dc/elements/1.1/\"" +
+		" xmlns:xmp=\"http://ns.adobe.com/xap/1.0/\"" +
+		" xmlns:pdf=\"http://ns.adobe.com/pdf/1.3/\">\n")
+	buf.WriteString("<pdfaid:part>2</pdfaid:part>\n")
+	buf.WriteString("<pdfaid:conformance>B</pdfaid:conformance>\n")
+	buf.WriteString("<dc:format>application/pdf</dc:format>\n")
+	if info.Title != "" {
+		fmt.Fprintf(&buf, "<dc:title><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:title>\n", xmlText(info.Title))
+	}
+	if info.Subject != "" {
+		fmt.Fprintf(&buf, "<dc:description><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:description>\n", xmlText(info.Subject))
+	}
+	if info.Metadata.ReportID != "" {
+		fmt.Fprintf(&buf, "<dc:identifier>%s</dc:identifier>\n", xmlText(info.Metadata.ReportID))
+	}
+	if info.Metadata.GeneratedBy != "" {
+		fmt.Fprintf(&buf, "<dc:creator><rdf:Seq><rdf:li>%s</rdf:li></rdf:Seq></dc:creator>\n", xmlText(info.Metadata.GeneratedBy))
+	}
+	fmt.Fprintf(&buf, "<dc:language><rdf:Bag><rdf:li>%s</rdf:li></rdf:Bag></dc:language>\n", documentLanguage)
+	fmt.Fprintf(&buf, "<xmp:CreateDate>%s</xmp:CreateDate>\n", date)
+	fmt.Fprintf(&buf, "<xmp:ModifyDate>%s</xmp:ModifyDate>\n", date)
+	fmt.Fprintf(&buf, "<xmp:MetadataDate>%s</xmp:MetadataDate>\n", date)
+	fmt.Fprintf(&buf, "<xmp:CreatorTool>%s</xmp:CreatorTool>\n", xmlText(documentCreator))
+	fmt.Fprintf(&buf, "<pdf:Producer>%s</pdf:Producer>\n", xmlText(documentProducer))
+	if keywords := info.keywords(); keywords != "" {
+		fmt.Fprintf(&buf, "<pdf:Keywords>%s</pdf:Keywords>\n", xmlText(keywords))
+	}
+	buf.WriteString("</rdf:Description>\n</rdf:RDF>\n</x:xmpmeta>\n")
+	buf.WriteString("<?xpacket end=\"w\"?>")
+	return buf.Bytes()
+}
+
+func xmlText(s string) string {
+	var buf strings.Builder
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// pdfTextString encodes s as a PDF literal string, or as UTF-16BE hex when it
+// has characters outside printable ASCII
+func pdfTextString(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r < 0x20 || r > 0x7e {
+			ascii = false
+			break
+		}
+	}
+
+	if ascii {
+		replacer := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+		return "(" + replacer.Replace(s) + ")"
+	}
+
+	var buf strings.Builder
+	buf.WriteString("<FEFF")
+	for _, unit := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&buf, "%04X", unit)
+	}
+	buf.WriteString(">")
+	return buf.String()
+}
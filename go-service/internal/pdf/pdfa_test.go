@@ -0,0 +1,278 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestGenerator(t *testing.T, archival bool) *Generator {
+	t.Helper()
+	generator, err := NewGenerator(&config.ReportConfig{
+		OutputDir:     t.TempDir(),
+		MaxFileSize:   10 * 1024 * 1024,
+		WatermarkText: "Confidential",
+		Archival:      archival,
+	})
+	require.NoError(t, err)
+	return generator
+}
+
+func archivalMetadata(archival bool) *models.ReportMetadata {
+	return &models.ReportMetadata{
+		GeneratedAt: time.Date(2024, 3, 5, 9, 30, 15, 0, time.UTC),
+		GeneratedBy: "Zoë (registrar)",
+		ReportID:    "RPT-7-1709631015",
+		Archival:    archival,
+	}
+}
+
+func TestGenerator_ArchivalOutput(t *testing.T) {
+	class := "Grade 5"
+	student := &models.Student{ID: 7, Name: "Ana Müller", Email: "ana@example.com", Class: &class}
+
+	tests := []struct {
+		name     string
+		config   bool
+		request  bool
+		info     documentInfo
+		generate func(*Generator, *models.ReportMetadata) (string, error)
+	}{
+		{
+			name:    "Student report requested as archival",
+			request: true,
+			info: documentInfo{
+				Title:    "Student Information Report: Ana Müller",
+				Subject:  "Student record of Ana Müller (ID 7)",
+				Keywords: []string{"student report", class},
+			},
+			generate: func(g *Generator, md *models.ReportMetadata) (string, error) {
+				return g.GenerateStudentReport(student, md)
+			},
+		},
+		{
+			name:   "Class roster with page numbers archived by configuration",
+			config: true,
+			info: documentInfo{
+				Title:    "Class Roster: Grade 5",
+				Subject:  "Students enrolled in Grade 5, all sections",
+				Keywords: []string{"class roster", class},
+			},
+			generate: func(g *Generator, md *models.ReportMetadata) (string, error) {
+				return g.GenerateClassRoster(&models.ClassRoster{ClassName: class, Students: []models.Student{*student}}, md)
+			},
+		},
+		{
+			name:    "Dashboard with charts",
+			request: true,
+			info: documentInfo{
+				Title:    "School Dashboard Summary",
+				Subject:  "Headcounts, leave and notices across the school",
+				Keywords: []string{"dashboard", "summary"},
+			},
+			generate: func(g *Generator, md *models.ReportMetadata) (string, error) {
+				return g.GenerateDashboardReport(&models.DashboardReport{Dashboard: &models.Dashboard{}}, md)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := tt.generate(newTestGenerator(t, tt.config), archivalMetadata(tt.request))
+			require.NoError(t, err)
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			tt.info.Metadata = archivalMetadata(true)
+			validatePDFA(t, data, tt.info)
+		})
+	}
+}
+
+func TestGenerator_StandardOutputIsUnchanged(t *testing.T) {
+	path, err := newTestGenerator(t, false).GenerateStudentReport(&models.Student{ID: 7, Name: "Ana"}, archivalMetadata(false))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "/OutputIntents")
+	assert.NotContains(t, string(data), "/FontFile2")
+	assert.Contains(t, string(data), "/BaseFont /Helvetica")
+}
+
+func TestGenerator_UpdateConfig(t *testing.T) {
+	generator := newTestGenerator(t, false)
+	student := &models.Student{ID: 7, Name: "Ana"}
+
+	reloaded := *generator.cfg()
+	reloaded.Archival = true
+	reloaded.OutputDir = t.TempDir()
+	generator.UpdateConfig(&reloaded)
+
+	path, err := generator.GenerateStudentReport(student, archivalMetadata(false))
+	require.NoError(t, err)
+	assert.Equal(t, generator.OutputDir(), filepath.Dir(path), "output directory is fixed at creation")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "/OutputIntents", "reloaded configuration archives reports")
+
+	limited := reloaded
+	limited.MaxFileSize = 1024
+	generator.UpdateConfig(&limited)
+	_, err = generator.GenerateStudentReport(student, archivalMetadata(false))
+	assert.ErrorContains(t, err, "maximum file size")
+}
+
+func TestPDFTextString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Admin", "(Admin)"},
+		{`a (b) \c`, `(a \(b\) \\c)`},
+		{"Zoë", "<FEFF005A006F00EB>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, pdfTextString(tt.input))
+		})
+	}
+}
+
+func TestFlagLinks(t *testing.T) {
+	page := "3 0 obj\n<</Type /Page\n/Annots [<</Type /Annot /Subtype /Link /Rect [0 0 1 1]>>]\n>>\nendobj\n"
+	assert.Equal(t, strings.Replace(page, "/Link ", "/Link /F 4 ", 1), string(flagLinks([]byte(page))))
+
+	// Stream bytes are left alone, as changing them would break /Length
+	stream := "4 0 obj\n<</Length 15>>\nstream\n/Subtype /Link \nendstream\nendobj\n"
+	assert.Equal(t, stream, string(flagLinks([]byte(stream))))
+}
+
+var idPattern = regexp.MustCompile(`/ID \[<([0-9a-f]{32})> <([0-9a-f]{32})>\]`)
+
+// validatePDFA checks the structural requirements of PDF/A-2b that the
+// archival mode is responsible for: file layout, cross-reference table,
+// output intent, XMP metadata agreeing with the Info dictionary, embedded
+// fonts and the absence of transparency and encryption
+func validatePDFA(t *testing.T, data []byte, info documentInfo) {
+	t.Helper()
+
+	// Header followed by a comment of at least four binary bytes
+	require.True(t, bytes.HasPrefix(data, []byte("%PDF-1.")), "missing PDF header")
+	lines := bytes.SplitN(data, []byte("\n"), 3)
+	require.Len(t, lines, 3)
+	require.True(t, len(lines[1]) >= 5 && lines[1][0] == '%', "missing binary comment")
+	for _, b := range lines[1][1:5] {
+		assert.GreaterOrEqual(t, b, byte(128), "binary comment must use bytes above 127")
+	}
+	assert.True(t, bytes.HasSuffix(data, []byte("%%EOF\n")))
+
+	// Every cross-reference entry points at its object
+	doc := readPDF(t, data)
+	object := doc.object
+
+	trailer := doc.trailer
+	assert.NotContains(t, trailer, "/Encrypt")
+	id := idPattern.FindStringSubmatch(trailer)
+	require.NotNil(t, id, "trailer must have a file identifier")
+
+	catalog := object(trailer, "/Root")
+	infoDict := object(trailer, "/Info")
+	assert.Contains(t, catalog, "/Type /Catalog")
+
+	// sRGB output intent with an embedded ICC profile
+	intent := object(catalog, `/OutputIntents \[`)
+	assert.Contains(t, intent, "/S /GTS_PDFA1")
+	profile := object(intent, "/DestOutputProfile")
+	assert.Contains(t, profile, "/N 3")
+	stream := streamData(t, profile)
+	require.Greater(t, len(stream), 128)
+	assert.Equal(t, "acsp", string(stream[36:40]))
+	assert.Equal(t, "RGB ", string(stream[16:20]))
+
+	// XMP metadata identifying PDF/A-2b and matching the Info dictionary
+	xmpObject := object(catalog, "/Metadata")
+	assert.Contains(t, xmpObject, "/Subtype /XML")
+	assert.NotContains(t, xmpObject, "/Filter")
+	xmp := streamData(t, xmpObject)
+	decoder := xml.NewDecoder(bytes.NewReader(xmp))
+	for {
+		_, err := decoder.Token()
+		if err != nil {
+			require.ErrorContains(t, err, "EOF", "XMP must be well-formed XML")
+			break
+		}
+	}
+
+	metadata := info.Metadata
+	created := metadata.GeneratedAt.UTC()
+	for _, expected := range []string{
+		"<pdfaid:part>2</pdfaid:part>",
+		"<pdfaid:conformance>B</pdfaid:conformance>",
+		"<dc:identifier>" + metadata.ReportID + "</dc:identifier>",
+		"<rdf:li>" + metadata.GeneratedBy + "</rdf:li>",
+		"<xmp:CreateDate>" + created.Format(time.RFC3339) + "</xmp:CreateDate>",
+		"<pdf:Producer>" + documentProducer + "</pdf:Producer>",
+		`<dc:title><rdf:Alt><rdf:li xml:lang="x-default">` + info.Title + "</rdf:li></rdf:Alt></dc:title>",
+		"<pdf:Keywords>" + info.keywords() + "</pdf:Keywords>",
+	} {
+		assert.Contains(t, string(xmp), expected)
+	}
+	assert.Contains(t, infoDict, "/CreationDate (D:"+created.Format("20060102150405")+"Z)")
+	assert.Contains(t, infoDict, "/Author "+pdfTextString(metadata.GeneratedBy))
+	assert.Contains(t, infoDict, "/Producer "+pdfTextString(documentProducer))
+	assert.Equal(t, info.Title, doc.infoString(t, "Title"))
+	assert.Equal(t, info.Subject, doc.infoString(t, "Subject"))
+	assert.Contains(t, catalog, "/Lang (en)")
+
+	// Every font program is embedded
+	fonts := 0
+	for _, obj := range doc.objects {
+		if bytes.Contains([]byte(obj), []byte("/Type /Font")) {
+			fonts++
+			assert.NotContains(t, obj, "/Subtype /Type1", "core fonts are not embedded")
+			assert.NotContains(t, obj, "/Subtype /TrueType", "simple fonts must be embedded")
+		}
+		if bytes.Contains([]byte(obj), []byte("/Type /FontDescriptor")) {
+			assert.Contains(t, obj, "/FontFile2")
+		}
+	}
+	assert.Positive(t, fonts)
+
+	// Annotations are printable
+	assert.Equal(t, bytes.Count(data, []byte("/Subtype /Link ")), bytes.Count(data, []byte("/Subtype /Link /F 4 ")))
+
+	// No transparency
+	for _, key := range []string{"/SMask", "/CA ", "/ca ", "/Transparency"} {
+		assert.NotContains(t, string(data), key)
+	}
+}
+
+// streamData returns the bytes of an uncompressed stream object
+func streamData(t *testing.T, obj string) []byte {
+	t.Helper()
+	match := regexp.MustCompile(`/Length (\d+)`).FindStringSubmatch(obj)
+	require.NotNil(t, match, "stream has no length")
+	length, _ := strconv.Atoi(match[1])
+
+	start := bytes.Index([]byte(obj), []byte("stream\n"))
+	require.Positive(t, start)
+	start += len("stream\n")
+	require.GreaterOrEqual(t, len(obj), start+length)
+	assert.True(t, bytes.HasPrefix([]byte(obj[start+length:]), []byte("\nendstream")), "stream length does not match")
+	return []byte(obj[start : start+length])
+}
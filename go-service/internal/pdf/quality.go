@@ -0,0 +1,98 @@
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+)
+
+var qualityFieldColumns = []Column{
+	{Title: "Field"},
+	{Title: "Students Missing", Align: "R"},
+}
+
+var qualityChecklistColumns = []Column{
+	{Title: "Done", Width: 12},
+	{Title: "Class", MinWidth: 18},
+	{Title: "Section"},
+	{Title: "Roll", Align: "R", MinWidth: 10},
+	{Title: "Name", MinWidth: 30},
+	{Title: "Missing Fields", MinWidth: 45},
+}
+
+// GenerateQualityChecklist generates a checklist of students with missing required fields
+func (g *Generator) GenerateQualityChecklist(report *models.QualityReport, metadata *models.ReportMetadata) (string, error) {
+	if report == nil {
+		return "", fmt.Errorf("quality report cannot be nil")
+	}
+
+	if metadata == nil {
+		metadata = &models.ReportMetadata{
+			GeneratedAt: time.Now(),
+			GeneratedBy: "System",
+			ReportID:    fmt.Sprintf("DQC-%d", time.Now().Unix()),
+		}
+	}
+
+	pdf := g.newDocument(metadata)
+	g.addPageNumbers(pdf)
+
+	g.addHeader(pdf, "Student Data Checklist", metadata)
+
+	g.addSectionHeader(pdf, "Summary")
+	g.addInfoRow(pdf, "Class:", orDefault(report.ClassName, "All classes"))
+	g.addInfoRow(pdf, "Section:", orDefault(report.Section, "All sections"))
+	g.addInfoRow(pdf, "Required Fields:", strings.Join(report.RequiredFields, ", "))
+	g.addInfoRow(pdf, "Students Checked:", strconv.Itoa(report.Students))
+	g.addInfoRow(pdf, "Complete Records:", strconv.Itoa(report.Complete))
+	g.addInfoRow(pdf, "Incomplete Records:", strconv.Itoa(len(report.Incomplete)))
+	pdf.Ln(5)
+
+	g.addSectionHeader(pdf, "Missing by Field")
+	fields := NewTable(qualityFieldColumns)
+	for _, field := range report.RequiredFields {
+		fields.AddRow(field, strconv.Itoa(report.MissingByField[field]))
+	}
+	fields.Render(pdf)
+	pdf.Ln(5)
+
+	g.addSectionHeader(pdf, "Checklist")
+	if len(report.Incomplete) == 0 {
+		g.addEmptyNote(pdf, "All student records are complete.")
+	} else {
+		checklist := NewTable(qualityChecklistColumns)
+		checklist.Striped = true
+		for _, student := range report.Incomplete {
+			roll := "-"
+			if student.Roll != nil {
+				roll = strconv.Itoa(*student.Roll)
+			}
+			checklist.AddRow(
+				"",
+				orDefault(student.Class, "-"),
+				orDefault(student.Section, "-"),
+				roll,
+				fmt.Sprintf("%s (#%d)", student.Name, student.StudentID),
+				strings.Join(student.Missing, ", "),
+			)
+		}
+		checklist.Render(pdf)
+	}
+
+	g.addFooter(pdf, metadata)
+
+	filename := fmt.Sprintf("quality_report_%s_%s.pdf",
+		g.sanitizeFilename(orDefault(strings.TrimSpace(report.ClassName+" "+report.Section), "all")),
+		content.FileStamp())
+
+	return g.saveReport(pdf, filename, documentInfo{
+		Title:    "Student Data Checklist: " + orDefault(strings.TrimSpace(report.ClassName+" "+report.Section), "All classes"),
+		Subject:  "Student records missing required fields",
+		Keywords: []string{"data quality", report.ClassName, report.Section},
+		Metadata: metadata,
+	})
+}
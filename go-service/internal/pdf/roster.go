@@ -0,0 +1,99 @@
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+var rosterColumns = []Column{
+	{Title: "Roll", Align: "R", MinWidth: 12},
+	{Title: "Name", MinWidth: 35},
+	{Title: "Email", MinWidth: 35},
+	{Title: "System Access", Align: "C"},
+	{Title: "Guardian Phone", MinWidth: 25},
+}
+
+// GenerateClassRoster generates a roster of the students in a class or section
+func (g *Generator) GenerateClassRoster(roster *models.ClassRoster, metadata *models.ReportMetadata) (string, error) {
+	if roster == nil {
+		return "", fmt.Errorf("roster cannot be nil")
+	}
+
+	if metadata == nil {
+		metadata = &models.ReportMetadata{
+			GeneratedAt: time.Now(),
+			GeneratedBy: "System",
+			ReportID:    fmt.Sprintf("RST-%d", time.Now().Unix()),
+		}
+	}
+
+	pdf := g.newDocument(metadata)
+	g.addPageNumbers(pdf)
+	g.renderClassRoster(pdf, roster, metadata)
+
+	name := roster.ClassName
+	if roster.Section != "" {
+		name += "_" + roster.Section
+	}
+	filename := fmt.Sprintf("roster_report_%s_%s.pdf",
+		g.sanitizeFilename(name),
+		content.FileStamp())
+
+	return g.saveReport(pdf, filename, documentInfo{
+		Title:    "Class Roster: " + strings.TrimSpace(roster.ClassName+" "+roster.Section),
+		Subject:  fmt.Sprintf("Students enrolled in %s, %s", roster.ClassName, orDefault(roster.Section, "all sections")),
+		Keywords: []string{"class roster", roster.ClassName, roster.Section},
+		Metadata: metadata,
+	})
+}
+
+// renderClassRoster draws the class details and student table
+func (g *Generator) renderClassRoster(pdf *gofpdf.Fpdf, roster *models.ClassRoster, metadata *models.ReportMetadata) {
+	g.addHeader(pdf, "Class Roster", metadata)
+
+	g.addSectionHeader(pdf, "Class")
+	g.addInfoRow(pdf, "Class:", roster.ClassName)
+	g.addInfoRow(pdf, "Section:", orDefault(roster.Section, "All sections"))
+	g.addInfoRow(pdf, "Students:", strconv.Itoa(len(roster.Students)))
+	g.addInfoRow(pdf, "Sorted by:", roster.SortBy)
+	pdf.Ln(5)
+
+	g.addSectionHeader(pdf, "Students")
+	if len(roster.Students) == 0 {
+		g.addEmptyNote(pdf, "No students found for this class.")
+	} else {
+		table := NewTable(rosterColumns)
+		table.Striped = true
+		for _, student := range roster.Students {
+			roll := "-"
+			if student.Roll != nil {
+				roll = strconv.Itoa(*student.Roll)
+			}
+			table.AddRow(
+				roll,
+				student.FormatName(),
+				student.FormatEmail(),
+				g.formatBool(student.SystemAccess),
+				models.SafeString(student.GuardianPhone, "-"),
+			)
+		}
+		table.Render(pdf)
+		pdf.Ln(5)
+	}
+
+	g.addFooter(pdf, metadata)
+}
+
+func orDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
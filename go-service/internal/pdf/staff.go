@@ -0,0 +1,102 @@
+package pdf
+
+import (
+	"fmt"
+	"time"
+
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// GenerateStaffReport generates a profile report for a staff member
+func (g *Generator) GenerateStaffReport(staff *models.Staff, metadata *models.ReportMetadata) (string, error) {
+	if staff == nil {
+		return "", fmt.Errorf("staff cannot be nil")
+	}
+
+	if metadata == nil {
+		metadata = &models.ReportMetadata{
+			GeneratedAt: time.Now(),
+			GeneratedBy: "System",
+			ReportID:    fmt.Sprintf("STF-%d-%d", staff.ID, time.Now().Unix()),
+		}
+	}
+
+	pdf := g.newDocument(metadata)
+	g.renderStaffReport(pdf, staff, metadata)
+
+	filename := fmt.Sprintf("staff_report_%d_%s_%s.pdf",
+		staff.ID,
+		g.sanitizeFilename(staff.FormatName()),
+		content.FileStamp())
+
+	return g.saveReport(pdf, filename, documentInfo{
+		Title:    "Staff Profile Report: " + staff.FormatName(),
+		Subject:  fmt.Sprintf("Staff profile of %s (ID %d)", staff.FormatName(), staff.ID),
+		Keywords: []string{"staff profile", models.SafeString(staff.Department, ""), models.SafeString(staff.RoleName, "")},
+		Metadata: metadata,
+	})
+}
+
+// renderStaffReport draws the staff profile report
+func (g *Generator) renderStaffReport(pdf *gofpdf.Fpdf, staff *models.Staff, metadata *models.ReportMetadata) {
+	g.addHeader(pdf, "Staff Profile Report", metadata)
+	g.addStaffBasicInfo(pdf, staff)
+	g.addStaffEmployment(pdf, staff)
+	g.addStaffFamily(pdf, staff)
+	g.addStaffAddress(pdf, staff)
+	g.addFooter(pdf, metadata)
+}
+
+// addStaffBasicInfo adds identity and contact details
+func (g *Generator) addStaffBasicInfo(pdf *gofpdf.Fpdf, staff *models.Staff) {
+	g.addSectionHeader(pdf, "Basic Information")
+
+	g.addInfoRow(pdf, "Staff ID:", fmt.Sprintf("%d", staff.ID))
+	g.addInfoRow(pdf, "Full Name:", staff.FormatName())
+	g.addInfoRow(pdf, "Email Address:", staff.FormatEmail())
+	g.addInfoRow(pdf, "Phone Number:", models.SafeString(staff.Phone, "Not provided"))
+	g.addInfoRow(pdf, "Gender:", models.SafeString(staff.Gender, "Not specified"))
+	g.addInfoRow(pdf, "Date of Birth:", models.FormatDate(staff.DOB, "Not specified"))
+	g.addInfoRow(pdf, "Marital Status:", models.SafeString(staff.MaritalStatus, "Not specified"))
+	g.addInfoRow(pdf, "System Access:", g.formatBool(staff.SystemAccess))
+
+	pdf.Ln(5)
+}
+
+// addStaffEmployment adds role, department and career details
+func (g *Generator) addStaffEmployment(pdf *gofpdf.Fpdf, staff *models.Staff) {
+	g.addSectionHeader(pdf, "Employment Information")
+
+	g.addInfoRow(pdf, "Role:", models.SafeString(staff.RoleName, "Not assigned"))
+	g.addInfoRow(pdf, "Department:", models.SafeString(staff.Department, "Not assigned"))
+	g.addInfoRow(pdf, "Join Date:", models.FormatDate(staff.JoinDate, "Not recorded"))
+	g.addInfoRow(pdf, "Qualification:", models.SafeString(staff.Qualification, "Not provided"))
+	g.addInfoRow(pdf, "Experience:", models.SafeString(staff.Experience, "Not provided"))
+	g.addInfoRow(pdf, "Reports To:", models.SafeString(staff.ReporterName, "Not assigned"))
+
+	pdf.Ln(5)
+}
+
+// addStaffFamily adds family and emergency contact details
+func (g *Generator) addStaffFamily(pdf *gofpdf.Fpdf, staff *models.Staff) {
+	g.addSectionHeader(pdf, "Family & Emergency Contact")
+
+	g.addInfoRow(pdf, "Father's Name:", models.SafeString(staff.FatherName, "Not provided"))
+	g.addInfoRow(pdf, "Mother's Name:", models.SafeString(staff.MotherName, "Not provided"))
+	g.addInfoRow(pdf, "Emergency Phone:", models.SafeString(staff.EmergencyPhone, "Not provided"))
+
+	pdf.Ln(5)
+}
+
+// addStaffAddress adds address information
+func (g *Generator) addStaffAddress(pdf *gofpdf.Fpdf, staff *models.Staff) {
+	g.addSectionHeader(pdf, "Address Information")
+
+	g.addInfoRow(pdf, "Current Address:", models.SafeString(staff.CurrentAddress, "Not provided"))
+	g.addInfoRow(pdf, "Permanent Address:", models.SafeString(staff.PermanentAddress, "Not provided"))
+
+	pdf.Ln(5)
+}
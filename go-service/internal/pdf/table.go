@@ -0,0 +1,245 @@
+package pdf
+
+import (
+	"strings"
+
+	"github.com/jung-kurt/gofpdf"
+)
+
+// Column describes a table column. Width is computed from the content unless
+// Width is set; MinWidth keeps narrow columns readable when space is short.
+type Column struct {
+	Title    string
+	Align    string // "L", "C" or "R"; defaults to "L"
+	Width    float64
+	MinWidth float64
+}
+
+// Table renders rows as a bordered table that spans pages. The header row is
+// repeated after every page break and cell text wraps within its column.
+type Table struct {
+	Columns []Column
+	Rows    [][]string
+
+	// Striped shades every other row
+	Striped bool
+
+	FontSize   float64
+	LineHeight float64
+	Padding    float64
+}
+
+// NewTable creates a table with the default font size, line height and padding
+func NewTable(columns []Column) *Table {
+	return &Table{
+		Columns:    columns,
+		FontSize:   9,
+		LineHeight: 4.5,
+		Padding:    1.5,
+	}
+}
+
+// AddRow appends a row; missing cells render empty
+func (t *Table) AddRow(cells ...string) {
+	t.Rows = append(t.Rows, cells)
+}
+
+// Render draws the table at the current position using the full printable width
+func (t *Table) Render(pdf *gofpdf.Fpdf) {
+	// Padding replaces the cell margin so wrapping and drawing agree on widths
+	cellMargin := pdf.GetCellMargin()
+	pdf.SetCellMargin(0)
+	defer pdf.SetCellMargin(cellMargin)
+
+	widths := t.ColumnWidths(pdf, printableWidth(pdf))
+
+	t.drawHeader(pdf, widths)
+	for i, row := range t.Rows {
+		lines, height := t.layoutRow(pdf, widths, row)
+
+		if pdf.GetY()+height > pageBreakY(pdf) {
+			pdf.AddPage()
+			t.drawHeader(pdf, widths)
+		}
+
+		pdf.SetFont("Arial", "", t.FontSize)
+		pdf.SetTextColor(51, 51, 51)
+		pdf.SetDrawColor(200, 200, 200)
+		if t.Striped && i%2 == 1 {
+			pdf.SetFillColor(240, 244, 248)
+		} else {
+			pdf.SetFillColor(255, 255, 255)
+		}
+		t.drawRow(pdf, widths, lines, height)
+	}
+	pdf.SetDrawColor(0, 0, 0)
+}
+
+// ColumnWidths sizes columns to their content within the available width.
+// Spare space is shared in proportion to natural width; when content is too
+// wide, columns shrink towards their minimum width and their text wraps.
+func (t *Table) ColumnWidths(pdf *gofpdf.Fpdf, available float64) []float64 {
+	natural := make([]float64, len(t.Columns))
+	minimum := make([]float64, len(t.Columns))
+
+	for i, column := range t.Columns {
+		if column.Width > 0 {
+			natural[i], minimum[i] = column.Width, column.Width
+			continue
+		}
+
+		pdf.SetFont("Arial", "B", t.FontSize)
+		natural[i] = pdf.GetStringWidth(column.Title)
+		minimum[i] = longestWord(pdf, column.Title)
+
+		pdf.SetFont("Arial", "", t.FontSize)
+		for _, row := range t.Rows {
+			if i < len(row) {
+				natural[i] = max(natural[i], pdf.GetStringWidth(row[i]))
+				minimum[i] = max(minimum[i], longestWord(pdf, row[i]))
+			}
+		}
+
+		natural[i] = max(natural[i]+2*t.Padding, column.MinWidth)
+		minimum[i] = min(max(minimum[i]+2*t.Padding, column.MinWidth), natural[i])
+	}
+
+	total := sum(natural)
+	if total <= available {
+		return scale(natural, available/total, t.Columns)
+	}
+
+	floor := sum(minimum)
+	if floor >= available {
+		// Even minimum widths do not fit; shrink everything and let words break
+		return scale(minimum, available/floor, nil)
+	}
+
+	// Take the excess from each column in proportion to how far it can shrink
+	excess := total - available
+	slack := total - floor
+	widths := make([]float64, len(natural))
+	for i := range natural {
+		widths[i] = natural[i] - excess*(natural[i]-minimum[i])/slack
+	}
+	return widths
+}
+
+func (t *Table) drawHeader(pdf *gofpdf.Fpdf, widths []float64) {
+	pdf.SetFont("Arial", "B", t.FontSize)
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFillColor(0, 51, 102)
+	pdf.SetDrawColor(0, 51, 102)
+
+	lines := make([][]string, len(widths))
+	height := t.LineHeight + 2*t.Padding
+	for i, column := range t.Columns {
+		lines[i] = splitCell(pdf, column.Title, widths[i]-2*t.Padding)
+		height = max(height, float64(len(lines[i]))*t.LineHeight+2*t.Padding)
+	}
+	t.drawRow(pdf, widths, lines, height)
+}
+
+// layoutRow wraps each cell and returns the lines and the row height
+func (t *Table) layoutRow(pdf *gofpdf.Fpdf, widths []float64, row []string) ([][]string, float64) {
+	pdf.SetFont("Arial", "", t.FontSize)
+
+	lines := make([][]string, len(widths))
+	height := t.LineHeight + 2*t.Padding
+	for i := range widths {
+		value := ""
+		if i < len(row) {
+			value = row[i]
+		}
+		lines[i] = splitCell(pdf, value, widths[i]-2*t.Padding)
+		height = max(height, float64(len(lines[i]))*t.LineHeight+2*t.Padding)
+	}
+	return lines, height
+}
+
+// drawRow draws one row of cells with the current font, colours and fill
+func (t *Table) drawRow(pdf *gofpdf.Fpdf, widths []float64, lines [][]string, height float64) {
+	left, _, _, _ := pdf.GetMargins()
+	x, y := left, pdf.GetY()
+
+	for i, width := range widths {
+		pdf.Rect(x, y, width, height, "FD")
+
+		align := t.Columns[i].Align
+		if align == "" {
+			align = "L"
+		}
+		for j, line := range lines[i] {
+			pdf.SetXY(x+t.Padding, y+t.Padding+float64(j)*t.LineHeight)
+			pdf.CellFormat(width-2*t.Padding, t.LineHeight, line, "", 0, align, false, 0, "")
+		}
+		x += width
+	}
+
+	pdf.SetXY(left, y+height)
+}
+
+// splitCell wraps text to the width, keeping explicit line breaks
+func splitCell(pdf *gofpdf.Fpdf, text string, width float64) []string {
+	if text == "" {
+		return []string{""}
+	}
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		lines = append(lines, pdf.SplitText(paragraph, width)...)
+	}
+	return lines
+}
+
+func longestWord(pdf *gofpdf.Fpdf, text string) float64 {
+	longest := 0.0
+	for _, word := range strings.Fields(text) {
+		longest = max(longest, pdf.GetStringWidth(word))
+	}
+	return longest
+}
+
+// scale multiplies widths by factor, leaving fixed-width columns untouched
+// when columns are given
+func scale(widths []float64, factor float64, columns []Column) []float64 {
+	fixed, flexible := 0.0, 0.0
+	for i, width := range widths {
+		if columns != nil && columns[i].Width > 0 {
+			fixed += width
+		} else {
+			flexible += width
+		}
+	}
+
+	target := sum(widths)*factor - fixed
+	result := make([]float64, len(widths))
+	for i, width := range widths {
+		if columns != nil && columns[i].Width > 0 {
+			result[i] = width
+		} else if flexible > 0 {
+			result[i] = width * target / flexible
+		}
+	}
+	return result
+}
+
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, value := range values {
+		total += value
+	}
+	return total
+}
+
+func printableWidth(pdf *gofpdf.Fpdf) float64 {
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	return pageWidth - left - right
+}
+
+// pageBreakY returns the y position where automatic page breaks start
+func pageBreakY(pdf *gofpdf.Fpdf) float64 {
+	_, pageHeight := pdf.GetPageSize()
+	_, _, _, bottom := pdf.GetMargins()
+	return pageHeight - bottom
+}
@@ -0,0 +1,94 @@
+package pdf
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDocument() *gofpdf.Fpdf {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetCompression(false)
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AddPage()
+	return pdf
+}
+
+func TestTable_ColumnWidths(t *testing.T) {
+	pdf := newTestDocument()
+
+	tests := []struct {
+		name  string
+		table func() *Table
+		check func(*testing.T, []float64)
+	}{
+		{
+			name: "Narrow content is stretched to the available width",
+			table: func() *Table {
+				table := NewTable([]Column{{Title: "ID"}, {Title: "Name"}})
+				table.AddRow("1", "Jo")
+				return table
+			},
+			check: func(t *testing.T, widths []float64) {
+				assert.InDelta(t, 170, widths[0]+widths[1], 0.01)
+				assert.Less(t, widths[0], widths[1])
+			},
+		},
+		{
+			name: "Fixed widths are kept",
+			table: func() *Table {
+				table := NewTable([]Column{{Title: "ID", Width: 15}, {Title: "Name"}})
+				table.AddRow("1", "Jo")
+				return table
+			},
+			check: func(t *testing.T, widths []float64) {
+				assert.Equal(t, 15.0, widths[0])
+				assert.InDelta(t, 155, widths[1], 0.01)
+			},
+		},
+		{
+			name: "Wide content shrinks but keeps minimum widths",
+			table: func() *Table {
+				table := NewTable([]Column{{Title: "Roll", MinWidth: 12}, {Title: "Notes"}})
+				table.AddRow("1", strings.Repeat("a long sentence that must wrap ", 20))
+				return table
+			},
+			check: func(t *testing.T, widths []float64) {
+				assert.InDelta(t, 170, widths[0]+widths[1], 0.01)
+				assert.GreaterOrEqual(t, widths[0], 12.0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := tt.table()
+			tt.check(t, table.ColumnWidths(pdf, printableWidth(pdf)))
+		})
+	}
+}
+
+func TestTable_RenderRepeatsHeaderAcrossPages(t *testing.T) {
+	pdf := newTestDocument()
+
+	table := NewTable([]Column{{Title: "Roll"}, {Title: "Student Name"}, {Title: "Remarks"}})
+	table.Striped = true
+	for i := 0; i < 120; i++ {
+		table.AddRow("1", "Jane Doe", "Wrapped remarks that are long enough to need more than one line in a narrow column")
+	}
+	table.Render(pdf)
+
+	require.Greater(t, pdf.PageCount(), 1)
+
+	path := filepath.Join(t.TempDir(), "table.pdf")
+	require.NoError(t, pdf.OutputFileAndClose(path))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Count(string(content), "(Roll)"), strings.Count(string(content), "/Type /Page\n"))
+}
@@ -0,0 +1,39 @@
+package pdf
+
+import "student-report-service/internal/chart"
+
+// Theme holds the colours of the report template
+type Theme struct {
+	Heading chart.Color
+	Text    chart.Color
+	Muted   chart.Color
+	Panel   chart.Color
+
+	// Palette colours chart series in order
+	Palette []chart.Color
+}
+
+// DefaultTheme is the template's dark blue colour scheme
+var DefaultTheme = Theme{
+	Heading: chart.Color{R: 0, G: 51, B: 102},
+	Text:    chart.Color{R: 51, G: 51, B: 51},
+	Muted:   chart.Color{R: 100, G: 100, B: 100},
+	Panel:   chart.Color{R: 240, G: 244, B: 248},
+	Palette: []chart.Color{
+		{R: 0, G: 51, B: 102},
+		{R: 46, G: 134, B: 193},
+		{R: 241, G: 196, B: 15},
+		{R: 231, G: 76, B: 60},
+		{R: 39, G: 174, B: 96},
+		{R: 142, G: 68, B: 173},
+	},
+}
+
+// ChartStyle returns a chart style in the theme's colours
+func (t Theme) ChartStyle() chart.Style {
+	style := chart.DefaultStyle()
+	style.Palette = t.Palette
+	style.Text = t.Text
+	style.Muted = t.Muted
+	return style
+}
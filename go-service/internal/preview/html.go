@@ -0,0 +1,112 @@
+// Package preview renders report content as self-contained HTML pages for
+// viewing in a browser before a PDF is generated
+package preview
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+
+	"student-report-service/internal/content"
+)
+
+// pageTemplate mirrors the PDF layout: a centred title, right-aligned report
+// details, sections of label/value rows and a confidentiality footer. All
+// styles are inlined so the page needs no other resources.
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Doc.Title}}</title>
+<style>
+body { margin: 0; background: #e9edf1; font-family: Arial, Helvetica, sans-serif; color: #333333; }
+.page { position: relative; overflow: hidden; max-width: 210mm; margin: 24px auto; padding: 20mm; box-sizing: border-box; background: #ffffff; box-shadow: 0 1px 4px rgba(0, 0, 0, 0.2); }
+.watermark { position: absolute; top: 45%; left: -10%; width: 120%; text-align: center; transform: rotate(-45deg); font-size: 50px; color: #f0f0f0; white-space: nowrap; pointer-events: none; user-select: none; z-index: 0; }
+.content { position: relative; z-index: 1; }
+h1 { margin: 0 0 18px; text-align: center; font-size: 26px; color: #003366; }
+.metadata { margin-bottom: 36px; text-align: right; font-size: 13px; line-height: 1.5; color: #646464; }
+h2 { margin: 0 0 8px; font-size: 19px; color: #003366; }
+h3 { margin: 0; font-size: 15px; color: #333333; }
+section { margin-bottom: 20px; }
+.group + .group { margin-top: 12px; }
+table { border-collapse: collapse; font-size: 13px; }
+th { width: 50mm; padding: 2px 0; text-align: left; vertical-align: top; color: #000000; }
+td { padding: 2px 0; color: #333333; }
+footer { margin-top: 40px; text-align: center; font-size: 11px; font-style: italic; line-height: 1.6; color: #969696; }
+@media print { body { background: #ffffff; } .page { margin: 0; box-shadow: none; } }
+</style>
+</head>
+<body>
+<div class="page">
+{{- if .Watermark}}
+<div class="watermark" aria-hidden="true">{{.Watermark}}</div>
+{{- end}}
+<div class="content">
+<h1>{{.Doc.Title}}</h1>
+<div class="metadata">
+{{- range .Metadata}}
+<div>{{.}}</div>
+{{- end}}
+</div>
+{{- range .Doc.Sections}}
+<section>
+<h2>{{.Title}}</h2>
+{{- range .Groups}}
+<div class="group">
+{{- if .Title}}
+<h3>{{.Title}}</h3>
+{{- end}}
+<table>
+{{- range .Rows}}
+<tr><th scope="row">{{.Label}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+</div>
+{{- end}}
+</section>
+{{- end}}
+<footer>
+{{- range .Footer}}
+<div>{{.}}</div>
+{{- end}}
+</footer>
+</div>
+</div>
+</body>
+</html>
+`))
+
+// Renderer writes report content as HTML
+type Renderer struct {
+	watermark string
+}
+
+// NewRenderer creates an HTML renderer that overlays the watermark text, if any
+func NewRenderer(watermark string) *Renderer {
+	return &Renderer{watermark: watermark}
+}
+
+// Render writes the document as a complete HTML page
+func (r *Renderer) Render(w io.Writer, doc *content.Document) error {
+	if doc == nil || doc.Metadata == nil {
+		return fmt.Errorf("document and metadata cannot be nil")
+	}
+
+	data := struct {
+		Doc       *content.Document
+		Metadata  []string
+		Footer    []string
+		Watermark string
+	}{
+		Doc:       doc,
+		Metadata:  content.MetadataLines(doc.Metadata),
+		Footer:    content.FooterLines(doc.Metadata),
+		Watermark: r.watermark,
+	}
+
+	if err := pageTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render HTML: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package preview
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderer_Render(t *testing.T) {
+	metadata := &models.ReportMetadata{
+		ReportID:    "PRV-1",
+		GeneratedBy: "alice",
+		GeneratedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+	}
+	student := &models.Student{ID: 1, Name: `<script>alert("x")</script>`}
+
+	var page bytes.Buffer
+	err := NewRenderer("Confidential").Render(&page, content.NewStudentDocument(student, metadata))
+	require.NoError(t, err)
+
+	html := page.String()
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, "<title>Student Information Report</title>")
+	assert.Contains(t, html, "<div>Report ID: PRV-1</div>")
+	assert.Contains(t, html, `<div class="watermark" aria-hidden="true">Confidential</div>`)
+	assert.Contains(t, html, "<h2>Family &amp; Guardian Information</h2>")
+	assert.Contains(t, html, "<h3>Father&#39;s Information</h3>")
+	assert.Contains(t, html, `<tr><th scope="row">Student ID:</th><td>1</td></tr>`)
+	assert.Contains(t, html, "&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;")
+	assert.NotContains(t, html, "<script>")
+	assert.NotContains(t, html, "<link", "styles are inlined")
+	assert.Contains(t, html, "<div>Generated on January 15, 2024</div>")
+}
+
+func TestRenderer_RenderWithoutWatermark(t *testing.T) {
+	doc := &content.Document{Title: "Empty", Metadata: &models.ReportMetadata{}}
+
+	var page bytes.Buffer
+	require.NoError(t, NewRenderer("").Render(&page, doc))
+	assert.NotContains(t, page.String(), `class="watermark"`)
+
+	assert.Error(t, NewRenderer("").Render(&page, &content.Document{}))
+}
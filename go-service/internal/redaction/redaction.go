@@ -0,0 +1,217 @@
+package redaction
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"student-report-service/internal/models"
+)
+
+// Profile names a redaction profile applied to student data before rendering
+type Profile string
+
+const (
+	// ProfileFull renders every field unchanged (administrative use)
+	ProfileFull Profile = "full"
+	// ProfileParentFacing masks contact details of third parties
+	ProfileParentFacing Profile = "parent-facing"
+	// ProfilePublicNotice withholds everything except identity and class placement
+	ProfilePublicNotice Profile = "public-notice"
+)
+
+// Withheld is the placeholder printed in place of withheld values
+const Withheld = "Withheld"
+
+// Action describes what happens to a field under a profile
+type Action int
+
+const (
+	// Keep leaves the field unchanged
+	Keep Action = iota
+	// Mask partially hides the field, keeping a recognisable fragment
+	Mask
+	// Withhold replaces the field entirely with the Withheld placeholder
+	Withhold
+)
+
+// Field names used in profile rules, matching the JSON names of models.Student
+const (
+	FieldEmail            = "email"
+	FieldPhone            = "phone"
+	FieldDOB              = "dob"
+	FieldFatherPhone      = "fatherPhone"
+	FieldMotherPhone      = "motherPhone"
+	FieldGuardianPhone    = "guardianPhone"
+	FieldCurrentAddress   = "currentAddress"
+	FieldPermanentAddress = "permanentAddress"
+	FieldPhoto            = "photo"
+)
+
+var (
+	// ErrUnknownProfile is returned when a profile name is not registered
+	ErrUnknownProfile = errors.New("unknown redaction profile")
+	// ErrProfileNotAllowed is returned when the caller role may not use a profile
+	ErrProfileNotAllowed = errors.New("redaction profile not allowed for role")
+)
+
+// profiles maps each profile to its per-field rules; fields not listed are kept
+var profiles = map[Profile]map[string]Action{
+	ProfileFull: {},
+	ProfileParentFacing: {
+		FieldFatherPhone:      Mask,
+		FieldMotherPhone:      Mask,
+		FieldGuardianPhone:    Mask,
+		FieldPermanentAddress: Withhold,
+	},
+	ProfilePublicNotice: {
+		FieldEmail:            Withhold,
+		FieldPhone:            Withhold,
+		FieldDOB:              Withhold,
+		FieldFatherPhone:      Withhold,
+		FieldMotherPhone:      Withhold,
+		FieldGuardianPhone:    Withhold,
+		FieldCurrentAddress:   Withhold,
+		FieldPermanentAddress: Withhold,
+		FieldPhoto:            Withhold,
+	},
+}
+
+// roleProfiles lists the profiles each caller role may request, most permissive first
+var roleProfiles = map[string][]Profile{
+	"admin":   {ProfileFull, ProfileParentFacing, ProfilePublicNotice},
+	"teacher": {ProfileParentFacing, ProfilePublicNotice},
+	"parent":  {ProfileParentFacing, ProfilePublicNotice},
+	"student": {ProfilePublicNotice},
+	"public":  {ProfilePublicNotice},
+}
+
+// Resolve picks the profile for a caller role. An empty requested profile
+// resolves to the most permissive profile the role is allowed to use.
+func Resolve(role, requested string) (Profile, error) {
+	allowed, ok := roleProfiles[strings.ToLower(role)]
+	if !ok {
+		allowed = roleProfiles["public"]
+	}
+
+	if requested == "" {
+		return allowed[0], nil
+	}
+
+	profile := Profile(strings.ToLower(requested))
+	if _, ok := profiles[profile]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownProfile, requested)
+	}
+
+	for _, p := range allowed {
+		if p == profile {
+			return profile, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %s cannot use %s", ErrProfileNotAllowed, role, profile)
+}
+
+// ApplyStudent returns a redacted copy of the student; the input is never modified
+func ApplyStudent(profile Profile, student *models.Student) *models.Student {
+	if student == nil {
+		return nil
+	}
+
+	rules := profiles[profile]
+	redacted := *student
+
+	redacted.Email = redactValue(rules[FieldEmail], student.Email, maskEmail)
+	redacted.Phone = redactPtr(rules[FieldPhone], student.Phone, maskPhone)
+	redacted.DOB = redactPtr(rules[FieldDOB], student.DOB, maskDate)
+	redacted.FatherPhone = redactPtr(rules[FieldFatherPhone], student.FatherPhone, maskPhone)
+	redacted.MotherPhone = redactPtr(rules[FieldMotherPhone], student.MotherPhone, maskPhone)
+	redacted.GuardianPhone = redactPtr(rules[FieldGuardianPhone], student.GuardianPhone, maskPhone)
+	redacted.CurrentAddress = redactPtr(rules[FieldCurrentAddress], student.CurrentAddress, maskAddress)
+	redacted.PermanentAddress = redactPtr(rules[FieldPermanentAddress], student.PermanentAddress, maskAddress)
+	if !ShowsPhoto(profile) {
+		redacted.Photo = nil
+	}
+
+	return &redacted
+}
+
+// ShowsPhoto reports whether the profile keeps student photos. A photo cannot
+// be partially hidden, so masking withholds it too
+func ShowsPhoto(profile Profile) bool {
+	return profiles[profile][FieldPhoto] == Keep
+}
+
+// ApplyListItems returns redacted copies of the list items
+func ApplyListItems(profile Profile, items []models.StudentListItem) []models.StudentListItem {
+	if items == nil {
+		return nil
+	}
+
+	rules := profiles[profile]
+	redacted := make([]models.StudentListItem, len(items))
+	for i, item := range items {
+		item.Email = redactValue(rules[FieldEmail], item.Email, maskEmail)
+		redacted[i] = item
+	}
+
+	return redacted
+}
+
+func redactValue(action Action, value string, mask func(string) string) string {
+	if value == "" {
+		return value
+	}
+
+	switch action {
+	case Mask:
+		return mask(value)
+	case Withhold:
+		return Withheld
+	default:
+		return value
+	}
+}
+
+func redactPtr(action Action, ptr *string, mask func(string) string) *string {
+	if ptr == nil || action == Keep {
+		return ptr
+	}
+
+	value := redactValue(action, *ptr, mask)
+	return &value
+}
+
+// maskPhone keeps the last four digits
+func maskPhone(phone string) string {
+	if len(phone) <= 4 {
+		return strings.Repeat("*", len(phone))
+	}
+	return strings.Repeat("*", len(phone)-4) + phone[len(phone)-4:]
+}
+
+// maskEmail keeps the first character of the local part and the domain
+func maskEmail(email string) string {
+	at := strings.Index(email, "@")
+	if at <= 0 {
+		return strings.Repeat("*", len(email))
+	}
+	return email[:1] + strings.Repeat("*", at-1) + email[at:]
+}
+
+// maskDate keeps only the year of an ISO-formatted date
+func maskDate(date string) string {
+	if len(date) >= 4 {
+		return date[:4] + "-**-**"
+	}
+	return Withheld
+}
+
+// maskAddress keeps only the last comma-separated component (city or region)
+func maskAddress(address string) string {
+	parts := strings.Split(address, ",")
+	if len(parts) < 2 {
+		return Withheld
+	}
+	return "***, " + strings.TrimSpace(parts[len(parts)-1])
+}
@@ -0,0 +1,144 @@
+package redaction
+
+import (
+	"errors"
+	"testing"
+
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestStudent() *models.Student {
+	return &models.Student{
+		ID:               1,
+		Name:             "John Doe",
+		Email:            "john.doe@example.com",
+		Phone:            stringPtr("9800000001"),
+		DOB:              stringPtr("2010-04-12"),
+		Class:            stringPtr("Grade 5"),
+		FatherName:       stringPtr("Richard Doe"),
+		FatherPhone:      stringPtr("9800000002"),
+		MotherPhone:      stringPtr("9800000003"),
+		GuardianPhone:    stringPtr("9800000004"),
+		CurrentAddress:   stringPtr("12 Elm Street, Springfield"),
+		PermanentAddress: stringPtr("44 Oak Avenue, Shelbyville"),
+		Photo:            []byte{0xff, 0xd8},
+	}
+}
+
+func TestApplyStudent(t *testing.T) {
+	tests := []struct {
+		name     string
+		profile  Profile
+		expected func(s *models.Student)
+	}{
+		{
+			name:    "Full profile keeps every field",
+			profile: ProfileFull,
+			expected: func(s *models.Student) {
+				assert.Equal(t, "john.doe@example.com", s.Email)
+				assert.Equal(t, "9800000001", *s.Phone)
+				assert.Equal(t, "2010-04-12", *s.DOB)
+				assert.Equal(t, "9800000002", *s.FatherPhone)
+				assert.Equal(t, "12 Elm Street, Springfield", *s.CurrentAddress)
+				assert.Equal(t, "44 Oak Avenue, Shelbyville", *s.PermanentAddress)
+				assert.NotEmpty(t, s.Photo)
+			},
+		},
+		{
+			name:    "Parent-facing profile masks guardian phones",
+			profile: ProfileParentFacing,
+			expected: func(s *models.Student) {
+				assert.Equal(t, "john.doe@example.com", s.Email)
+				assert.Equal(t, "9800000001", *s.Phone)
+				assert.Equal(t, "2010-04-12", *s.DOB)
+				assert.Equal(t, "******0002", *s.FatherPhone)
+				assert.Equal(t, "******0003", *s.MotherPhone)
+				assert.Equal(t, "******0004", *s.GuardianPhone)
+				assert.Equal(t, "12 Elm Street, Springfield", *s.CurrentAddress)
+				assert.Equal(t, Withheld, *s.PermanentAddress)
+				assert.NotEmpty(t, s.Photo)
+			},
+		},
+		{
+			name:    "Public-notice profile withholds contact details",
+			profile: ProfilePublicNotice,
+			expected: func(s *models.Student) {
+				assert.Equal(t, "John Doe", s.Name)
+				assert.Equal(t, "Grade 5", *s.Class)
+				assert.Equal(t, "Richard Doe", *s.FatherName)
+				assert.Equal(t, Withheld, s.Email)
+				assert.Equal(t, Withheld, *s.Phone)
+				assert.Equal(t, Withheld, *s.DOB)
+				assert.Equal(t, Withheld, *s.FatherPhone)
+				assert.Equal(t, Withheld, *s.MotherPhone)
+				assert.Equal(t, Withheld, *s.GuardianPhone)
+				assert.Equal(t, Withheld, *s.CurrentAddress)
+				assert.Equal(t, Withheld, *s.PermanentAddress)
+				assert.Nil(t, s.Photo)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := newTestStudent()
+			result := ApplyStudent(tt.profile, original)
+			tt.expected(result)
+
+			// The source record must never be modified
+			assert.Equal(t, newTestStudent(), original)
+		})
+	}
+}
+
+func TestApplyStudent_NilFieldsStayNil(t *testing.T) {
+	result := ApplyStudent(ProfilePublicNotice, &models.Student{ID: 2, Name: "Jane"})
+	assert.Nil(t, result.Phone)
+	assert.Nil(t, result.DOB)
+	assert.Empty(t, result.Email)
+	assert.Nil(t, ApplyStudent(ProfileFull, nil))
+}
+
+func TestApplyListItems(t *testing.T) {
+	items := []models.StudentListItem{{ID: 1, Name: "John Doe", Email: "john.doe@example.com"}}
+
+	assert.Equal(t, "john.doe@example.com", ApplyListItems(ProfileFull, items)[0].Email)
+	assert.Equal(t, "john.doe@example.com", ApplyListItems(ProfileParentFacing, items)[0].Email)
+	assert.Equal(t, Withheld, ApplyListItems(ProfilePublicNotice, items)[0].Email)
+	assert.Equal(t, "john.doe@example.com", items[0].Email)
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		role        string
+		requested   string
+		expected    Profile
+		expectedErr error
+	}{
+		{name: "Admin default", role: "admin", expected: ProfileFull},
+		{name: "Teacher default", role: "teacher", expected: ProfileParentFacing},
+		{name: "Unknown role falls back to public", role: "visitor", expected: ProfilePublicNotice},
+		{name: "Admin may downgrade", role: "admin", requested: "public-notice", expected: ProfilePublicNotice},
+		{name: "Parent may not request full", role: "parent", requested: "full", expectedErr: ErrProfileNotAllowed},
+		{name: "Unknown profile", role: "admin", requested: "secret", expectedErr: ErrUnknownProfile},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := Resolve(tt.role, tt.requested)
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, profile)
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,58 @@
+package retention
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// metadataSuffix is appended to a report path to name its metadata file
+const metadataSuffix = ".meta.json"
+
+// Metadata is stored next to each generated report so downloads and
+// cleanup can be decided without reading the audit log
+type Metadata struct {
+	ReportID    string    `json:"report_id,omitempty"`
+	Profile     string    `json:"profile,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// MetadataPath returns the path of the metadata file for a report
+func MetadataPath(reportPath string) string {
+	return reportPath + metadataSuffix
+}
+
+// WriteMetadata writes the report's metadata to a temporary file and renames
+// it into place
+func WriteMetadata(reportPath string, metadata Metadata) error {
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report metadata: %w", err)
+	}
+
+	path := MetadataPath(reportPath)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0640); err != nil {
+		return fmt.Errorf("failed to write report metadata: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace report metadata: %w", err)
+	}
+	return nil
+}
+
+// ReadMetadata reads the metadata written for a report
+func ReadMetadata(reportPath string) (*Metadata, error) {
+	content, err := os.ReadFile(MetadataPath(reportPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report metadata: %w", err)
+	}
+
+	var metadata Metadata
+	if err := json.Unmarshal(content, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode report metadata: %w", err)
+	}
+	return &metadata, nil
+}
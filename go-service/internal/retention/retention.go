@@ -0,0 +1,237 @@
+package retention
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Reasons recorded for each cleanup entry
+const (
+	ReasonExpired = "expired"
+	ReasonQuota   = "quota"
+)
+
+// Policy describes how long reports are kept and how much disk they may use
+type Policy struct {
+	// MaxAge applies to report types without a specific rule
+	MaxAge time.Duration
+	// Rules overrides MaxAge per report type (e.g. "student" => 720h)
+	Rules map[string]time.Duration
+	// TagRules overrides the report type rules for files carrying a tag in
+	// their metadata. A file with several tag rules is kept for the longest;
+	// 0 keeps it forever
+	TagRules map[string]time.Duration
+	// Quota is the maximum total size in bytes; 0 disables the quota
+	Quota int64
+}
+
+// Entry describes a single file considered for deletion
+type Entry struct {
+	Path       string    `json:"path"`
+	ReportType string    `json:"report_type"`
+	Tags       []string  `json:"tags,omitempty"`
+	Size       int64     `json:"size"`
+	ModTime    time.Time `json:"mod_time"`
+	Reason     string    `json:"reason"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Summary reports the outcome of a cleanup run
+type Summary struct {
+	DryRun     bool      `json:"dry_run"`
+	Scanned    int       `json:"scanned"`
+	Deleted    []Entry   `json:"deleted"`
+	Failed     []Entry   `json:"failed"`
+	FreedBytes int64     `json:"freed_bytes"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// Cleaner applies a retention policy to a report directory
+type Cleaner struct {
+	dir    string
+	policy Policy
+	now    func() time.Time
+	remove func(string) error
+}
+
+// NewCleaner creates a cleaner for dir
+func NewCleaner(dir string, policy Policy) *Cleaner {
+	return &Cleaner{
+		dir:    dir,
+		policy: policy,
+		now:    time.Now,
+		remove: os.Remove,
+	}
+}
+
+// Run deletes expired reports and, if a quota is set, evicts the oldest
+// remaining reports until the directory fits. Errors on individual files are
+// collected in the summary instead of aborting the run. In dry-run mode
+// nothing is deleted and the summary lists what would have been.
+func (c *Cleaner) Run(dryRun bool) (*Summary, error) {
+	summary := &Summary{
+		DryRun:    dryRun,
+		Deleted:   []Entry{},
+		Failed:    []Entry{},
+		StartedAt: c.now(),
+	}
+
+	files, err := c.scan()
+	if err != nil {
+		return nil, err
+	}
+	summary.Scanned = len(files)
+
+	// Oldest first, so quota eviction can walk the slice in order
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime.Before(files[j].ModTime)
+	})
+
+	var kept []Entry
+	var keptBytes int64
+	for _, file := range files {
+		if c.expired(file) {
+			file.Reason = ReasonExpired
+			if !c.delete(summary, file, dryRun) {
+				// Still on disk, so it counts against the quota
+				keptBytes += file.Size
+			}
+			continue
+		}
+		kept = append(kept, file)
+		keptBytes += file.Size
+	}
+
+	if c.policy.Quota > 0 {
+		for _, file := range kept {
+			if keptBytes <= c.policy.Quota {
+				break
+			}
+			file.Reason = ReasonQuota
+			if c.delete(summary, file, dryRun) {
+				keptBytes -= file.Size
+			}
+		}
+	}
+
+	summary.FinishedAt = c.now()
+	return summary, nil
+}
+
+// MaxAgeFor returns the retention period for a report type
+func (c *Cleaner) MaxAgeFor(reportType string) time.Duration {
+	if maxAge, ok := c.policy.Rules[reportType]; ok {
+		return maxAge
+	}
+	return c.policy.MaxAge
+}
+
+// maxAge returns the retention period for a file: the longest of its tag
+// rules, or the rule for its report type when no tag has one
+func (c *Cleaner) maxAge(file Entry) time.Duration {
+	var longest time.Duration
+	tagged := false
+	for _, tag := range file.Tags {
+		maxAge, ok := c.policy.TagRules[tag]
+		if !ok {
+			continue
+		}
+		if maxAge == 0 {
+			return 0
+		}
+		if maxAge > longest {
+			longest = maxAge
+		}
+		tagged = true
+	}
+
+	if tagged {
+		return longest
+	}
+	return c.MaxAgeFor(file.ReportType)
+}
+
+func (c *Cleaner) expired(file Entry) bool {
+	maxAge := c.maxAge(file)
+	return maxAge > 0 && c.now().Sub(file.ModTime) > maxAge
+}
+
+// delete removes the file and its metadata (unless dry-run) and records the outcome
+func (c *Cleaner) delete(summary *Summary, file Entry, dryRun bool) bool {
+	if !dryRun {
+		if err := c.remove(file.Path); err != nil {
+			file.Error = err.Error()
+			summary.Failed = append(summary.Failed, file)
+			return false
+		}
+		// The metadata is of no use without its report
+		if err := c.remove(MetadataPath(file.Path)); err != nil && !os.IsNotExist(err) {
+			file.Error = fmt.Sprintf("report deleted but its metadata was not: %v", err)
+		}
+	}
+
+	summary.Deleted = append(summary.Deleted, file)
+	summary.FreedBytes += file.Size
+	return true
+}
+
+func (c *Cleaner) scan() ([]Entry, error) {
+	var files []Entry
+
+	err := filepath.Walk(c.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Unreadable entries are skipped rather than aborting the scan
+			if path == c.dir {
+				return err
+			}
+			return nil
+		}
+
+		if !info.IsDir() && IsReportFile(info.Name()) {
+			entry := Entry{
+				Path:       path,
+				ReportType: ReportType(info.Name()),
+				Size:       info.Size(),
+				ModTime:    info.ModTime(),
+			}
+			// Reports without readable metadata fall back to the type rules
+			if metadata, err := ReadMetadata(path); err == nil {
+				entry.Tags = metadata.Tags
+			}
+			files = append(files, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan report directory: %w", err)
+	}
+
+	return files, nil
+}
+
+// reportExtensions lists the file extensions of generated reports
+var reportExtensions = []string{".pdf", ".docx"}
+
+// IsReportFile reports whether the filename has a generated report extension
+func IsReportFile(filename string) bool {
+	for _, ext := range reportExtensions {
+		if strings.HasSuffix(filename, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// ReportType derives the report type from a generated filename,
+// e.g. "student_report_1_John_20240115_103000.pdf" => "student"
+func ReportType(filename string) string {
+	if idx := strings.Index(filename, "_report_"); idx > 0 {
+		return filename[:idx]
+	}
+	return "unknown"
+}
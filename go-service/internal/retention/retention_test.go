@@ -0,0 +1,184 @@
+package retention
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeReport(t *testing.T, dir, name string, size int, age time.Duration) string {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0644))
+	modTime := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	return path
+}
+
+func TestCleaner_Run(t *testing.T) {
+	tests := []struct {
+		name            string
+		policy          Policy
+		tags            map[string][]string
+		dryRun          bool
+		expectedDeleted []string
+		expectedFreed   int64
+	}{
+		{
+			name:            "Default max age",
+			policy:          Policy{MaxAge: 24 * time.Hour},
+			expectedDeleted: []string{"student_report_1_old.pdf", "leave_report_2_old.pdf"},
+			expectedFreed:   300,
+		},
+		{
+			name:            "Per-type rule overrides default",
+			policy:          Policy{MaxAge: 24 * time.Hour, Rules: map[string]time.Duration{"leave": 30 * 24 * time.Hour}},
+			expectedDeleted: []string{"student_report_1_old.pdf"},
+			expectedFreed:   100,
+		},
+		{
+			name: "Tag rule keeps tagged files",
+			policy: Policy{
+				MaxAge:   24 * time.Hour,
+				TagRules: map[string]time.Duration{"legal-hold": 0, "term-1": time.Hour},
+			},
+			tags:            map[string][]string{"student_report_1_old.pdf": {"term-1", "legal-hold"}},
+			expectedDeleted: []string{"leave_report_2_old.pdf"},
+			expectedFreed:   200,
+		},
+		{
+			name: "Tag rule overrides type rule",
+			policy: Policy{
+				MaxAge:   24 * time.Hour,
+				Rules:    map[string]time.Duration{"leave": 30 * 24 * time.Hour},
+				TagRules: map[string]time.Duration{"term-1": 36 * time.Hour, "draft": time.Hour},
+			},
+			tags:            map[string][]string{"leave_report_2_old.pdf": {"term-1"}, "student_report_3_new.pdf": {"final"}},
+			expectedDeleted: []string{"student_report_1_old.pdf", "leave_report_2_old.pdf"},
+			expectedFreed:   300,
+		},
+		{
+			name:            "Quota evicts oldest first",
+			policy:          Policy{Quota: 250},
+			expectedDeleted: []string{"student_report_1_old.pdf", "leave_report_2_old.pdf"},
+			expectedFreed:   300,
+		},
+		{
+			name:            "Dry run reports without deleting",
+			policy:          Policy{MaxAge: 24 * time.Hour},
+			dryRun:          true,
+			expectedDeleted: []string{"student_report_1_old.pdf", "leave_report_2_old.pdf"},
+			expectedFreed:   300,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeReport(t, dir, "student_report_1_old.pdf", 100, 72*time.Hour)
+			writeReport(t, dir, "leave_report_2_old.pdf", 200, 48*time.Hour)
+			writeReport(t, dir, "student_report_3_new.pdf", 150, time.Hour)
+			writeReport(t, dir, "notes.txt", 50, 72*time.Hour)
+			for name, tags := range tt.tags {
+				require.NoError(t, WriteMetadata(filepath.Join(dir, name), Metadata{Tags: tags}))
+			}
+
+			summary, err := NewCleaner(dir, tt.policy).Run(tt.dryRun)
+			require.NoError(t, err)
+
+			var deleted []string
+			for _, entry := range summary.Deleted {
+				deleted = append(deleted, filepath.Base(entry.Path))
+			}
+			assert.Equal(t, tt.expectedDeleted, deleted)
+			assert.Equal(t, tt.expectedFreed, summary.FreedBytes)
+			assert.Equal(t, 3, summary.Scanned)
+			assert.Empty(t, summary.Failed)
+
+			for _, entry := range summary.Deleted {
+				_, statErr := os.Stat(entry.Path)
+				assert.Equal(t, tt.dryRun, statErr == nil)
+				if _, tagged := tt.tags[filepath.Base(entry.Path)]; tagged {
+					_, statErr = os.Stat(MetadataPath(entry.Path))
+					assert.Equal(t, tt.dryRun, statErr == nil, "metadata is deleted with the report")
+				}
+			}
+		})
+	}
+}
+
+func TestCleaner_ContinuesOnError(t *testing.T) {
+	dir := t.TempDir()
+	locked := writeReport(t, dir, "student_report_1_a.pdf", 100, 72*time.Hour)
+	writeReport(t, dir, "student_report_2_b.pdf", 100, 72*time.Hour)
+
+	cleaner := NewCleaner(dir, Policy{MaxAge: time.Hour})
+	cleaner.remove = func(path string) error {
+		if path == locked {
+			return errors.New("permission denied")
+		}
+		return os.Remove(path)
+	}
+
+	summary, err := cleaner.Run(false)
+	require.NoError(t, err)
+	assert.Len(t, summary.Deleted, 1)
+	assert.Len(t, summary.Failed, 1)
+	assert.Equal(t, "permission denied", summary.Failed[0].Error)
+	assert.Equal(t, int64(100), summary.FreedBytes)
+}
+
+func TestCleaner_QuotaCountsUndeletedFiles(t *testing.T) {
+	dir := t.TempDir()
+	locked := writeReport(t, dir, "student_report_1_old.pdf", 200, 72*time.Hour)
+	writeReport(t, dir, "student_report_2_mid.pdf", 100, 2*time.Hour)
+	writeReport(t, dir, "student_report_3_new.pdf", 100, time.Hour)
+
+	cleaner := NewCleaner(dir, Policy{MaxAge: 24 * time.Hour, Quota: 250})
+	cleaner.remove = func(path string) error {
+		if path == locked {
+			return errors.New("permission denied")
+		}
+		return os.Remove(path)
+	}
+
+	// The expired file could not be removed, so both newer files are evicted
+	summary, err := cleaner.Run(false)
+	require.NoError(t, err)
+	require.Len(t, summary.Failed, 1)
+	require.Len(t, summary.Deleted, 2)
+	assert.Equal(t, ReasonQuota, summary.Deleted[0].Reason)
+	assert.Equal(t, ReasonQuota, summary.Deleted[1].Reason)
+}
+
+func TestMetadata_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "student_report_1_a.pdf")
+	metadata := Metadata{ReportID: "RPT-1", Profile: "parent-facing", Tags: []string{"term-1"}, GeneratedAt: time.Now().UTC()}
+
+	require.NoError(t, WriteMetadata(path, metadata))
+	read, err := ReadMetadata(path)
+	require.NoError(t, err)
+	assert.Equal(t, metadata.ReportID, read.ReportID)
+	assert.Equal(t, metadata.Profile, read.Profile)
+	assert.Equal(t, metadata.Tags, read.Tags)
+	assert.False(t, IsReportFile(filepath.Base(MetadataPath(path))))
+
+	_, err = ReadMetadata(filepath.Join(t.TempDir(), "missing.pdf"))
+	assert.Error(t, err)
+}
+
+func TestReportType(t *testing.T) {
+	assert.Equal(t, "student", ReportType("student_report_1_John_Doe_20240115_103000.pdf"))
+	assert.Equal(t, "leave", ReportType("leave_report_7_20240115_103000.pdf"))
+	assert.Equal(t, "unknown", ReportType("manual.pdf"))
+}
+
+func TestIsReportFile(t *testing.T) {
+	assert.True(t, IsReportFile("student_report_1_John_Doe_20240115_103000.pdf"))
+	assert.True(t, IsReportFile("student_report_1_John_Doe_20240115_103000.docx"))
+	assert.False(t, IsReportFile("notes.txt"))
+}
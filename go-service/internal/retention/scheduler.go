@@ -0,0 +1,77 @@
+package retention
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Scheduler runs a cleanup function on a fixed interval in the background
+type Scheduler struct {
+	interval time.Duration
+	run      func() (*Summary, error)
+	logger   *logrus.Logger
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// NewScheduler creates a scheduler; call Start to begin running
+func NewScheduler(interval time.Duration, run func() (*Summary, error), logger *logrus.Logger) *Scheduler {
+	return &Scheduler{
+		interval: interval,
+		run:      run,
+		logger:   logger,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start launches the background loop
+func (s *Scheduler) Start() {
+	go s.loop()
+}
+
+// Stop stops the loop and waits for an in-progress run to finish
+func (s *Scheduler) Stop() {
+	s.once.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+}
+
+func (s *Scheduler) loop() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	s.logger.WithField("interval", s.interval.String()).Info("Report cleanup scheduler started")
+
+	for {
+		select {
+		case <-ticker.C:
+			s.runOnce()
+		case <-s.stop:
+			s.logger.Info("Report cleanup scheduler stopped")
+			return
+		}
+	}
+}
+
+func (s *Scheduler) runOnce() {
+	summary, err := s.run()
+	if err != nil {
+		s.logger.WithError(err).Error("Scheduled report cleanup failed")
+		return
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"scanned":     summary.Scanned,
+		"deleted":     len(summary.Deleted),
+		"failed":      len(summary.Failed),
+		"freed_bytes": summary.FreedBytes,
+	}).Info("Scheduled report cleanup completed")
+}
@@ -0,0 +1,185 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CronExpr is a parsed five-field cron expression (minute hour day-of-month month day-of-week)
+type CronExpr struct {
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+
+	// Standard cron semantics: when both day fields are restricted a time
+	// matches if either of them does
+	daysRestricted     bool
+	weekdaysRestricted bool
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var weekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// ParseCron parses a standard cron expression or one of the @ descriptors
+func ParseCron(expr string) (*CronExpr, error) {
+	expr = strings.TrimSpace(expr)
+	if descriptor, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	var err error
+	c := &CronExpr{}
+	if c.minutes, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron minute field: %w", err)
+	}
+	if c.hours, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron hour field: %w", err)
+	}
+	if c.days, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron day-of-month field: %w", err)
+	}
+	if c.months, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid cron month field: %w", err)
+	}
+	if c.weekdays, err = parseField(fields[4], 0, 7, weekdayNames); err != nil {
+		return nil, fmt.Errorf("invalid cron day-of-week field: %w", err)
+	}
+
+	// 7 is an alias for Sunday
+	if c.weekdays&(1<<7) != 0 {
+		c.weekdays |= 1
+	}
+
+	// As in Vixie cron, a field starting with "*" (such as "*/2") is unrestricted
+	c.daysRestricted = !strings.HasPrefix(fields[2], "*")
+	c.weekdaysRestricted = !strings.HasPrefix(fields[4], "*")
+
+	return c, nil
+}
+
+// Next returns the first matching time strictly after t, truncated to the minute.
+// The zero time is returned if nothing matches within five years.
+func (c *CronExpr) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+func (c *CronExpr) matchesDay(t time.Time) bool {
+	dayMatch := c.days&(1<<uint(t.Day())) != 0
+	weekdayMatch := c.weekdays&(1<<uint(t.Weekday())) != 0
+
+	if c.daysRestricted && c.weekdaysRestricted {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
+
+// parseField parses a comma-separated list of values, ranges and steps into a bit set
+func parseField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		var start, end int
+		switch {
+		case rangePart == "*":
+			start, end = min, max
+		case strings.Contains(rangePart, "-"):
+			low, high, _ := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseValue(low, names); err != nil {
+				return 0, err
+			}
+			if end, err = parseValue(high, names); err != nil {
+				return 0, err
+			}
+		default:
+			value, err := parseValue(rangePart, names)
+			if err != nil {
+				return 0, err
+			}
+			start, end = value, value
+			if hasStep {
+				end = max
+			}
+		}
+
+		if start < min || end > max || start > end {
+			return 0, fmt.Errorf("value out of range %d-%d in %q", min, max, part)
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+func parseValue(value string, names map[string]int) (int, error) {
+	if named, ok := names[strings.ToLower(value)]; ok {
+		return named, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	return number, nil
+}
@@ -0,0 +1,61 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronExpr_Next(t *testing.T) {
+	// Wednesday
+	base := time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected time.Time
+	}{
+		{name: "Every minute", expr: "* * * * *", expected: time.Date(2024, 1, 10, 10, 31, 0, 0, time.UTC)},
+		{name: "Every 15 minutes", expr: "*/15 * * * *", expected: time.Date(2024, 1, 10, 10, 45, 0, 0, time.UTC)},
+		{name: "Daily at 08:00", expr: "0 8 * * *", expected: time.Date(2024, 1, 11, 8, 0, 0, 0, time.UTC)},
+		{name: "Monthly descriptor", expr: "@monthly", expected: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "First of month at 07:30", expr: "30 7 1 * *", expected: time.Date(2024, 2, 1, 7, 30, 0, 0, time.UTC)},
+		{name: "Weekdays by name", expr: "0 9 * * mon-fri", expected: time.Date(2024, 1, 11, 9, 0, 0, 0, time.UTC)},
+		{name: "Sunday as 7", expr: "0 0 * * 7", expected: time.Date(2024, 1, 14, 0, 0, 0, 0, time.UTC)},
+		{name: "Month list", expr: "0 0 1 mar,sep *", expected: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "Day of month or weekday", expr: "0 0 15 * fri", expected: time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC)},
+		{name: "Stepped day of month and weekday", expr: "0 0 */2 * mon", expected: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{name: "Leap day", expr: "0 0 29 2 *", expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expr, err := ParseCron(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, expr.Next(base))
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * * funday",
+	}
+
+	for _, expr := range invalid {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseCron(expr)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package schedule
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Executor generates and delivers the reports for a schedule
+type Executor interface {
+	// Validate rejects schedules the executor cannot run (unknown template, delivery type, ...)
+	Validate(s *Schedule) error
+	// Authorize rejects a caller role that could not have defined the schedule
+	Authorize(s Schedule, role string) error
+	// Execute performs one run of the schedule
+	Execute(s Schedule) Result
+}
+
+// Manager owns the schedule definitions and runs them when they are due
+type Manager struct {
+	store        Store
+	executor     Executor
+	logger       *logrus.Logger
+	pollInterval time.Duration
+	now          func() time.Time
+
+	mutex   sync.Mutex
+	running map[string]bool
+	wg      sync.WaitGroup
+	stop    chan struct{}
+	done    chan struct{}
+	once    sync.Once
+}
+
+// NewManager creates a schedule manager; call Start to begin executing schedules
+func NewManager(store Store, executor Executor, logger *logrus.Logger, pollInterval time.Duration) *Manager {
+	if pollInterval <= 0 {
+		pollInterval = 30 * time.Second
+	}
+
+	return &Manager{
+		store:        store,
+		executor:     executor,
+		logger:       logger,
+		pollInterval: pollInterval,
+		now:          time.Now,
+		running:      make(map[string]bool),
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+// Create validates and stores a new schedule
+func (m *Manager) Create(s Schedule) (*Schedule, error) {
+	if err := m.validate(&s); err != nil {
+		return nil, err
+	}
+
+	now := m.now()
+	s.ID = newID("sch")
+	s.CreatedAt = now
+	s.UpdatedAt = now
+	s.LastRunAt = time.Time{}
+	s.LastOutcome = ""
+	s.NextRunAt = m.nextRun(s, now)
+
+	if err := m.store.SaveSchedule(s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Update replaces the definition of an existing schedule, keeping its history
+func (m *Manager) Update(id string, s Schedule) (*Schedule, error) {
+	existing, err := m.store.GetSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.validate(&s); err != nil {
+		return nil, err
+	}
+
+	now := m.now()
+	s.ID = existing.ID
+	s.CreatedBy = existing.CreatedBy
+	s.CreatedAt = existing.CreatedAt
+	s.LastRunAt = existing.LastRunAt
+	s.LastOutcome = existing.LastOutcome
+	s.UpdatedAt = now
+	s.NextRunAt = m.nextRun(s, now)
+
+	if err := m.store.SaveSchedule(s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Delete removes a schedule and its history. The caller role is checked as
+// when the schedule was defined.
+func (m *Manager) Delete(id, role string) error {
+	s, err := m.store.GetSchedule(id)
+	if err != nil {
+		return err
+	}
+	if err := m.executor.Authorize(*s, role); err != nil {
+		return err
+	}
+	return m.store.DeleteSchedule(id)
+}
+
+// Get returns a schedule by ID
+func (m *Manager) Get(id string) (*Schedule, error) {
+	return m.store.GetSchedule(id)
+}
+
+// List returns all schedules
+func (m *Manager) List() ([]Schedule, error) {
+	return m.store.ListSchedules()
+}
+
+// Runs returns the execution history of a schedule, newest first
+func (m *Manager) Runs(id string, limit int) ([]Run, error) {
+	if _, err := m.store.GetSchedule(id); err != nil {
+		return nil, err
+	}
+	return m.store.ListRuns(id, limit)
+}
+
+// Trigger starts a run of the schedule in the background and returns the run
+// ID; the outcome is added to the schedule's run history. The caller role is
+// checked as when the schedule was defined.
+func (m *Manager) Trigger(id, role string) (string, error) {
+	s, err := m.store.GetSchedule(id)
+	if err != nil {
+		return "", err
+	}
+	if err := m.executor.Authorize(*s, role); err != nil {
+		return "", err
+	}
+
+	runID, started := m.start(*s, false, true)
+	if !started {
+		return "", fmt.Errorf("%w: %s", ErrRunning, id)
+	}
+	return runID, nil
+}
+
+// Start handles runs missed during downtime and launches the polling loop
+func (m *Manager) Start() {
+	m.recoverMissedRuns()
+	go m.loop()
+}
+
+// Stop ends the polling loop and waits for in-flight runs
+func (m *Manager) Stop() {
+	m.once.Do(func() {
+		close(m.stop)
+	})
+	<-m.done
+	m.wg.Wait()
+}
+
+func (m *Manager) loop() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.pollInterval)
+	defer ticker.Stop()
+
+	m.logger.WithField("poll_interval", m.pollInterval.String()).Info("Report schedule runner started")
+
+	for {
+		select {
+		case <-ticker.C:
+			m.runDue()
+		case <-m.stop:
+			m.logger.Info("Report schedule runner stopped")
+			return
+		}
+	}
+}
+
+// runDue starts every enabled schedule whose next run time has passed
+func (m *Manager) runDue() {
+	schedules, err := m.store.ListSchedules()
+	if err != nil {
+		m.logger.WithError(err).Error("Failed to load report schedules")
+		return
+	}
+
+	now := m.now()
+	for _, s := range schedules {
+		if !s.Enabled || s.NextRunAt.IsZero() || s.NextRunAt.After(now) {
+			continue
+		}
+
+		m.start(s, false, false)
+	}
+}
+
+// recoverMissedRuns applies each schedule's missed-run policy to runs that
+// fell due while the service was not running
+func (m *Manager) recoverMissedRuns() {
+	schedules, err := m.store.ListSchedules()
+	if err != nil {
+		m.logger.WithError(err).Error("Failed to load report schedules")
+		return
+	}
+
+	now := m.now()
+	for _, s := range schedules {
+		if !s.Enabled || s.NextRunAt.IsZero() || s.NextRunAt.After(now) {
+			continue
+		}
+
+		logger := m.logger.WithFields(logrus.Fields{
+			"schedule_id": s.ID,
+			"missed_at":   s.NextRunAt,
+			"policy":      s.MissedRuns,
+		})
+
+		if s.MissedRuns == MissedRunOnce {
+			logger.Info("Running catch-up for missed report schedule")
+			m.start(s, true, false)
+			continue
+		}
+
+		logger.Info("Skipping missed report schedule run")
+		s.NextRunAt = m.nextRun(s, now)
+		if err := m.store.SaveSchedule(s); err != nil {
+			logger.WithError(err).Error("Failed to update report schedule")
+		}
+	}
+}
+
+// start performs a run in the background unless the schedule is already
+// running, returning the ID the run will be recorded under
+func (m *Manager) start(s Schedule, catchUp, manual bool) (string, bool) {
+	m.mutex.Lock()
+	if m.running[s.ID] {
+		m.mutex.Unlock()
+		return "", false
+	}
+	m.running[s.ID] = true
+	m.mutex.Unlock()
+
+	runID := newID("run")
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.execute(s, runID, catchUp, manual)
+	}()
+	return runID, true
+}
+
+// execute performs a run of a schedule claimed by start and records it
+func (m *Manager) execute(s Schedule, runID string, catchUp, manual bool) {
+	defer func() {
+		m.mutex.Lock()
+		delete(m.running, s.ID)
+		m.mutex.Unlock()
+	}()
+
+	run := Run{
+		ID:         runID,
+		ScheduleID: s.ID,
+		StartedAt:  m.now(),
+		CatchUp:    catchUp,
+		Manual:     manual,
+		Reports:    []string{},
+	}
+
+	result := m.executor.Execute(s)
+
+	run.FinishedAt = m.now()
+	run.Reports = append(run.Reports, result.Reports...)
+	for _, err := range result.Errors {
+		run.Errors = append(run.Errors, err.Error())
+	}
+
+	switch {
+	case len(result.Errors) == 0:
+		run.Status = RunSucceeded
+	case len(result.Reports) > 0:
+		run.Status = RunPartial
+	default:
+		run.Status = RunFailed
+	}
+
+	logger := m.logger.WithFields(logrus.Fields{
+		"schedule_id": s.ID,
+		"run_id":      run.ID,
+		"status":      run.Status,
+		"reports":     len(run.Reports),
+		"errors":      len(run.Errors),
+	})
+	logger.Info("Report schedule run finished")
+
+	if err := m.store.AppendRun(run); err != nil {
+		logger.WithError(err).Error("Failed to record report schedule run")
+	}
+
+	// Reload in case the schedule was edited or deleted while running
+	current, err := m.store.GetSchedule(s.ID)
+	if err != nil {
+		return
+	}
+	current.LastRunAt = run.FinishedAt
+	current.LastOutcome = run.Status
+	if !manual {
+		current.NextRunAt = m.nextRun(*current, run.FinishedAt)
+	}
+	if err := m.store.SaveSchedule(*current); err != nil {
+		logger.WithError(err).Error("Failed to update report schedule")
+	}
+}
+
+func (m *Manager) validate(s *Schedule) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	return m.executor.Validate(s)
+}
+
+func (m *Manager) nextRun(s Schedule, after time.Time) time.Time {
+	expr, err := ParseCron(s.Cron)
+	if err != nil {
+		return time.Time{}
+	}
+	return expr.Next(after)
+}
+
+func newID(prefix string) string {
+	buf := make([]byte, 6)
+	rand.Read(buf)
+	return prefix + "_" + hex.EncodeToString(buf)
+}
@@ -0,0 +1,203 @@
+package schedule
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExecutor records executions and returns a fixed result
+type fakeExecutor struct {
+	mutex  sync.Mutex
+	calls  []string
+	result Result
+}
+
+func (f *fakeExecutor) Validate(s *Schedule) error {
+	if s.Template != TemplateStudent && s.Template != TemplateDashboard {
+		return errors.New("unknown template")
+	}
+	return nil
+}
+
+func (f *fakeExecutor) Authorize(s Schedule, role string) error {
+	if role == "student" {
+		return errors.New("profile not allowed")
+	}
+	return nil
+}
+
+func (f *fakeExecutor) Execute(s Schedule) Result {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.calls = append(f.calls, s.ID)
+	return f.result
+}
+
+func (f *fakeExecutor) callCount() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return len(f.calls)
+}
+
+func newTestManager(t *testing.T, executor Executor) (*Manager, *FileStore) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "schedules.json"))
+	require.NoError(t, err)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return NewManager(store, executor, logger, time.Hour), store
+}
+
+func validSchedule() Schedule {
+	return Schedule{
+		Name:    "Grade 5 monthly",
+		Cron:    "@monthly",
+		Target:  Target{Filters: map[string]string{"className": "Grade 5"}},
+		Enabled: true,
+	}
+}
+
+func TestManager_CreateValidatesAndPersists(t *testing.T) {
+	manager, store := newTestManager(t, &fakeExecutor{})
+	manager.now = func() time.Time { return time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC) }
+
+	created, err := manager.Create(validSchedule())
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.ID)
+	assert.Equal(t, DeliveryStore, created.Delivery.Type)
+	assert.Equal(t, MissedRunOnce, created.MissedRuns)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), created.NextRunAt)
+
+	// Reloading the store from disk returns the same schedule
+	reloaded, err := NewFileStore(store.path)
+	require.NoError(t, err)
+	found, err := reloaded.GetSchedule(created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.Name, found.Name)
+
+	invalid := validSchedule()
+	invalid.Cron = "not a cron"
+	invalid.Target = Target{}
+	_, err = manager.Create(invalid)
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Len(t, validationErr.Problems, 2)
+
+	// Dashboard schedules need no target
+	dashboard := validSchedule()
+	dashboard.Template = TemplateDashboard
+	dashboard.Target = Target{}
+	_, err = manager.Create(dashboard)
+	assert.NoError(t, err)
+
+	unknownTemplate := validSchedule()
+	unknownTemplate.Template = "yearbook"
+	_, err = manager.Create(unknownTemplate)
+	assert.Error(t, err)
+}
+
+func TestManager_MissedRuns(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        string
+		expectedCalls int
+	}{
+		{name: "Run once catches up", policy: MissedRunOnce, expectedCalls: 1},
+		{name: "Skip drops missed runs", policy: MissedSkip, expectedCalls: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &fakeExecutor{result: Result{Reports: []string{"/reports/a.pdf"}}}
+			manager, store := newTestManager(t, executor)
+
+			// Created well before the current time, so several runs were missed
+			manager.now = func() time.Time { return time.Date(2024, 1, 10, 10, 30, 0, 0, time.UTC) }
+			definition := validSchedule()
+			definition.MissedRuns = tt.policy
+			created, err := manager.Create(definition)
+			require.NoError(t, err)
+
+			manager.now = func() time.Time { return time.Date(2024, 5, 20, 9, 0, 0, 0, time.UTC) }
+			manager.Start()
+			manager.Stop()
+
+			assert.Equal(t, tt.expectedCalls, executor.callCount())
+
+			updated, err := store.GetSchedule(created.ID)
+			require.NoError(t, err)
+			assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), updated.NextRunAt)
+
+			runs, err := manager.Runs(created.ID, 10)
+			require.NoError(t, err)
+			assert.Len(t, runs, tt.expectedCalls)
+			if tt.expectedCalls > 0 {
+				assert.True(t, runs[0].CatchUp)
+				assert.Equal(t, RunSucceeded, runs[0].Status)
+			}
+		})
+	}
+}
+
+func TestManager_TriggerRecordsHistory(t *testing.T) {
+	executor := &fakeExecutor{result: Result{
+		Reports: []string{"/reports/a.pdf"},
+		Errors:  []error{errors.New("student 2: not found")},
+	}}
+	manager, _ := newTestManager(t, executor)
+
+	created, err := manager.Create(validSchedule())
+	require.NoError(t, err)
+
+	runID, err := manager.Trigger(created.ID, "admin")
+	require.NoError(t, err)
+
+	// The run finishes in the background and is recorded before the schedule is updated
+	var found *Schedule
+	require.Eventually(t, func() bool {
+		found, err = manager.Get(created.ID)
+		return err == nil && found.LastOutcome != ""
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, RunPartial, found.LastOutcome)
+	assert.Equal(t, created.NextRunAt, found.NextRunAt)
+
+	runs, err := manager.Runs(created.ID, 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, runID, runs[0].ID)
+	assert.Equal(t, RunPartial, runs[0].Status)
+	assert.True(t, runs[0].Manual)
+	assert.Equal(t, []string{"student 2: not found"}, runs[0].Errors)
+
+	_, err = manager.Trigger("sch_missing", "admin")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestManager_DeleteAndTriggerCheckRole(t *testing.T) {
+	executor := &fakeExecutor{}
+	manager, _ := newTestManager(t, executor)
+
+	created, err := manager.Create(validSchedule())
+	require.NoError(t, err)
+
+	_, err = manager.Trigger(created.ID, "student")
+	assert.Error(t, err)
+	assert.Equal(t, 0, executor.callCount())
+
+	assert.Error(t, manager.Delete(created.ID, "student"))
+	_, err = manager.Get(created.ID)
+	assert.NoError(t, err, "a refused delete keeps the schedule")
+
+	require.NoError(t, manager.Delete(created.ID, "admin"))
+	_, err = manager.Get(created.ID)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
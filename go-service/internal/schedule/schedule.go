@@ -0,0 +1,154 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Delivery types for scheduled reports
+const (
+	// DeliveryStore keeps the generated reports in the report output directory
+	DeliveryStore = "store"
+	// DeliveryEmail sends the generated reports to the delivery recipients
+	DeliveryEmail = "email"
+)
+
+// Report templates a schedule can run
+const (
+	// TemplateStudent generates a student report for every targeted student
+	TemplateStudent = "student"
+	// TemplateDashboard generates the school dashboard summary; it takes no target
+	TemplateDashboard = "dashboard"
+)
+
+// Missed-run policies applied after downtime
+const (
+	// MissedSkip drops runs missed while the service was down
+	MissedSkip = "skip"
+	// MissedRunOnce performs a single catch-up run for any number of missed runs
+	MissedRunOnce = "run_once"
+)
+
+// Run statuses
+const (
+	RunSucceeded = "succeeded"
+	RunPartial   = "partial"
+	RunFailed    = "failed"
+)
+
+// ErrNotFound is returned when a schedule does not exist
+var ErrNotFound = errors.New("schedule not found")
+
+// ErrRunning is returned when a schedule is triggered while a run is in progress
+var ErrRunning = errors.New("schedule is already running")
+
+// Target selects the students a schedule reports on: either explicit IDs or
+// the same filters accepted by GET /api/v1/students
+type Target struct {
+	StudentIDs []int             `json:"student_ids,omitempty"`
+	Filters    map[string]string `json:"filters,omitempty"`
+}
+
+// Delivery describes where generated reports go
+type Delivery struct {
+	Type       string   `json:"type"`
+	Recipients []string `json:"recipients,omitempty"`
+}
+
+// Schedule is a recurring report job
+type Schedule struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Cron     string `json:"cron"`
+	Target   Target `json:"target"`
+	Template string `json:"template"`
+	Profile  string `json:"profile,omitempty"`
+	// Role is the caller role of whoever defined the schedule; the profile
+	// is checked against it whenever the schedule runs
+	Role        string    `json:"role,omitempty"`
+	Delivery    Delivery  `json:"delivery"`
+	Tags        []string  `json:"tags,omitempty"`
+	MissedRuns  string    `json:"missed_runs"`
+	Enabled     bool      `json:"enabled"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	LastRunAt   time.Time `json:"last_run_at,omitempty"`
+	NextRunAt   time.Time `json:"next_run_at,omitempty"`
+	LastOutcome string    `json:"last_outcome,omitempty"`
+}
+
+// Run is one execution of a schedule
+type Run struct {
+	ID         string    `json:"id"`
+	ScheduleID string    `json:"schedule_id"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Status     string    `json:"status"`
+	CatchUp    bool      `json:"catch_up"`
+	Manual     bool      `json:"manual"`
+	Reports    []string  `json:"reports"`
+	Errors     []string  `json:"errors,omitempty"`
+}
+
+// Result is what an executor reports back for a run
+type Result struct {
+	Reports []string
+	Errors  []error
+}
+
+// Validate checks the schedule definition and fills defaults
+func (s *Schedule) Validate() error {
+	var problems []string
+
+	if s.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	if _, err := ParseCron(s.Cron); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if s.Template == "" {
+		s.Template = TemplateStudent
+	}
+	if s.Template == TemplateStudent && len(s.Target.StudentIDs) == 0 && len(s.Target.Filters) == 0 {
+		problems = append(problems, "target must list student_ids or filters")
+	}
+	for _, id := range s.Target.StudentIDs {
+		if id <= 0 {
+			problems = append(problems, fmt.Sprintf("invalid student ID: %d", id))
+		}
+	}
+	for key := range s.Target.Filters {
+		if key != "name" && key != "className" && key != "section" && key != "roll" {
+			problems = append(problems, fmt.Sprintf("invalid target filter: %s", key))
+		}
+	}
+
+	if s.Delivery.Type == "" {
+		s.Delivery.Type = DeliveryStore
+	}
+	if s.Delivery.Type == DeliveryEmail && len(s.Delivery.Recipients) == 0 {
+		problems = append(problems, "email delivery requires recipients")
+	}
+	if s.MissedRuns == "" {
+		s.MissedRuns = MissedRunOnce
+	}
+	if s.MissedRuns != MissedSkip && s.MissedRuns != MissedRunOnce {
+		problems = append(problems, fmt.Sprintf("invalid missed_runs policy: %s", s.MissedRuns))
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// ValidationError lists every problem found in a schedule definition
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid schedule: %v", e.Problems)
+}
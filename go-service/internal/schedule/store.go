@@ -0,0 +1,167 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// maxRunsPerSchedule bounds the execution history kept per schedule
+const maxRunsPerSchedule = 100
+
+// Store persists schedules and their execution history
+type Store interface {
+	ListSchedules() ([]Schedule, error)
+	GetSchedule(id string) (*Schedule, error)
+	SaveSchedule(s Schedule) error
+	DeleteSchedule(id string) error
+	AppendRun(run Run) error
+	ListRuns(scheduleID string, limit int) ([]Run, error)
+}
+
+// FileStore keeps schedules and runs in a single JSON document that is
+// rewritten atomically on every change
+type FileStore struct {
+	path  string
+	mutex sync.Mutex
+	data  fileData
+}
+
+type fileData struct {
+	Schedules map[string]Schedule `json:"schedules"`
+	Runs      map[string][]Run    `json:"runs"`
+}
+
+// NewFileStore loads the store from path, creating it if missing
+func NewFileStore(path string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create schedule directory: %w", err)
+	}
+
+	store := &FileStore{
+		path: path,
+		data: fileData{
+			Schedules: make(map[string]Schedule),
+			Runs:      make(map[string][]Run),
+		},
+	}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schedule store: %w", err)
+	}
+
+	if err := json.Unmarshal(content, &store.data); err != nil {
+		return nil, fmt.Errorf("failed to parse schedule store: %w", err)
+	}
+	if store.data.Schedules == nil {
+		store.data.Schedules = make(map[string]Schedule)
+	}
+	if store.data.Runs == nil {
+		store.data.Runs = make(map[string][]Run)
+	}
+
+	return store, nil
+}
+
+// ListSchedules returns all schedules ordered by creation time
+func (s *FileStore) ListSchedules() ([]Schedule, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	schedules := make([]Schedule, 0, len(s.data.Schedules))
+	for _, schedule := range s.data.Schedules {
+		schedules = append(schedules, schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].CreatedAt.Before(schedules[j].CreatedAt)
+	})
+
+	return schedules, nil
+}
+
+// GetSchedule returns a single schedule
+func (s *FileStore) GetSchedule(id string) (*Schedule, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	schedule, ok := s.data.Schedules[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return &schedule, nil
+}
+
+// SaveSchedule creates or replaces a schedule
+func (s *FileStore) SaveSchedule(schedule Schedule) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.data.Schedules[schedule.ID] = schedule
+	return s.persist()
+}
+
+// DeleteSchedule removes a schedule and its history
+func (s *FileStore) DeleteSchedule(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.data.Schedules[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	delete(s.data.Schedules, id)
+	delete(s.data.Runs, id)
+	return s.persist()
+}
+
+// AppendRun records a run, trimming the oldest entries beyond the history limit
+func (s *FileStore) AppendRun(run Run) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	runs := append(s.data.Runs[run.ScheduleID], run)
+	if len(runs) > maxRunsPerSchedule {
+		runs = runs[len(runs)-maxRunsPerSchedule:]
+	}
+	s.data.Runs[run.ScheduleID] = runs
+	return s.persist()
+}
+
+// ListRuns returns the most recent runs of a schedule, newest first
+func (s *FileStore) ListRuns(scheduleID string, limit int) ([]Run, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	runs := s.data.Runs[scheduleID]
+	result := make([]Run, 0, len(runs))
+	for i := len(runs) - 1; i >= 0; i-- {
+		result = append(result, runs[i])
+		if limit > 0 && len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// persist writes the document to a temporary file and renames it into place
+func (s *FileStore) persist() error {
+	content, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode schedule store: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0640); err != nil {
+		return fmt.Errorf("failed to write schedule store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace schedule store: %w", err)
+	}
+	return nil
+}
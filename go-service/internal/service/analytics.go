@@ -0,0 +1,169 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"student-report-service/internal/models"
+)
+
+// analyticsCache keeps computed class analytics per filter set until they expire
+type analyticsCache struct {
+	mutex   sync.Mutex
+	entries map[string]analyticsEntry
+}
+
+type analyticsEntry struct {
+	report  models.ClassAnalyticsReport
+	expires time.Time
+}
+
+func (c *analyticsCache) get(key string, now time.Time) (*models.ClassAnalyticsReport, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		return nil, false
+	}
+	report := entry.report
+	return &report, true
+}
+
+func (c *analyticsCache) put(key string, report models.ClassAnalyticsReport, expires time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]analyticsEntry)
+	}
+	// Drop expired entries so the cache does not grow with every filter combination
+	now := time.Now()
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = analyticsEntry{report: report, expires: expires}
+}
+
+// GetClassAnalytics computes demographic aggregates per class and section.
+// Filters are those accepted by GET /api/v1/students. Results are cached for
+// ANALYTICS_CACHE_TTL unless refresh is set.
+func (ps *PDFReportService) GetClassAnalytics(filters map[string]string, refresh bool) (*models.ClassAnalyticsReport, error) {
+	key := analyticsCacheKey(filters)
+	ttl := ps.Config().Report.AnalyticsCacheTTL
+
+	if !refresh && ttl > 0 {
+		if report, ok := ps.analytics.get(key, time.Now()); ok {
+			report.Cached = true
+			return report, nil
+		}
+	}
+
+	items, err := ps.nodeClient.GetAllStudents(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch students list: %w", err)
+	}
+
+	// The list endpoint omits gender, dates and guardian details
+	students, err := ps.fetchStudents(items)
+	if err != nil {
+		return nil, err
+	}
+
+	report := models.ClassAnalyticsReport{
+		GeneratedAt: time.Now(),
+		Students:    len(students),
+		Classes:     buildClassAnalytics(students, time.Now()),
+	}
+	if ttl > 0 {
+		ps.analytics.put(key, report, report.GeneratedAt.Add(ttl))
+	}
+
+	return &report, nil
+}
+
+// buildClassAnalytics groups students by class and section, ordered by class then section
+func buildClassAnalytics(students []models.Student, now time.Time) []models.ClassAnalytics {
+	type group struct {
+		analytics       models.ClassAnalytics
+		withAccess      int
+		missingGuardian int
+	}
+
+	groups := make(map[[2]string]*group)
+	for _, student := range students {
+		className := models.SafeString(student.Class, "Unassigned")
+		section := models.SafeString(student.Section, "")
+
+		g, ok := groups[[2]string{className, section}]
+		if !ok {
+			g = &group{analytics: models.ClassAnalytics{
+				ClassName:        className,
+				Section:          section,
+				Gender:           make(map[string]int),
+				AgeDistribution:  make(map[string]int),
+				AdmissionsByTerm: make(map[string]int),
+			}}
+			groups[[2]string{className, section}] = g
+		}
+
+		a := &g.analytics
+		a.Headcount++
+		a.Gender[models.SafeString(student.Gender, "Unspecified")]++
+		a.AgeDistribution[student.AgeOn(now)]++
+		if student.AdmissionDate != nil {
+			if admitted, err := models.ParseDate(*student.AdmissionDate); err == nil {
+				a.AdmissionsByTerm[models.AdmissionTerm(admitted)]++
+			}
+		}
+		if student.SystemAccess {
+			g.withAccess++
+		}
+		if !student.HasGuardianDetails() {
+			g.missingGuardian++
+		}
+	}
+
+	result := make([]models.ClassAnalytics, 0, len(groups))
+	for _, g := range groups {
+		g.analytics.SystemAccessShare = share(g.withAccess, g.analytics.Headcount)
+		g.analytics.MissingGuardianShare = share(g.missingGuardian, g.analytics.Headcount)
+		result = append(result, g.analytics)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ClassName != result[j].ClassName {
+			return result[i].ClassName < result[j].ClassName
+		}
+		return result[i].Section < result[j].Section
+	})
+	return result
+}
+
+// share returns part/total rounded to three decimals
+func share(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*1000) / 1000
+}
+
+// analyticsCacheKey builds a stable key from the filters
+func analyticsCacheKey(filters map[string]string) string {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+filters[key])
+	}
+	return strings.Join(parts, "&")
+}
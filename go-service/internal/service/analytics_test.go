@@ -0,0 +1,73 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func analyticsStudents() []models.Student {
+	return []models.Student{
+		{ID: 1, Class: stringPtr("Grade 5"), Section: stringPtr("A"), Gender: stringPtr("Male"), DOB: stringPtr("2014-06-01"),
+			AdmissionDate: stringPtr("2023-02-10"), SystemAccess: true, GuardianName: stringPtr("Ram"), GuardianPhone: stringPtr("9800000001")},
+		{ID: 2, Class: stringPtr("Grade 5"), Section: stringPtr("A"), Gender: stringPtr("Female"), DOB: stringPtr("2014-03-01T00:00:00.000Z"),
+			AdmissionDate: stringPtr("2023-09-01"), GuardianName: stringPtr("Sita")},
+		{ID: 3, Class: stringPtr("Grade 5"), Section: stringPtr("A"), AdmissionDate: stringPtr("2023-03-15"), SystemAccess: true},
+		{ID: 4, Class: stringPtr("Grade 4"), Gender: stringPtr("Female"), DOB: stringPtr("2015-01-20"), SystemAccess: true,
+			GuardianName: stringPtr("Hari"), GuardianPhone: stringPtr("9800000002")},
+	}
+}
+
+func TestBuildClassAnalytics(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	classes := buildClassAnalytics(analyticsStudents(), now)
+
+	require.Len(t, classes, 2)
+	assert.Equal(t, "Grade 4", classes[0].ClassName, "classes are ordered by name")
+
+	grade5 := classes[1]
+	assert.Equal(t, "A", grade5.Section)
+	assert.Equal(t, 3, grade5.Headcount)
+	assert.Equal(t, map[string]int{"Male": 1, "Female": 1, "Unspecified": 1}, grade5.Gender)
+	assert.Equal(t, map[string]int{"9": 1, "10": 1, models.AgeUnknown: 1}, grade5.AgeDistribution)
+	assert.Equal(t, map[string]int{"2023-T1": 2, "2023-T3": 1}, grade5.AdmissionsByTerm)
+	assert.Equal(t, 0.667, grade5.SystemAccessShare)
+	assert.Equal(t, 0.667, grade5.MissingGuardianShare)
+
+	assert.Equal(t, 1.0, classes[0].SystemAccessShare)
+	assert.Equal(t, 0.0, classes[0].MissingGuardianShare)
+	assert.Empty(t, classes[0].AdmissionsByTerm)
+}
+
+func TestPDFReportService_GetClassAnalyticsCaches(t *testing.T) {
+	mockNodeClient := new(MockNodeJSClient)
+	filters := map[string]string{"className": "Grade 5"}
+
+	mockNodeClient.On("GetAllStudents", filters).Return([]models.StudentListItem{{ID: 1}, {ID: 2}}, nil)
+	students := analyticsStudents()
+	mockNodeClient.On("GetStudentByID", 1).Return(&students[0], nil)
+	mockNodeClient.On("GetStudentByID", 2).Return(&students[1], nil)
+
+	cfg := &config.Config{Report: config.ReportConfig{AnalyticsCacheTTL: time.Minute}}
+	service := NewPDFReportService(mockNodeClient, new(MockPDFGenerator), cfg)
+
+	first, err := service.GetClassAnalytics(filters, false)
+	require.NoError(t, err)
+	assert.False(t, first.Cached)
+	assert.Equal(t, 2, first.Students)
+
+	second, err := service.GetClassAnalytics(filters, false)
+	require.NoError(t, err)
+	assert.True(t, second.Cached)
+	mockNodeClient.AssertNumberOfCalls(t, "GetAllStudents", 1)
+
+	refreshed, err := service.GetClassAnalytics(filters, true)
+	require.NoError(t, err)
+	assert.False(t, refreshed.Cached)
+	mockNodeClient.AssertNumberOfCalls(t, "GetAllStudents", 2)
+}
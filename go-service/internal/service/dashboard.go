@@ -0,0 +1,149 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+	"student-report-service/internal/snapshot"
+)
+
+// DashboardReportResult represents the result of a dashboard report generation
+type DashboardReportResult struct {
+	ReportID    string       `json:"report_id"`
+	KPIs        []models.KPI `json:"kpis"`
+	BaselineAt  *time.Time   `json:"baseline_at,omitempty"`
+	FilePath    string       `json:"file_path"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	GeneratedBy string       `json:"generated_by"`
+	FileSize    int64        `json:"file_size"`
+}
+
+// SetSnapshotStore replaces the store used for dashboard trend comparisons
+func (ps *PDFReportService) SetSnapshotStore(store snapshot.Store) {
+	if store == nil {
+		store = snapshot.NopStore{}
+	}
+	ps.snapshotStore = store
+}
+
+// CreateDashboardPDF generates the dashboard summary and records a snapshot of
+// its metrics. Trends compare against the newest snapshot taken before since;
+// a zero since means the start of today, so repeated runs on one day share a baseline.
+func (ps *PDFReportService) CreateDashboardPDF(since time.Time, opts ReportOptions) (*DashboardReportResult, error) {
+	// The report has no redacted variant
+	opts.Profile = redaction.ProfileFull
+	if since.IsZero() {
+		now := time.Now()
+		since = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	}
+
+	result, err := ps.createDashboardPDF(since, opts)
+	if err != nil {
+		ps.auditGeneration(opts, "dashboard report", "", "", err)
+		return nil, err
+	}
+
+	if err := ps.auditGeneration(opts, "", result.ReportID, result.FilePath, nil); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createDashboardPDF fetches the dashboard, compares it with the baseline and renders the report
+func (ps *PDFReportService) createDashboardPDF(since time.Time, opts ReportOptions) (*DashboardReportResult, error) {
+	dashboard, err := ps.nodeClient.GetDashboard()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch dashboard data: %w", err)
+	}
+
+	baseline, err := ps.snapshotStore.Latest(since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dashboard snapshot: %w", err)
+	}
+
+	report := &models.DashboardReport{
+		Dashboard: dashboard,
+		KPIs:      buildKPIs(dashboard, baseline),
+	}
+	if baseline != nil {
+		report.BaselineAt = baseline.TakenAt
+	}
+
+	metadata := &models.ReportMetadata{
+		GeneratedAt: time.Now(),
+		GeneratedBy: opts.GeneratedBy,
+		ReportID:    fmt.Sprintf("DSH-%d", time.Now().UnixNano()),
+		Archival:    opts.Archival,
+		School:      ps.Config().Report.SchoolName,
+	}
+
+	filePath, err := ps.jobs.run(func() (string, error) {
+		return ps.pdfGenerator.GenerateDashboardReport(report, metadata)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF report: %w", err)
+	}
+
+	err = ps.snapshotStore.Save(snapshot.Snapshot{
+		TakenAt:  metadata.GeneratedAt,
+		ReportID: metadata.ReportID,
+		Metrics:  dashboard.Metrics(),
+	})
+	if err != nil {
+		// The report is not returned, so do not leave its file behind
+		os.Remove(filePath)
+		return nil, fmt.Errorf("failed to save dashboard snapshot: %w", err)
+	}
+
+	result := &DashboardReportResult{
+		ReportID:    metadata.ReportID,
+		KPIs:        report.KPIs,
+		FilePath:    filePath,
+		GeneratedAt: metadata.GeneratedAt,
+		GeneratedBy: opts.GeneratedBy,
+		FileSize:    ps.getActualFileSize(filePath),
+	}
+	if baseline != nil {
+		result.BaselineAt = &baseline.TakenAt
+	}
+
+	return result, nil
+}
+
+// buildKPIs builds the KPI tiles. Headcounts carry the backend's change from
+// the previous year; every tile is compared with the baseline when it has the metric.
+func buildKPIs(dashboard *models.Dashboard, baseline *snapshot.Snapshot) []models.KPI {
+	yearChange := func(count models.DashboardCount) *float64 {
+		change := count.PercentChange
+		return &change
+	}
+
+	kpis := []struct {
+		metric string
+		kpi    models.KPI
+	}{
+		{models.MetricStudents, models.KPI{Label: "New Students", YearChange: yearChange(dashboard.Students)}},
+		{models.MetricTeachers, models.KPI{Label: "New Teachers", YearChange: yearChange(dashboard.Teachers)}},
+		{models.MetricParents, models.KPI{Label: "New Parents", YearChange: yearChange(dashboard.Parents)}},
+		{models.MetricOnLeave, models.KPI{Label: "On Leave (30 days)"}},
+	}
+
+	metrics := dashboard.Metrics()
+	result := make([]models.KPI, 0, len(kpis))
+	for _, item := range kpis {
+		kpi := item.kpi
+		kpi.Value = metrics[item.metric]
+		if baseline != nil {
+			if previous, ok := baseline.Metrics[item.metric]; ok {
+				change := kpi.Value - previous
+				kpi.SnapshotChange = &change
+			}
+		}
+		result = append(result, kpi)
+	}
+	return result
+}
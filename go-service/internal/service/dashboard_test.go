@@ -0,0 +1,144 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+	"student-report-service/internal/schedule"
+	"student-report-service/internal/snapshot"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testDashboard(students int) *models.Dashboard {
+	return &models.Dashboard{
+		Students: models.DashboardCount{CurrentYear: students, PercentChange: 25},
+		Teachers: models.DashboardCount{CurrentYear: 4},
+		Parents:  models.DashboardCount{CurrentYear: 6, PercentChange: -10},
+		LeavePolicies: []models.DashboardLeavePolicy{
+			{ID: 1, Name: "Sick", TotalDaysUsed: 3},
+			{ID: 2, Name: "Casual", TotalDaysUsed: 2.5},
+		},
+		OneMonthLeave: []models.UpcomingLeave{{UserID: 7}, {UserID: 7}, {UserID: 9}},
+	}
+}
+
+func TestPDFReportService_CreateDashboardPDF(t *testing.T) {
+	outputDir := t.TempDir()
+	store, err := snapshot.NewFileStore(filepath.Join(t.TempDir(), "snapshots.json"))
+	require.NoError(t, err)
+
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+
+	mockNodeClient.On("GetDashboard").Return(testDashboard(10), nil).Once()
+	mockNodeClient.On("GetDashboard").Return(testDashboard(13), nil).Once()
+
+	var rendered *models.DashboardReport
+	mockPDFGen.On("GenerateDashboardReport", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { rendered = args.Get(0).(*models.DashboardReport) }).
+		Return(filepath.Join(outputDir, "dashboard.pdf"), nil)
+
+	service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+	service.SetSnapshotStore(store)
+
+	// The first report has nothing to compare with
+	first, err := service.CreateDashboardPDF(time.Time{}, ReportOptions{GeneratedBy: "Test User"})
+	require.NoError(t, err)
+	assert.Nil(t, first.BaselineAt)
+	require.Len(t, rendered.KPIs, 4)
+	assert.Equal(t, 10.0, rendered.KPIs[0].Value)
+	assert.Equal(t, 25.0, *rendered.KPIs[0].YearChange)
+	assert.Nil(t, rendered.KPIs[0].SnapshotChange)
+	assert.Equal(t, 2.0, rendered.KPIs[3].Value, "people on leave are counted once")
+	assert.Nil(t, rendered.KPIs[3].YearChange)
+
+	// A later report compares against the snapshot recorded by the first
+	second, err := service.CreateDashboardPDF(time.Now().Add(time.Hour), ReportOptions{GeneratedBy: "Test User"})
+	require.NoError(t, err)
+	require.NotNil(t, second.BaselineAt)
+	assert.Equal(t, 3.0, *rendered.KPIs[0].SnapshotChange)
+	assert.Equal(t, 0.0, *rendered.KPIs[1].SnapshotChange)
+}
+
+// failingSnapshots cannot record snapshots
+type failingSnapshots struct {
+	snapshot.NopStore
+}
+
+func (failingSnapshots) Save(snapshot.Snapshot) error {
+	return errors.New("disk full")
+}
+
+func TestPDFReportService_CreateDashboardPDFRemovesFileWhenSnapshotFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dashboard.pdf")
+	require.NoError(t, os.WriteFile(path, []byte("%PDF"), 0644))
+
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+	mockNodeClient.On("GetDashboard").Return(testDashboard(10), nil)
+	mockPDFGen.On("GenerateDashboardReport", mock.Anything, mock.Anything).Return(path, nil)
+
+	service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+	service.SetSnapshotStore(failingSnapshots{})
+
+	_, err := service.CreateDashboardPDF(time.Time{}, ReportOptions{GeneratedBy: "Test User"})
+	assert.ErrorContains(t, err, "failed to save dashboard snapshot")
+	assert.NoFileExists(t, path)
+}
+
+func TestScheduleExecutor_ValidateDashboard(t *testing.T) {
+	tests := []struct {
+		name          string
+		schedule      schedule.Schedule
+		expectedError string
+	}{
+		{
+			name: "Dashboard without target",
+			schedule: schedule.Schedule{
+				Template: schedule.TemplateDashboard,
+				Profile:  "full",
+				Role:     "admin",
+				Delivery: schedule.Delivery{Type: schedule.DeliveryStore},
+			},
+		},
+		{
+			name: "Dashboard with a target",
+			schedule: schedule.Schedule{
+				Template: schedule.TemplateDashboard,
+				Target:   schedule.Target{StudentIDs: []int{1}},
+				Delivery: schedule.Delivery{Type: schedule.DeliveryStore},
+			},
+			expectedError: "takes no target",
+		},
+		{
+			name: "Dashboard with a redacted profile",
+			schedule: schedule.Schedule{
+				Template: schedule.TemplateDashboard,
+				Profile:  "parent-facing",
+				Role:     "teacher",
+				Delivery: schedule.Delivery{Type: schedule.DeliveryStore},
+			},
+			expectedError: "require the full profile",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := NewScheduleExecutor(NewPDFReportService(new(MockNodeJSClient), new(MockPDFGenerator), &config.Config{}))
+			err := executor.Validate(&tt.schedule)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
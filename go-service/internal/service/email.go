@@ -0,0 +1,211 @@
+package service
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"student-report-service/internal/audit"
+	"student-report-service/internal/mailer"
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+)
+
+// RecipientStudent is the recipient keyword replaced with the student's own
+// email address
+const RecipientStudent = "student"
+
+// EmailReportResult is the outcome of generating and emailing a report
+type EmailReportResult struct {
+	Report   *PDFReportResult       `json:"report"`
+	Delivery *mailer.DeliveryRecord `json:"delivery"`
+}
+
+// SetMailer enables email delivery of generated reports
+func (ps *PDFReportService) SetMailer(m ReportMailerInterface) {
+	ps.mailer = m
+}
+
+// EmailEnabled reports whether email delivery is configured
+func (ps *PDFReportService) EmailEnabled() bool {
+	return ps.mailer != nil
+}
+
+// EmailStudentReport generates a student report and queues it for emailing to
+// the recipients. Recipients are email addresses or the keyword "student".
+// The returned delivery is queued; its outcome is audited and recorded with
+// the deliveries once sent.
+func (ps *PDFReportService) EmailStudentReport(studentID int, opts ReportOptions, recipients []string) (*EmailReportResult, error) {
+	if ps.mailer == nil {
+		return nil, fmt.Errorf("email delivery is not configured")
+	}
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("invalid request: at least one recipient is required")
+	}
+
+	report, err := ps.CreateStudentPDF(studentID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := ps.queueReportEmail(report, recipients, opts)
+	return &EmailReportResult{Report: report, Delivery: delivery}, err
+}
+
+// Deliveries returns recorded email deliveries, optionally for a single report
+func (ps *PDFReportService) Deliveries(reportID string, limit int) ([]mailer.DeliveryRecord, error) {
+	if ps.mailer == nil {
+		return nil, fmt.Errorf("email delivery is not configured")
+	}
+	return ps.mailer.Deliveries(reportID, limit), nil
+}
+
+// emailReport delivers an already generated report and audits the attempt
+func (ps *PDFReportService) emailReport(report *PDFReportResult, recipients []string, opts ReportOptions) (*mailer.DeliveryRecord, error) {
+	email, record, err := ps.prepareEmail(report, recipients, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := ps.mailer.SendReport(email)
+	return delivery, ps.auditDelivery(record, email.To, err)
+}
+
+// queueReportEmail queues an already generated report for delivery, so the
+// caller does not wait for retries. The attempt is audited once it completes
+func (ps *PDFReportService) queueReportEmail(report *PDFReportResult, recipients []string, opts ReportOptions) (*mailer.DeliveryRecord, error) {
+	email, record, err := ps.prepareEmail(report, recipients, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := ps.mailer.QueueReport(email, func(_ mailer.DeliveryRecord, err error) {
+		ps.auditDelivery(record, email.To, err)
+	})
+	if err != nil {
+		return nil, ps.auditDelivery(record, email.To, err)
+	}
+	return delivery, nil
+}
+
+// prepareEmail resolves the recipients of a report email. The returned audit
+// record is completed by auditDelivery; a failure is audited here
+func (ps *PDFReportService) prepareEmail(report *PDFReportResult, recipients []string, opts ReportOptions) (mailer.ReportEmail, audit.Record, error) {
+	record := ps.newAuditRecord(audit.ActionEmail, report.StudentID, opts)
+	record.ReportID = report.ReportID
+
+	to, err := ps.resolveRecipients(report.StudentID, recipients, opts.Profile)
+	if err != nil {
+		record.Outcome = audit.OutcomeFailure
+		record.Details = err.Error()
+		ps.auditStore.Append(record)
+		return mailer.ReportEmail{}, record, err
+	}
+
+	return mailer.ReportEmail{
+		ReportID:    report.ReportID,
+		StudentID:   report.StudentID,
+		StudentName: report.StudentName,
+		GeneratedAt: report.GeneratedAt,
+		GeneratedBy: report.GeneratedBy,
+		FilePath:    report.FilePath,
+		To:          to,
+	}, record, nil
+}
+
+// auditDelivery records the outcome of emailing a report
+func (ps *PDFReportService) auditDelivery(record audit.Record, to []string, err error) error {
+	record.Details = strings.Join(to, ", ")
+	if err != nil {
+		record.Outcome = audit.OutcomeFailure
+		record.Details = fmt.Sprintf("%s: %v", record.Details, err)
+		ps.auditStore.Append(record)
+		return fmt.Errorf("failed to email report: %w", err)
+	}
+
+	ps.auditStore.Append(record)
+	return nil
+}
+
+// resolveRecipients expands the recipient keyword into the student's email
+// address and checks every address against the domains allowed for the profile.
+func (ps *PDFReportService) resolveRecipients(studentID int, recipients []string, profile redaction.Profile) ([]string, error) {
+	resolved := make([]string, 0, len(recipients))
+	seen := make(map[string]bool)
+
+	var student *models.Student
+	for _, recipient := range recipients {
+		address := strings.TrimSpace(recipient)
+
+		if isRecipientKeyword(address) {
+			if student == nil {
+				found, err := ps.nodeClient.GetStudentByID(studentID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to fetch student email: %w", err)
+				}
+				if found == nil {
+					return nil, fmt.Errorf("student with ID %d not found", studentID)
+				}
+				student = found
+			}
+			if student.Email == "" {
+				return nil, fmt.Errorf("invalid recipient: student %d has no email address", studentID)
+			}
+			address = student.Email
+		}
+
+		if address == "" {
+			continue
+		}
+		if err := ps.checkRecipient(address, isRecipientKeyword(recipient), profile); err != nil {
+			return nil, err
+		}
+		if !seen[strings.ToLower(address)] {
+			seen[strings.ToLower(address)] = true
+			resolved = append(resolved, address)
+		}
+	}
+
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("invalid request: no valid recipients")
+	}
+	return resolved, nil
+}
+
+// isRecipientKeyword reports whether the recipient is resolved from the student record
+func isRecipientKeyword(recipient string) bool {
+	return strings.EqualFold(strings.TrimSpace(recipient), RecipientStudent)
+}
+
+// checkRecipient rejects addresses a report with the profile may not be sent
+// to. Other addresses must be in SMTP_ALLOWED_DOMAINS, except those taken from
+// the student record. Full-profile reports may only go to addresses that are
+// also in SMTP_FULL_PROFILE_DOMAINS, wherever they came from
+func (ps *PDFReportService) checkRecipient(address string, fromRecord bool, profile redaction.Profile) error {
+	smtp := ps.Config().SMTP
+	if profile == redaction.ProfileFull {
+		if !inDomains(address, smtp.AllowedDomains) || !inDomains(address, smtp.FullProfileDomains) {
+			return fmt.Errorf("invalid recipient: %s is outside the email domains allowed for full-profile reports", address)
+		}
+		return nil
+	}
+	if !fromRecord && !inDomains(address, smtp.AllowedDomains) {
+		return fmt.Errorf("invalid recipient: %s is outside the allowed email domains", address)
+	}
+	return nil
+}
+
+// inDomains reports whether the address is in one of the domains
+func inDomains(address string, domains []string) bool {
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return false
+	}
+	_, domain, _ := strings.Cut(parsed.Address, "@")
+	for _, allowed := range domains {
+		if strings.EqualFold(domain, strings.TrimPrefix(allowed, "@")) {
+			return true
+		}
+	}
+	return false
+}
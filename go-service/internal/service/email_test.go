@@ -0,0 +1,98 @@
+package service
+
+import (
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPDFReportService_ResolveRecipients(t *testing.T) {
+	tests := []struct {
+		name          string
+		student       *models.Student
+		recipients    []string
+		profile       redaction.Profile
+		expected      []string
+		expectedError string
+	}{
+		{
+			name:       "Keyword resolves from the student record",
+			student:    &models.Student{ID: 1, Email: "john@family.test"},
+			recipients: []string{"student", "Student", "teacher@school.edu"},
+			profile:    redaction.ProfileParentFacing,
+			expected:   []string{"john@family.test", "teacher@school.edu"},
+		},
+		{
+			name:          "Student without an email address",
+			student:       &models.Student{ID: 1},
+			recipients:    []string{"student"},
+			profile:       redaction.ProfileParentFacing,
+			expectedError: "has no email address",
+		},
+		{
+			name:       "Address in an allowed domain",
+			recipients: []string{"Class Teacher <teacher@School.edu>"},
+			profile:    redaction.ProfileParentFacing,
+			expected:   []string{"Class Teacher <teacher@School.edu>"},
+		},
+		{
+			name:          "Address outside the allowed domains",
+			recipients:    []string{"someone@elsewhere.test"},
+			profile:       redaction.ProfileParentFacing,
+			expectedError: "outside the allowed email domains",
+		},
+		{
+			name:          "Full profile reports outside the allowed domains",
+			recipients:    []string{"someone@elsewhere.test"},
+			profile:       redaction.ProfileFull,
+			expectedError: "outside the email domains allowed for full-profile reports",
+		},
+		{
+			name:          "Full profile reports need the stricter list",
+			recipients:    []string{"teacher@school.edu"},
+			profile:       redaction.ProfileFull,
+			expectedError: "outside the email domains allowed for full-profile reports",
+		},
+		{
+			name:          "Full profile reports to student addresses are checked too",
+			student:       &models.Student{ID: 1, Email: "john@family.test"},
+			recipients:    []string{"student"},
+			profile:       redaction.ProfileFull,
+			expectedError: "john@family.test is outside",
+		},
+		{
+			name:       "Full profile reports to the stricter list",
+			recipients: []string{"head@office.school.edu"},
+			profile:    redaction.ProfileFull,
+			expected:   []string{"head@office.school.edu"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			if tt.student != nil {
+				mockNodeClient.On("GetStudentByID", 1).Return(tt.student, nil).Once()
+			}
+
+			cfg := &config.Config{SMTP: config.SMTPConfig{
+				AllowedDomains:     []string{"school.edu", "office.school.edu"},
+				FullProfileDomains: []string{"office.school.edu"},
+			}}
+			service := NewPDFReportService(mockNodeClient, new(MockPDFGenerator), cfg)
+
+			resolved, err := service.resolveRecipients(1, tt.recipients, tt.profile)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, resolved)
+			mockNodeClient.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"fmt"
+
+	"student-report-service/internal/export"
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+)
+
+var studentListColumns = []export.Column{
+	{Title: "ID", Width: 8},
+	{Title: "Name", Width: 28},
+	{Title: "Email", Width: 32},
+	{Title: "System Access"},
+	{Title: "Class", Width: 12},
+	{Title: "Section"},
+	{Title: "Roll", Width: 8},
+}
+
+var studentDetailColumns = []export.Column{
+	{Title: "ID", Width: 8},
+	{Title: "Name", Width: 28},
+	{Title: "Email", Width: 32},
+	{Title: "System Access"},
+	{Title: "Phone", Width: 16},
+	{Title: "Gender"},
+	{Title: "Date of Birth", Width: 14},
+	{Title: "Class", Width: 12},
+	{Title: "Section"},
+	{Title: "Roll", Width: 8},
+	{Title: "Admission Date", Width: 16},
+	{Title: "Father's Name", Width: 24},
+	{Title: "Father's Phone", Width: 16},
+	{Title: "Mother's Name", Width: 24},
+	{Title: "Mother's Phone", Width: 16},
+	{Title: "Guardian's Name", Width: 24},
+	{Title: "Guardian's Phone", Width: 16},
+	{Title: "Relation of Guardian", Width: 20},
+	{Title: "Current Address", Width: 36},
+	{Title: "Permanent Address", Width: 36},
+	{Title: "Reporter", Width: 24},
+}
+
+// ExportStudents fetches the students matching the filters and writes a row
+// for each to the writer returned by open as soon as it is fetched. open is
+// called with the columns once the student list has been fetched, so nothing
+// is written when that fails. With detailed set, every student's full record
+// is fetched, a few at a time, and each batch is flushed once written.
+// The redaction profile is applied before any value is written.
+func (ps *PDFReportService) ExportStudents(filters map[string]string, detailed bool, profile redaction.Profile, open func([]export.Column) (export.RowWriter, error)) error {
+	items, err := ps.nodeClient.GetAllStudents(filters)
+	if err != nil {
+		return fmt.Errorf("failed to fetch students list: %w", err)
+	}
+
+	if !detailed {
+		rows, err := open(studentListColumns)
+		if err != nil {
+			return err
+		}
+		for _, item := range redaction.ApplyListItems(profile, items) {
+			err := rows.WriteRow(
+				export.Number(float64(item.ID)),
+				export.Text(item.Name),
+				export.Text(item.Email),
+				exportBool(item.SystemAccess),
+				exportText(item.Class),
+				exportText(item.Section),
+				exportInt(item.Roll),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return rows.Flush()
+	}
+
+	rows, err := open(studentDetailColumns)
+	if err != nil {
+		return err
+	}
+	for start := 0; start < len(items); start += rosterFetchConcurrency {
+		students, err := ps.fetchStudents(items[start:min(start+rosterFetchConcurrency, len(items))])
+		if err != nil {
+			return err
+		}
+		for i := range students {
+			if err := writeStudentRow(rows, redaction.ApplyStudent(profile, &students[i])); err != nil {
+				return err
+			}
+		}
+		if err := rows.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeStudentRow writes a student's full record in studentDetailColumns order
+func writeStudentRow(rows export.RowWriter, student *models.Student) error {
+	return rows.WriteRow(
+		export.Number(float64(student.ID)),
+		export.Text(student.Name),
+		export.Text(student.Email),
+		exportBool(student.SystemAccess),
+		exportText(student.Phone),
+		exportText(student.Gender),
+		exportDate(student.DOB),
+		exportText(student.Class),
+		exportText(student.Section),
+		exportInt(student.Roll),
+		exportDate(student.AdmissionDate),
+		exportText(student.FatherName),
+		exportText(student.FatherPhone),
+		exportText(student.MotherName),
+		exportText(student.MotherPhone),
+		exportText(student.GuardianName),
+		exportText(student.GuardianPhone),
+		exportText(student.RelationOfGuardian),
+		exportText(student.CurrentAddress),
+		exportText(student.PermanentAddress),
+		exportText(student.ReporterName),
+	)
+}
+
+func exportText(ptr *string) export.Value {
+	if ptr == nil || *ptr == "" {
+		return export.Blank()
+	}
+	return export.Text(*ptr)
+}
+
+func exportInt(ptr *int) export.Value {
+	if ptr == nil {
+		return export.Blank()
+	}
+	return export.Number(float64(*ptr))
+}
+
+func exportBool(value bool) export.Value {
+	if value {
+		return export.Text("Yes")
+	}
+	return export.Text("No")
+}
+
+// exportDate writes parseable dates as date cells and anything else, such as
+// a redacted value, as text
+func exportDate(ptr *string) export.Value {
+	if ptr == nil || *ptr == "" {
+		return export.Blank()
+	}
+	if t, err := models.ParseDate(*ptr); err == nil {
+		return export.Date(t)
+	}
+	return export.Text(*ptr)
+}
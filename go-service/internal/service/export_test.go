@@ -0,0 +1,140 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/export"
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPDFReportService_ExportStudents(t *testing.T) {
+	filters := map[string]string{"className": "Grade 5"}
+	items := []models.StudentListItem{
+		{ID: 1, Name: "Asha", Email: "asha@example.com", SystemAccess: true, Class: stringPtr("Grade 5"), Roll: intPtr(3)},
+	}
+	student := &models.Student{
+		ID: 1, Name: "Asha", Email: "asha@example.com", DOB: stringPtr("2014-06-01T00:00:00.000Z"),
+		Class: stringPtr("Grade 5"), Roll: intPtr(3), AdmissionDate: stringPtr("not recorded"),
+	}
+
+	tests := []struct {
+		name     string
+		detailed bool
+		profile  redaction.Profile
+		columns  int
+		expected map[string]string
+	}{
+		{
+			name:     "List",
+			profile:  redaction.ProfileFull,
+			columns:  len(studentListColumns),
+			expected: map[string]string{"ID": "1", "Email": "asha@example.com", "System Access": "Yes", "Section": "", "Roll": "3"},
+		},
+		{
+			name:     "Detailed",
+			detailed: true,
+			profile:  redaction.ProfileFull,
+			columns:  len(studentDetailColumns),
+			expected: map[string]string{"Date of Birth": "2014-06-01", "Admission Date": "not recorded", "Phone": ""},
+		},
+		{
+			name:     "Detailed with redaction",
+			detailed: true,
+			profile:  redaction.ProfilePublicNotice,
+			columns:  len(studentDetailColumns),
+			expected: map[string]string{"Email": redaction.Withheld, "Date of Birth": redaction.Withheld},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockNodeClient.On("GetAllStudents", filters).Return(items, nil)
+			mockNodeClient.On("GetStudentByID", 1).Return(student, nil)
+
+			service := NewPDFReportService(mockNodeClient, new(MockPDFGenerator), &config.Config{})
+			sheet := &export.Sheet{}
+			err := service.ExportStudents(filters, tt.detailed, tt.profile, func(columns []export.Column) (export.RowWriter, error) {
+				sheet.Columns = columns
+				return sheet, nil
+			})
+			require.NoError(t, err)
+
+			require.Len(t, sheet.Columns, tt.columns)
+			require.Len(t, sheet.Rows, 1)
+			row := make(map[string]string)
+			for i, column := range sheet.Columns {
+				row[column.Title] = sheet.Rows[0][i].String()
+			}
+			for title, value := range tt.expected {
+				assert.Equal(t, value, row[title], title)
+			}
+
+			if !tt.detailed {
+				mockNodeClient.AssertNotCalled(t, "GetStudentByID", 1)
+			}
+		})
+	}
+}
+
+func TestPDFReportService_ExportStudentsStreamsRows(t *testing.T) {
+	var items []models.StudentListItem
+	for id := 1; id <= 6; id++ {
+		items = append(items, models.StudentListItem{ID: id, Name: fmt.Sprintf("Student %d", id)})
+	}
+
+	mockNodeClient := new(MockNodeJSClient)
+	mockNodeClient.On("GetAllStudents", mock.Anything).Return(items, nil)
+	for id := 1; id <= 5; id++ {
+		mockNodeClient.On("GetStudentByID", id).Return(&models.Student{ID: id, Name: fmt.Sprintf("Student %d", id)}, nil)
+	}
+	mockNodeClient.On("GetStudentByID", 6).Return(nil, errors.New("API Error 500: boom"))
+
+	service := NewPDFReportService(mockNodeClient, new(MockPDFGenerator), &config.Config{})
+	var buffer bytes.Buffer
+	err := service.ExportStudents(nil, true, redaction.ProfileFull, func(columns []export.Column) (export.RowWriter, error) {
+		return export.NewCSVWriter(&buffer, columns)
+	})
+	assert.Error(t, err)
+
+	// The first batch was written and flushed before the second failed
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\r\n")
+	assert.Len(t, lines, 1+rosterFetchConcurrency)
+}
+
+func TestPDFReportService_ExportStudentsListFailure(t *testing.T) {
+	mockNodeClient := new(MockNodeJSClient)
+	mockNodeClient.On("GetAllStudents", mock.Anything).Return(nil, errors.New("API Error 500: boom"))
+
+	service := NewPDFReportService(mockNodeClient, new(MockPDFGenerator), &config.Config{})
+	opened := false
+	err := service.ExportStudents(nil, false, redaction.ProfileFull, func(columns []export.Column) (export.RowWriter, error) {
+		opened = true
+		return &export.Sheet{}, nil
+	})
+	assert.Error(t, err)
+	assert.False(t, opened, "nothing is written when the list cannot be fetched")
+}
+
+func TestExportDate(t *testing.T) {
+	assert.Equal(t, export.Blank(), exportDate(nil))
+	assert.Equal(t, export.Date(mustDate(t, "2024-01-05")), exportDate(stringPtr("2024-01-05")))
+	assert.Equal(t, export.Text("2014-**-**"), exportDate(stringPtr("2014-**-**")))
+}
+
+func mustDate(t *testing.T, value string) time.Time {
+	parsed, err := models.ParseDate(value)
+	require.NoError(t, err)
+	return parsed
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+
+	"student-report-service/internal/health"
+)
+
+// assetChecker is implemented by generators that can check their template,
+// font and logo assets load
+type assetChecker interface {
+	CheckAssets() error
+}
+
+// ReadinessChecks returns the checks that must pass before the service can
+// generate reports: the backend accepts the service account, the output
+// directory is writable with enough free space, the report assets load and
+// the job queue has room
+func (ps *PDFReportService) ReadinessChecks() []health.Check {
+	timeout := ps.Config().Health.CheckTimeout
+
+	checks := []health.Check{
+		{Name: "backend", Timeout: timeout, Run: ps.nodeClient.HealthCheck},
+		{Name: "output_dir", Timeout: timeout, Run: func(ctx context.Context) error {
+			cfg := ps.Config().Health
+			return health.WritableDir(ps.pdfGenerator.OutputDir(), cfg.MinFreeSpace, cfg.MinFreePercent)
+		}},
+	}
+	if checker, ok := ps.pdfGenerator.(assetChecker); ok {
+		checks = append(checks, health.Check{Name: "report_assets", Timeout: timeout, Run: func(ctx context.Context) error {
+			return checker.CheckAssets()
+		}})
+	}
+	checks = append(checks, health.Check{Name: "job_queue", Timeout: timeout, Run: func(ctx context.Context) error {
+		return ps.jobs.check()
+	}})
+	return checks
+}
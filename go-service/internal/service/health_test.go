@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/health"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPDFReportService_ReadinessChecks(t *testing.T) {
+	tests := []struct {
+		name      string
+		loginErr  error
+		outputDir func(t *testing.T) string
+		failing   map[string]string
+	}{
+		{
+			name:      "Ready",
+			outputDir: func(t *testing.T) string { return t.TempDir() },
+		},
+		{
+			name:      "Backend login fails",
+			loginErr:  errors.New("backend login failed: API Error 401: Invalid credentials"),
+			outputDir: func(t *testing.T) string { return t.TempDir() },
+			failing:   map[string]string{"backend": "Invalid credentials"},
+		},
+		{
+			name:      "Output directory missing",
+			outputDir: func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing") },
+			failing:   map[string]string{"output_dir": "not writable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockNodeClient.On("HealthCheck").Return(tt.loginErr)
+			mockPDFGen := new(MockPDFGenerator)
+			mockPDFGen.On("OutputDir").Return(tt.outputDir(t))
+
+			cfg := &config.Config{}
+			cfg.Health.CheckTimeout = time.Second
+			service := NewPDFReportService(mockNodeClient, mockPDFGen, cfg)
+
+			checks := service.ReadinessChecks()
+			var names []string
+			for _, check := range checks {
+				names = append(names, check.Name)
+				assert.Equal(t, time.Second, check.Timeout)
+			}
+			assert.Equal(t, []string{"backend", "output_dir", "job_queue"}, names,
+				"the mock generator has no assets to check")
+
+			report := health.NewChecker(0, checks...).Run(context.Background())
+			assert.Equal(t, len(tt.failing) == 0, report.Healthy)
+			for _, result := range report.Checks {
+				if message, ok := tt.failing[result.Name]; ok {
+					assert.False(t, result.Healthy, result.Name)
+					assert.Contains(t, result.Message, message)
+				} else {
+					assert.True(t, result.Healthy, "%s: %s", result.Name, result.Message)
+				}
+			}
+
+			mockNodeClient.AssertExpectations(t)
+		})
+	}
+}
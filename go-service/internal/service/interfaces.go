@@ -1,17 +1,63 @@
 package service
 
-import "student-report-service/internal/models"
+import (
+	"context"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/mailer"
+	"student-report-service/internal/models"
+	"student-report-service/internal/retention"
+)
 
 // NodeJSClientInterface defines the interface for Node.js API client
 type NodeJSClientInterface interface {
 	GetStudentByID(studentID int) (*models.Student, error)
 	GetAllStudents(filters map[string]string) ([]models.StudentListItem, error)
-	HealthCheck() error
+	GetStaffByID(staffID int) (*models.Staff, error)
+	GetAllStaff(filters map[string]string) ([]models.StaffListItem, error)
+	GetDashboard() (*models.Dashboard, error)
+	GetNotices(userID int) ([]models.Notice, error)
+	GetNoticeByID(noticeID int) (*models.NoticeDetail, error)
+	GetNoticeRecipients() ([]models.NoticeRecipient, error)
+	GetLeaveHistory(userID int) ([]models.LeaveRequest, error)
+	GetPendingLeaves() ([]models.LeaveRequest, error)
+	GetLeavePolicies() ([]models.LeavePolicy, error)
+	HealthCheck(ctx context.Context) error
 	Close() error
 }
 
 // PDFGeneratorInterface defines the interface for PDF generation
 type PDFGeneratorInterface interface {
 	GenerateStudentReport(student *models.Student, metadata *models.ReportMetadata) (string, error)
-	CleanupOldReports() error
+	GenerateStaffReport(staff *models.Staff, metadata *models.ReportMetadata) (string, error)
+	GenerateClassRoster(roster *models.ClassRoster, metadata *models.ReportMetadata) (string, error)
+	GenerateQualityChecklist(report *models.QualityReport, metadata *models.ReportMetadata) (string, error)
+	GenerateDashboardReport(report *models.DashboardReport, metadata *models.ReportMetadata) (string, error)
+	GenerateNoticeDigest(digest *models.NoticeDigest, metadata *models.ReportMetadata) (string, error)
+	GenerateLeaveReport(report *models.LeaveReport, metadata *models.ReportMetadata) (string, error)
+	GeneratePacket(packet *models.ReportPacket, metadata *models.ReportMetadata) (string, error)
+	CleanupOldReports(dryRun bool) (*retention.Summary, error)
+	OutputDir() string
+}
+
+// DocumentGeneratorInterface defines the interface for editable (DOCX) report generation
+type DocumentGeneratorInterface interface {
+	GenerateStudentReport(student *models.Student, metadata *models.ReportMetadata) (string, error)
+}
+
+// reportConfigUpdater is implemented by generators that accept a reloaded configuration
+type reportConfigUpdater interface {
+	UpdateConfig(cfg *config.ReportConfig)
+}
+
+// clientConfigUpdater is implemented by backend clients that accept a reloaded configuration
+type clientConfigUpdater interface {
+	UpdateConfig(cfg *config.NodeJSConfig)
+}
+
+// ReportMailerInterface defines the interface for emailing generated reports
+type ReportMailerInterface interface {
+	SendReport(email mailer.ReportEmail) (*mailer.DeliveryRecord, error)
+	QueueReport(email mailer.ReportEmail, complete func(mailer.DeliveryRecord, error)) (*mailer.DeliveryRecord, error)
+	Deliveries(reportID string, limit int) []mailer.DeliveryRecord
 }
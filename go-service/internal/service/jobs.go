@@ -0,0 +1,64 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrQueueFull is returned when too many reports are already waiting to be
+// generated
+var ErrQueueFull = errors.New("report queue is full, try again later")
+
+// jobQueue bounds how many reports are rendered at once. Reports beyond the
+// limit wait for a slot, and once maxWaiting are waiting new ones are refused
+type jobQueue struct {
+	// slots is nil when there is no limit
+	slots      chan struct{}
+	maxWaiting int64
+	running    atomic.Int64
+	waiting    atomic.Int64
+}
+
+// newJobQueue creates a queue running maxJobs reports at once, or any number
+// when maxJobs is 0
+func newJobQueue(maxJobs, maxWaiting int) *jobQueue {
+	q := &jobQueue{maxWaiting: int64(maxWaiting)}
+	if maxJobs > 0 {
+		q.slots = make(chan struct{}, maxJobs)
+	}
+	return q
+}
+
+// run renders a report once a slot is free
+func (q *jobQueue) run(render func() (string, error)) (string, error) {
+	if q.slots != nil {
+		select {
+		case q.slots <- struct{}{}:
+		default:
+			if q.waiting.Add(1) > q.maxWaiting {
+				q.waiting.Add(-1)
+				return "", ErrQueueFull
+			}
+			q.slots <- struct{}{}
+			q.waiting.Add(-1)
+		}
+		defer func() { <-q.slots }()
+	}
+
+	q.running.Add(1)
+	defer q.running.Add(-1)
+	return render()
+}
+
+// check reports an error when the queue is full and new reports are refused
+func (q *jobQueue) check() error {
+	if q.slots == nil {
+		return nil
+	}
+	running, waiting := q.running.Load(), q.waiting.Load()
+	if waiting >= q.maxWaiting && running >= int64(cap(q.slots)) {
+		return fmt.Errorf("%d reports rendering and %d waiting, the queue is full", running, waiting)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobQueue(t *testing.T) {
+	queue := newJobQueue(1, 1)
+	require.NoError(t, queue.check())
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go queue.run(func() (string, error) {
+		close(started)
+		<-release
+		return "first.pdf", nil
+	})
+	<-started
+
+	waiting := make(chan string)
+	go func() {
+		path, _ := queue.run(func() (string, error) { return "second.pdf", nil })
+		waiting <- path
+	}()
+	require.Eventually(t, func() bool { return queue.waiting.Load() == 1 }, time.Second, time.Millisecond)
+
+	_, err := queue.run(func() (string, error) { return "third.pdf", nil })
+	assert.ErrorIs(t, err, ErrQueueFull)
+	assert.EqualError(t, queue.check(), "1 reports rendering and 1 waiting, the queue is full")
+
+	close(release)
+	assert.Equal(t, "second.pdf", <-waiting, "waiting report runs once a slot is free")
+	require.Eventually(t, func() bool { return queue.check() == nil }, time.Second, time.Millisecond)
+
+	unlimited := newJobQueue(0, 0)
+	path, err := unlimited.run(func() (string, error) { return "report.pdf", nil })
+	require.NoError(t, err)
+	assert.Equal(t, "report.pdf", path)
+	assert.NoError(t, unlimited.check())
+}
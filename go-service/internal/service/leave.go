@@ -0,0 +1,166 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+)
+
+// LeaveReportResult represents the result of a leave report generation
+type LeaveReportResult struct {
+	ReportID    string    `json:"report_id"`
+	UserID      int       `json:"user_id"`
+	UserName    string    `json:"user_name"`
+	From        string    `json:"from,omitempty"`
+	To          string    `json:"to,omitempty"`
+	Requests    int       `json:"requests"`
+	FilePath    string    `json:"file_path"`
+	GeneratedAt time.Time `json:"generated_at"`
+	GeneratedBy string    `json:"generated_by"`
+	FileSize    int64     `json:"file_size"`
+}
+
+// CreateLeavePDF generates a leave history report for a user. Zero from/to
+// values leave that end of the date range open.
+func (ps *PDFReportService) CreateLeavePDF(userID int, from, to time.Time, opts ReportOptions) (*LeaveReportResult, error) {
+	// The report has no redacted variant
+	opts.Profile = redaction.ProfileFull
+	if userID <= 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", userID)
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, fmt.Errorf("invalid date range: from must not be after to")
+	}
+
+	result, err := ps.createLeavePDF(userID, from, to, opts)
+	if err != nil {
+		ps.auditGeneration(opts, fmt.Sprintf("leave report for user %d", userID), "", "", err)
+		return nil, err
+	}
+
+	if err := ps.auditGeneration(opts, "", result.ReportID, result.FilePath, nil); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createLeavePDF fetches leave data, computes balances and renders the report
+func (ps *PDFReportService) createLeavePDF(userID int, from, to time.Time, opts ReportOptions) (*LeaveReportResult, error) {
+	report, err := ps.buildLeaveReport(userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &models.ReportMetadata{
+		GeneratedAt: time.Now(),
+		GeneratedBy: opts.GeneratedBy,
+		ReportID:    fmt.Sprintf("LVE-%d-%d", userID, time.Now().Unix()),
+		Archival:    opts.Archival,
+		School:      ps.Config().Report.SchoolName,
+	}
+
+	filePath, err := ps.jobs.run(func() (string, error) {
+		return ps.pdfGenerator.GenerateLeaveReport(report, metadata)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF report: %w", err)
+	}
+
+	result := &LeaveReportResult{
+		ReportID:    metadata.ReportID,
+		UserID:      userID,
+		UserName:    report.UserName,
+		Requests:    len(report.Requests),
+		FilePath:    filePath,
+		GeneratedAt: metadata.GeneratedAt,
+		GeneratedBy: opts.GeneratedBy,
+		FileSize:    ps.getActualFileSize(filePath),
+	}
+	if !from.IsZero() {
+		result.From = from.Format("2006-01-02")
+	}
+	if !to.IsZero() {
+		result.To = to.Format("2006-01-02")
+	}
+
+	return result, nil
+}
+
+// buildLeaveReport fetches a user's leave history within the date range and
+// computes their balances
+func (ps *PDFReportService) buildLeaveReport(userID int, from, to time.Time) (*models.LeaveReport, error) {
+	history, err := ps.nodeClient.GetLeaveHistory(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch leave history: %w", err)
+	}
+
+	policies, err := ps.nodeClient.GetLeavePolicies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch leave policies: %w", err)
+	}
+
+	report := &models.LeaveReport{
+		UserID:   userID,
+		UserName: fmt.Sprintf("User #%d", userID),
+		From:     from,
+		To:       to,
+		Requests: []models.LeaveRequest{},
+	}
+	if len(history) > 0 && history[0].User != "" {
+		report.UserName = history[0].User
+	}
+
+	for _, leave := range history {
+		if leave.Overlaps(from, to) {
+			report.Requests = append(report.Requests, leave)
+		}
+	}
+	report.Balances = buildLeaveBalances(report.Requests, policies, ps.Config().Report.LeaveAllowances)
+
+	return report, nil
+}
+
+// buildLeaveBalances sums approved days per policy. Policies are listed in
+// backend order when they appear in the requests or have a configured
+// allowance; the remaining balance is only known when an allowance is set.
+func buildLeaveBalances(requests []models.LeaveRequest, policies []models.LeavePolicy, allowances map[string]float64) []models.LeaveBalance {
+	taken := make(map[string]float64)
+	used := make(map[string]bool)
+	for _, leave := range requests {
+		used[leave.Policy] = true
+		if leave.StatusID == models.LeaveStatusApproved {
+			taken[leave.Policy] += float64(leave.Days)
+		}
+	}
+
+	names := make([]string, 0, len(policies))
+	listed := make(map[string]bool)
+	for _, policy := range policies {
+		_, hasAllowance := allowances[policy.Name]
+		if used[policy.Name] || (policy.IsActive && hasAllowance) {
+			names = append(names, policy.Name)
+			listed[policy.Name] = true
+		}
+	}
+	for _, leave := range requests {
+		if !listed[leave.Policy] {
+			names = append(names, leave.Policy)
+			listed[leave.Policy] = true
+		}
+	}
+
+	balances := make([]models.LeaveBalance, 0, len(names))
+	for _, name := range names {
+		balance := models.LeaveBalance{Policy: name, DaysTaken: taken[name]}
+		if allowance, ok := allowances[name]; ok {
+			remaining := allowance - taken[name]
+			balance.Allowance = &allowance
+			balance.Remaining = &remaining
+		}
+		balances = append(balances, balance)
+	}
+	return balances
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testLeaveHistory() []models.LeaveRequest {
+	approver := "Admin"
+	return []models.LeaveRequest{
+		{ID: 3, Policy: "Sick Leave", From: "2024-03-10T00:00:00.000Z", To: "2024-03-11T00:00:00.000Z", StatusID: models.LeaveStatusApproved, Status: "Approved", Approver: &approver, User: "Jane Teacher", Days: 2},
+		{ID: 2, Policy: "Annual Leave", From: "2024-02-01T00:00:00.000Z", To: "2024-02-05T00:00:00.000Z", StatusID: models.LeaveStatusOnReview, Status: "On Review", User: "Jane Teacher", Days: 5},
+		{ID: 1, Policy: "Sick Leave", From: "2023-12-20T00:00:00.000Z", To: "2023-12-20T00:00:00.000Z", StatusID: models.LeaveStatusApproved, Status: "Approved", Approver: &approver, User: "Jane Teacher", Days: 1},
+	}
+}
+
+func testLeavePolicies() []models.LeavePolicy {
+	return []models.LeavePolicy{
+		{ID: 1, Name: "Annual Leave", IsActive: true},
+		{ID: 2, Name: "Sick Leave", IsActive: true},
+		{ID: 3, Name: "Study Leave", IsActive: true},
+		{ID: 4, Name: "Unused Leave", IsActive: true},
+	}
+}
+
+func TestPDFReportService_CreateLeavePDF(t *testing.T) {
+	outputDir := t.TempDir()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		userID        int
+		from, to      time.Time
+		setupMocks    func(*MockNodeJSClient, *MockPDFGenerator)
+		expectedError bool
+		errorContains string
+		requests      int
+	}{
+		{
+			name:   "Filters history to the date range",
+			userID: 5,
+			from:   from,
+			to:     to,
+			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
+				nodeClient.On("GetLeaveHistory", 5).Return(testLeaveHistory(), nil)
+				nodeClient.On("GetLeavePolicies").Return(testLeavePolicies(), nil)
+				pdfGen.On("GenerateLeaveReport",
+					mock.MatchedBy(func(r *models.LeaveReport) bool {
+						return r.UserName == "Jane Teacher" && len(r.Requests) == 2
+					}),
+					mock.AnythingOfType("*models.ReportMetadata"),
+				).Return(filepath.Join(outputDir, "leave.pdf"), nil)
+			},
+			requests: 2,
+		},
+		{
+			name:   "Open range includes all history",
+			userID: 5,
+			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
+				nodeClient.On("GetLeaveHistory", 5).Return(testLeaveHistory(), nil)
+				nodeClient.On("GetLeavePolicies").Return(testLeavePolicies(), nil)
+				pdfGen.On("GenerateLeaveReport", mock.Anything, mock.Anything).Return(filepath.Join(outputDir, "leave.pdf"), nil)
+			},
+			requests: 3,
+		},
+		{
+			name:          "Invalid user ID",
+			userID:        0,
+			setupMocks:    func(*MockNodeJSClient, *MockPDFGenerator) {},
+			expectedError: true,
+			errorContains: "invalid user ID",
+		},
+		{
+			name:          "Inverted date range",
+			userID:        5,
+			from:          to,
+			to:            from,
+			setupMocks:    func(*MockNodeJSClient, *MockPDFGenerator) {},
+			expectedError: true,
+			errorContains: "invalid date range",
+		},
+		{
+			name:   "Backend failure",
+			userID: 5,
+			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
+				nodeClient.On("GetLeaveHistory", 5).Return(nil, errors.New("API Error 500: boom"))
+			},
+			expectedError: true,
+			errorContains: "failed to fetch leave history",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockPDFGen := new(MockPDFGenerator)
+			tt.setupMocks(mockNodeClient, mockPDFGen)
+
+			service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+			result, err := service.CreateLeavePDF(tt.userID, tt.from, tt.to, ReportOptions{GeneratedBy: "Test User"})
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.userID, result.UserID)
+				assert.Equal(t, tt.requests, result.Requests)
+				assert.Equal(t, "Test User", result.GeneratedBy)
+			}
+
+			mockNodeClient.AssertExpectations(t)
+			mockPDFGen.AssertExpectations(t)
+		})
+	}
+}
+
+func TestBuildLeaveBalances(t *testing.T) {
+	allowances := map[string]float64{"Sick Leave": 10, "Study Leave": 5}
+	balances := buildLeaveBalances(testLeaveHistory(), testLeavePolicies(), allowances)
+
+	assert.Len(t, balances, 3)
+
+	// Pending leave does not count towards days taken; no allowance means no balance
+	assert.Equal(t, "Annual Leave", balances[0].Policy)
+	assert.Equal(t, 0.0, balances[0].DaysTaken)
+	assert.Nil(t, balances[0].Remaining)
+
+	assert.Equal(t, "Sick Leave", balances[1].Policy)
+	assert.Equal(t, 3.0, balances[1].DaysTaken)
+	assert.Equal(t, 7.0, *balances[1].Remaining)
+
+	// Unused policies are listed only when an allowance is configured
+	assert.Equal(t, "Study Leave", balances[2].Policy)
+	assert.Equal(t, 5.0, *balances[2].Remaining)
+}
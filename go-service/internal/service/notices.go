@@ -0,0 +1,237 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+)
+
+// NoticeDigestRequest selects the notices compiled into a digest
+type NoticeDigestRequest struct {
+	Title string
+	From  time.Time
+	To    time.Time
+
+	// UserID limits the digest to notices visible to one user
+	UserID int
+	// Group limits the digest to notices addressed to a recipient group
+	Group *models.RecipientGroup
+}
+
+// Unfiltered reports whether the digest covers every notice visible to the
+// service account rather than those of one user or recipient group
+func (r NoticeDigestRequest) Unfiltered() bool {
+	return r.UserID == 0 && r.Group == nil
+}
+
+// NoticeDigestResult represents the result of a notice digest generation
+type NoticeDigestResult struct {
+	ReportID    string    `json:"report_id"`
+	Title       string    `json:"title"`
+	Audience    string    `json:"audience"`
+	From        string    `json:"from,omitempty"`
+	To          string    `json:"to,omitempty"`
+	Notices     int       `json:"notices"`
+	FilePath    string    `json:"file_path"`
+	GeneratedAt time.Time `json:"generated_at"`
+	GeneratedBy string    `json:"generated_by"`
+	FileSize    int64     `json:"file_size"`
+}
+
+// GetNoticeRecipients lists the recipient groups notices can be addressed to
+func (ps *PDFReportService) GetNoticeRecipients() ([]models.NoticeRecipient, error) {
+	recipients, err := ps.nodeClient.GetNoticeRecipients()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch notice recipients: %w", err)
+	}
+	return recipients, nil
+}
+
+// CreateNoticeDigestPDF compiles approved notices published in the date range into a bulletin
+func (ps *PDFReportService) CreateNoticeDigestPDF(req NoticeDigestRequest, opts ReportOptions) (*NoticeDigestResult, error) {
+	// Digests carry no student data, so any role may download one for a user
+	// or recipient group. A digest of every notice, including those addressed
+	// to staff only, is kept to the full profile.
+	opts.Profile = redaction.ProfilePublicNotice
+	if req.Unfiltered() {
+		opts.Profile = redaction.ProfileFull
+	}
+	if req.UserID < 0 {
+		return nil, fmt.Errorf("invalid user ID: %d", req.UserID)
+	}
+	if req.UserID > 0 && req.Group != nil {
+		return nil, fmt.Errorf("invalid request: choose either a user or a recipient group")
+	}
+	if !req.From.IsZero() && !req.To.IsZero() && req.To.Before(req.From) {
+		return nil, fmt.Errorf("invalid date range: from must not be after to")
+	}
+
+	result, err := ps.createNoticeDigestPDF(req, opts)
+	if err != nil {
+		ps.auditGeneration(opts, "notice digest", "", "", err)
+		return nil, err
+	}
+
+	if err := ps.auditGeneration(opts, "", result.ReportID, result.FilePath, nil); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createNoticeDigestPDF selects, orders and renders the digest
+func (ps *PDFReportService) createNoticeDigestPDF(req NoticeDigestRequest, opts ReportOptions) (*NoticeDigestResult, error) {
+	notices, err := ps.nodeClient.GetNotices(req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch notices: %w", err)
+	}
+
+	selected := make([]models.DigestNotice, 0, len(notices))
+	for _, notice := range notices {
+		if notice.StatusID != models.NoticeStatusApproved {
+			continue
+		}
+		published := notice.PublishedAt()
+		if !inDateRange(published, req.From, req.To) {
+			continue
+		}
+		selected = append(selected, models.DigestNotice{Notice: notice, Published: published})
+	}
+
+	audience := "Everyone"
+	if req.UserID > 0 {
+		audience = fmt.Sprintf("User %d", req.UserID)
+	}
+	if req.Group != nil {
+		audience, err = ps.describeGroup(*req.Group)
+		if err != nil {
+			return nil, err
+		}
+		if selected, err = ps.filterByGroup(selected, *req.Group); err != nil {
+			return nil, err
+		}
+	}
+
+	// Newest notices first, as on a notice board
+	sort.SliceStable(selected, func(i, j int) bool {
+		return selected[i].Published.After(selected[j].Published)
+	})
+
+	digest := &models.NoticeDigest{
+		Title:    req.Title,
+		Audience: audience,
+		From:     req.From,
+		To:       req.To,
+		Notices:  selected,
+	}
+	if digest.Title == "" {
+		digest.Title = "Notice Board"
+	}
+
+	metadata := &models.ReportMetadata{
+		GeneratedAt: time.Now(),
+		GeneratedBy: opts.GeneratedBy,
+		ReportID:    fmt.Sprintf("NTC-%d", time.Now().UnixNano()),
+		Archival:    opts.Archival,
+		School:      ps.Config().Report.SchoolName,
+	}
+
+	filePath, err := ps.jobs.run(func() (string, error) {
+		return ps.pdfGenerator.GenerateNoticeDigest(digest, metadata)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF report: %w", err)
+	}
+
+	result := &NoticeDigestResult{
+		ReportID:    metadata.ReportID,
+		Title:       digest.Title,
+		Audience:    audience,
+		Notices:     len(selected),
+		FilePath:    filePath,
+		GeneratedAt: metadata.GeneratedAt,
+		GeneratedBy: opts.GeneratedBy,
+		FileSize:    ps.getActualFileSize(filePath),
+	}
+	if !req.From.IsZero() {
+		result.From = req.From.Format("2006-01-02")
+	}
+	if !req.To.IsZero() {
+		result.To = req.To.Format("2006-01-02")
+	}
+
+	return result, nil
+}
+
+// filterByGroup keeps notices addressed to the group. The notice list does not
+// carry recipients, so each notice's detail is fetched with bounded concurrency.
+func (ps *PDFReportService) filterByGroup(notices []models.DigestNotice, group models.RecipientGroup) ([]models.DigestNotice, error) {
+	keep := make([]bool, len(notices))
+	errs := make([]error, len(notices))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, rosterFetchConcurrency)
+	for i, item := range notices {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i, id int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			detail, err := ps.nodeClient.GetNoticeByID(id)
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to fetch notice %d: %w", id, err)
+				return
+			}
+			keep[i] = detail != nil && detail.AddressedTo(group)
+		}(i, item.Notice.ID)
+	}
+	wg.Wait()
+
+	filtered := make([]models.DigestNotice, 0, len(notices))
+	for i, item := range notices {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if keep[i] {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered, nil
+}
+
+// describeGroup names a recipient group for the digest title page
+func (ps *PDFReportService) describeGroup(group models.RecipientGroup) (string, error) {
+	recipients, err := ps.GetNoticeRecipients()
+	if err != nil {
+		return "", err
+	}
+
+	for _, recipient := range recipients {
+		if recipient.RoleID == group.RoleID {
+			if group.Field != "" {
+				return fmt.Sprintf("%s (%s)", recipient.RoleName, group.Field), nil
+			}
+			return recipient.RoleName, nil
+		}
+	}
+	return "", fmt.Errorf("invalid recipient group: role %d is not a notice recipient", group.RoleID)
+}
+
+// inDateRange reports whether t falls in [from, to], where to covers the whole day
+func inDateRange(t, from, to time.Time) bool {
+	if t.IsZero() {
+		return from.IsZero() && to.IsZero()
+	}
+	if !from.IsZero() && t.Before(from) {
+		return false
+	}
+	if !to.IsZero() && !t.Before(to.AddDate(0, 0, 1)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+	"student-report-service/internal/retention"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func digestNotices() []models.Notice {
+	return []models.Notice{
+		{ID: 1, Title: "Sports day", StatusID: models.NoticeStatusApproved, ReviewedDate: stringPtr("2024-03-10T09:00:00Z")},
+		{ID: 2, Title: "Draft notice", StatusID: models.NoticeStatusDraft, CreatedDate: stringPtr("2024-03-11T09:00:00Z")},
+		{ID: 3, Title: "Exam schedule", StatusID: models.NoticeStatusApproved, ReviewedDate: stringPtr("2024-03-20T09:00:00Z")},
+		{ID: 4, Title: "Old notice", StatusID: models.NoticeStatusApproved, ReviewedDate: stringPtr("2024-01-05T09:00:00Z")},
+		{ID: 5, Title: "Staff meeting", StatusID: models.NoticeStatusApproved, CreatedDate: stringPtr("2024-03-31T16:00:00Z")},
+	}
+}
+
+func TestPDFReportService_CreateNoticeDigestPDF(t *testing.T) {
+	outputDir := t.TempDir()
+	march := func(day int) time.Time { return time.Date(2024, 3, day, 0, 0, 0, 0, time.UTC) }
+	teacher := 2
+
+	tests := []struct {
+		name          string
+		request       NoticeDigestRequest
+		setupMocks    func(*MockNodeJSClient)
+		expectedOrder []int
+		expectedError string
+	}{
+		{
+			name:          "Approved notices in range, newest first",
+			request:       NoticeDigestRequest{From: march(1), To: march(31)},
+			expectedOrder: []int{5, 3, 1},
+		},
+		{
+			name:    "Filtered by recipient group",
+			request: NoticeDigestRequest{From: march(1), To: march(31), Group: &models.RecipientGroup{RoleID: 2, Field: "1"}},
+			setupMocks: func(m *MockNodeJSClient) {
+				m.On("GetNoticeRecipients").Return([]models.NoticeRecipient{{ID: 1, RoleID: 2, RoleName: "Teacher"}}, nil)
+				m.On("GetNoticeByID", 1).Return(&models.NoticeDetail{ID: 1, RecipientType: models.NoticeRecipientEveryone}, nil)
+				m.On("GetNoticeByID", 3).Return(&models.NoticeDetail{ID: 3, RecipientType: models.NoticeRecipientSpecific, RecipientRole: &teacher, FirstField: stringPtr("2")}, nil)
+				m.On("GetNoticeByID", 5).Return(&models.NoticeDetail{ID: 5, RecipientType: models.NoticeRecipientSpecific, RecipientRole: &teacher, FirstField: stringPtr("1")}, nil)
+			},
+			expectedOrder: []int{5, 1},
+		},
+		{
+			name:          "Unknown recipient role",
+			request:       NoticeDigestRequest{Group: &models.RecipientGroup{RoleID: 9}},
+			setupMocks:    func(m *MockNodeJSClient) { m.On("GetNoticeRecipients").Return([]models.NoticeRecipient{}, nil) },
+			expectedError: "invalid recipient group",
+		},
+		{
+			name:          "User and group are exclusive",
+			request:       NoticeDigestRequest{UserID: 3, Group: &models.RecipientGroup{RoleID: 2}},
+			expectedError: "invalid request",
+		},
+		{
+			name:          "Reversed date range",
+			request:       NoticeDigestRequest{From: march(31), To: march(1)},
+			expectedError: "invalid date range",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockPDFGen := new(MockPDFGenerator)
+
+			mockNodeClient.On("GetNotices", tt.request.UserID).Return(digestNotices(), nil)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockNodeClient)
+			}
+
+			var rendered *models.NoticeDigest
+			mockPDFGen.On("GenerateNoticeDigest", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { rendered = args.Get(0).(*models.NoticeDigest) }).
+				Return(filepath.Join(outputDir, "notices.pdf"), nil)
+
+			service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+			result, err := service.CreateNoticeDigestPDF(tt.request, ReportOptions{GeneratedBy: "Test User"})
+
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, result)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.expectedOrder), result.Notices)
+			assert.Equal(t, "Notice Board", rendered.Title)
+
+			order := make([]int, 0, len(rendered.Notices))
+			for _, item := range rendered.Notices {
+				order = append(order, item.Notice.ID)
+			}
+			assert.Equal(t, tt.expectedOrder, order)
+		})
+	}
+}
+
+func TestPDFReportService_CreateNoticeDigestPDF_Profile(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  NoticeDigestRequest
+		expected redaction.Profile
+	}{
+		{name: "Every notice", request: NoticeDigestRequest{}, expected: redaction.ProfileFull},
+		{name: "Notices of one user", request: NoticeDigestRequest{UserID: 3}, expected: redaction.ProfilePublicNotice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "notices_report_everyone.pdf")
+			mockNodeClient := new(MockNodeJSClient)
+			mockPDFGen := new(MockPDFGenerator)
+			mockNodeClient.On("GetNotices", tt.request.UserID).Return(digestNotices(), nil)
+			mockPDFGen.On("GenerateNoticeDigest", mock.Anything, mock.Anything).Return(filePath, nil)
+
+			service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+			_, err := service.CreateNoticeDigestPDF(tt.request, ReportOptions{Profile: redaction.ProfilePublicNotice})
+			assert.NoError(t, err)
+
+			metadata, err := retention.ReadMetadata(filePath)
+			if assert.NoError(t, err) {
+				assert.Equal(t, string(tt.expected), metadata.Profile)
+			}
+		})
+	}
+}
+
+func TestPDFReportService_CreateNoticeDigestPDF_DetailError(t *testing.T) {
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+
+	mockNodeClient.On("GetNotices", 0).Return(digestNotices()[:1], nil)
+	mockNodeClient.On("GetNoticeRecipients").Return([]models.NoticeRecipient{{RoleID: 3, RoleName: "Student"}}, nil)
+	mockNodeClient.On("GetNoticeByID", 1).Return(nil, errors.New("API Error 500"))
+
+	service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+	_, err := service.CreateNoticeDigestPDF(NoticeDigestRequest{Group: &models.RecipientGroup{RoleID: 3}}, ReportOptions{})
+
+	assert.ErrorContains(t, err, "failed to fetch notice 1")
+	mockPDFGen.AssertNotCalled(t, "GenerateNoticeDigest", mock.Anything, mock.Anything)
+}
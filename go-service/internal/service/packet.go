@@ -0,0 +1,226 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+)
+
+// packetMaxParts bounds the number of reports combined into one packet
+const packetMaxParts = 200
+
+// Packet item types
+const (
+	PacketItemStudent = "student"
+	PacketItemStaff   = "staff"
+	PacketItemLeave   = "leave"
+	PacketItemRoster  = "roster"
+	// PacketItemClass expands to the profile of every student in a class
+	PacketItemClass = "class"
+)
+
+// PacketItem selects one report, or for the class type one report per
+// student, to include in a packet
+type PacketItem struct {
+	Type string
+	ID   int
+
+	// ClassName, Section and SortBy select the students of roster and class items
+	ClassName string
+	Section   string
+	SortBy    string
+
+	// From and To limit the requests in a leave item. Zero values leave that
+	// end of the range open
+	From time.Time
+	To   time.Time
+}
+
+// PacketRequest lists the reports combined into a packet, in order
+type PacketRequest struct {
+	Title string
+	Items []PacketItem
+}
+
+// RequiresFullProfile reports whether the packet includes staff or leave
+// reports, which have no redacted variant
+func (req PacketRequest) RequiresFullProfile() bool {
+	for _, item := range req.Items {
+		if item.Type == PacketItemStaff || item.Type == PacketItemLeave {
+			return true
+		}
+	}
+	return false
+}
+
+// PacketReportResult represents the result of a report packet generation
+type PacketReportResult struct {
+	ReportID    string    `json:"report_id"`
+	Title       string    `json:"title"`
+	Reports     []string  `json:"reports"`
+	FilePath    string    `json:"file_path"`
+	GeneratedAt time.Time `json:"generated_at"`
+	GeneratedBy string    `json:"generated_by"`
+	Profile     string    `json:"profile"`
+	FileSize    int64     `json:"file_size"`
+}
+
+// CreatePacketPDF combines the requested reports into one PDF with a cover
+// page and table of contents
+func (ps *PDFReportService) CreatePacketPDF(req PacketRequest, opts ReportOptions) (*PacketReportResult, error) {
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("invalid packet: at least one report is required")
+	}
+	for i, item := range req.Items {
+		if err := validatePacketItem(item); err != nil {
+			return nil, fmt.Errorf("invalid packet item %d: %w", i+1, err)
+		}
+	}
+	if opts.Profile == "" {
+		opts.Profile = redaction.ProfileFull
+	}
+	if req.RequiresFullProfile() && opts.Profile != redaction.ProfileFull {
+		return nil, fmt.Errorf("invalid packet: staff and leave reports require the full profile")
+	}
+
+	result, err := ps.createPacketPDF(req, opts)
+	if err != nil {
+		ps.auditGeneration(opts, "report packet", "", "", err)
+		return nil, err
+	}
+
+	if err := ps.auditGeneration(opts, "", result.ReportID, result.FilePath, nil); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// validatePacketItem checks an item carries what its report type needs
+func validatePacketItem(item PacketItem) error {
+	switch item.Type {
+	case PacketItemStudent, PacketItemStaff, PacketItemLeave:
+		if item.ID <= 0 {
+			return fmt.Errorf("%s report needs a positive id", item.Type)
+		}
+		if !item.From.IsZero() && !item.To.IsZero() && item.To.Before(item.From) {
+			return fmt.Errorf("from must not be after to")
+		}
+	case PacketItemRoster, PacketItemClass:
+		if item.ClassName == "" {
+			return fmt.Errorf("%s report needs a class_name", item.Type)
+		}
+		if item.SortBy != "" && item.SortBy != models.RosterSortRoll && item.SortBy != models.RosterSortName {
+			return fmt.Errorf("sort order %q: expected roll or name", item.SortBy)
+		}
+	default:
+		return fmt.Errorf("unknown report type %q: expected student, staff, leave, roster or class", item.Type)
+	}
+	return nil
+}
+
+// createPacketPDF fetches the data for every item and renders the packet
+func (ps *PDFReportService) createPacketPDF(req PacketRequest, opts ReportOptions) (*PacketReportResult, error) {
+	packet := &models.ReportPacket{Title: req.Title}
+	for i, item := range req.Items {
+		parts, err := ps.buildPacketParts(item, opts.Profile)
+		if err != nil {
+			return nil, fmt.Errorf("packet item %d: %w", i+1, err)
+		}
+		packet.Parts = append(packet.Parts, parts...)
+		if len(packet.Parts) > packetMaxParts {
+			return nil, fmt.Errorf("invalid packet: more than %d reports", packetMaxParts)
+		}
+	}
+
+	metadata := &models.ReportMetadata{
+		GeneratedAt: time.Now(),
+		GeneratedBy: opts.GeneratedBy,
+		ReportID:    fmt.Sprintf("PKT-%d", time.Now().UnixNano()),
+		Profile:     string(opts.Profile),
+		Archival:    opts.Archival,
+		School:      ps.Config().Report.SchoolName,
+	}
+
+	filePath, err := ps.jobs.run(func() (string, error) {
+		return ps.pdfGenerator.GeneratePacket(packet, metadata)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF report: %w", err)
+	}
+
+	reports := make([]string, len(packet.Parts))
+	for i, part := range packet.Parts {
+		reports[i] = part.Title()
+	}
+
+	return &PacketReportResult{
+		ReportID:    metadata.ReportID,
+		Title:       req.Title,
+		Reports:     reports,
+		FilePath:    filePath,
+		GeneratedAt: metadata.GeneratedAt,
+		GeneratedBy: opts.GeneratedBy,
+		Profile:     string(opts.Profile),
+		FileSize:    ps.getActualFileSize(filePath),
+	}, nil
+}
+
+// buildPacketParts fetches the report data for one packet item
+func (ps *PDFReportService) buildPacketParts(item PacketItem, profile redaction.Profile) ([]models.PacketPart, error) {
+	sortBy := item.SortBy
+	if sortBy == "" {
+		sortBy = models.RosterSortRoll
+	}
+
+	switch item.Type {
+	case PacketItemStudent:
+		student, err := ps.fetchRedactedStudent(item.ID, profile)
+		if err != nil {
+			return nil, err
+		}
+		return []models.PacketPart{{Student: student}}, nil
+
+	case PacketItemStaff:
+		staff, err := ps.nodeClient.GetStaffByID(item.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch staff data: %w", err)
+		}
+		if staff == nil {
+			return nil, fmt.Errorf("staff with ID %d not found", item.ID)
+		}
+		return []models.PacketPart{{Staff: staff}}, nil
+
+	case PacketItemLeave:
+		report, err := ps.buildLeaveReport(item.ID, item.From, item.To)
+		if err != nil {
+			return nil, err
+		}
+		return []models.PacketPart{{Leave: report}}, nil
+
+	case PacketItemRoster:
+		roster, err := ps.buildClassRoster(item.ClassName, item.Section, sortBy, profile)
+		if err != nil {
+			return nil, err
+		}
+		return []models.PacketPart{{Roster: roster}}, nil
+
+	default:
+		roster, err := ps.buildClassRoster(item.ClassName, item.Section, sortBy, profile)
+		if err != nil {
+			return nil, err
+		}
+		if len(roster.Students) == 0 {
+			return nil, fmt.Errorf("no students found in class %s", item.ClassName)
+		}
+		parts := make([]models.PacketPart, len(roster.Students))
+		for i := range roster.Students {
+			student := &roster.Students[i]
+			student.Photo = ps.studentPhoto(student.ID, profile)
+			parts[i] = models.PacketPart{Student: student}
+		}
+		return parts, nil
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPDFReportService_CreatePacketPDF(t *testing.T) {
+	outputDir := t.TempDir()
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+
+	mockNodeClient.On("GetAllStudents", map[string]string{"className": "Grade 5"}).
+		Return([]models.StudentListItem{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}, nil)
+	for id, student := range rosterStudents() {
+		mockNodeClient.On("GetStudentByID", id).Return(student, nil)
+	}
+	mockNodeClient.On("GetLeaveHistory", 5).Return(testLeaveHistory(), nil)
+	mockNodeClient.On("GetLeavePolicies").Return(testLeavePolicies(), nil)
+
+	var rendered *models.ReportPacket
+	mockPDFGen.On("GeneratePacket", mock.Anything, mock.AnythingOfType("*models.ReportMetadata")).
+		Run(func(args mock.Arguments) { rendered = args.Get(0).(*models.ReportPacket) }).
+		Return(filepath.Join(outputDir, "packet.pdf"), nil)
+
+	service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+	result, err := service.CreatePacketPDF(PacketRequest{
+		Title: "Parent-Teacher Meeting",
+		Items: []PacketItem{
+			{Type: PacketItemStudent, ID: 2},
+			{Type: PacketItemLeave, ID: 5},
+			{Type: PacketItemClass, ClassName: "Grade 5"},
+		},
+	}, ReportOptions{GeneratedBy: "Test User"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Parent-Teacher Meeting", rendered.Title)
+	assert.Equal(t, []string{
+		"Student Profile: Alice",
+		"Leave History: Jane Teacher",
+		"Student Profile: alice",
+		"Student Profile: Alice",
+		"Student Profile: charlie",
+		"Student Profile: Bob",
+	}, result.Reports, "class items expand to one profile per student in roll order")
+	assert.Equal(t, "full", result.Profile)
+	assert.Contains(t, result.ReportID, "PKT-")
+	mockPDFGen.AssertExpectations(t)
+}
+
+func TestPDFReportService_CreatePacketPDF_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		req           PacketRequest
+		profile       redaction.Profile
+		setupMocks    func(*MockNodeJSClient)
+		errorContains string
+	}{
+		{name: "No items", errorContains: "at least one report"},
+		{
+			name:          "Unknown type",
+			req:           PacketRequest{Items: []PacketItem{{Type: "invoice", ID: 1}}},
+			errorContains: `invalid packet item 1: unknown report type "invoice"`,
+		},
+		{
+			name:          "Missing id",
+			req:           PacketRequest{Items: []PacketItem{{Type: PacketItemStudent, ID: 1}, {Type: PacketItemStaff}}},
+			errorContains: "invalid packet item 2: staff report needs a positive id",
+		},
+		{
+			name:          "Roster without a class",
+			req:           PacketRequest{Items: []PacketItem{{Type: PacketItemRoster}}},
+			errorContains: "needs a class_name",
+		},
+		{
+			name:          "Leave with a redacted profile",
+			req:           PacketRequest{Items: []PacketItem{{Type: PacketItemLeave, ID: 5}}},
+			profile:       redaction.ProfileParentFacing,
+			errorContains: "require the full profile",
+		},
+		{
+			name: "Missing student",
+			req:  PacketRequest{Items: []PacketItem{{Type: PacketItemStudent, ID: 9}}},
+			setupMocks: func(nodeClient *MockNodeJSClient) {
+				nodeClient.On("GetStudentByID", 9).Return(nil, nil)
+			},
+			errorContains: "packet item 1: student with ID 9 not found",
+		},
+		{
+			name: "Backend failure",
+			req:  PacketRequest{Items: []PacketItem{{Type: PacketItemClass, ClassName: "Grade 5"}}},
+			setupMocks: func(nodeClient *MockNodeJSClient) {
+				nodeClient.On("GetAllStudents", mock.Anything).Return(nil, errors.New("connection refused"))
+			},
+			errorContains: "failed to fetch students list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockPDFGen := new(MockPDFGenerator)
+			if tt.setupMocks != nil {
+				tt.setupMocks(mockNodeClient)
+			}
+
+			service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+			result, err := service.CreatePacketPDF(tt.req, ReportOptions{Profile: tt.profile})
+
+			assert.Nil(t, result)
+			assert.ErrorContains(t, err, tt.errorContains)
+			mockPDFGen.AssertNotCalled(t, "GeneratePacket", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"student-report-service/internal/imaging"
+	"student-report-service/internal/redaction"
+)
+
+const (
+	// photoMaxDimension bounds photos to about 300 dpi at the printed size
+	photoMaxDimension = 480
+	// photoMaxBytes bounds a prepared photo so class packets stay small
+	photoMaxBytes = 200 << 10
+)
+
+// photoExtensions are tried in order when looking up a photo file
+var photoExtensions = []string{".jpg", ".jpeg", ".png"}
+
+// studentPhoto returns a student's prepared photo, or nil when photos are
+// disabled, withheld by the profile, missing or unusable. Photo problems never
+// fail a report; the report shows a placeholder instead
+func (ps *PDFReportService) studentPhoto(studentID int, profile redaction.Profile) []byte {
+	cfg := &ps.Config().Report
+	if !cfg.PhotosEnabled() || !redaction.ShowsPhoto(profile) {
+		return nil
+	}
+
+	data, err := ps.readStudentPhoto(studentID)
+	if err != nil || data == nil {
+		return nil
+	}
+
+	maxBytes := int64(photoMaxBytes)
+	if cfg.MaxFileSize > 0 && cfg.MaxFileSize/8 < maxBytes {
+		maxBytes = cfg.MaxFileSize / 8
+	}
+	photo, err := imaging.Prepare(data, imaging.Options{MaxDimension: photoMaxDimension, MaxBytes: maxBytes})
+	if err != nil {
+		return nil
+	}
+	return photo.Data
+}
+
+// readStudentPhoto looks for the photo in the photo directory
+func (ps *PDFReportService) readStudentPhoto(studentID int) ([]byte, error) {
+	photoDir := ps.Config().Report.PhotoDir
+	for _, ext := range photoExtensions {
+		path := filepath.Join(photoDir, fmt.Sprintf("%d%s", studentID, ext))
+		if _, err := os.Stat(path); err == nil {
+			return imaging.Read(path, 0)
+		}
+	}
+	return nil, nil
+}
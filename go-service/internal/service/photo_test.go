@@ -0,0 +1,65 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPDFReportService_StudentPhoto(t *testing.T) {
+	var source bytes.Buffer
+	require.NoError(t, png.Encode(&source, image.NewRGBA(image.Rect(0, 0, 960, 1200))))
+
+	photoDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(photoDir, "7.png"), source.Bytes(), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(photoDir, "8.jpg"), []byte("not a photo"), 0644))
+
+	tests := []struct {
+		name        string
+		studentID   int
+		profile     redaction.Profile
+		photoDir    string
+		expectPhoto bool
+	}{
+		{name: "Photos disabled", studentID: 7, profile: redaction.ProfileFull},
+		{name: "Found in the photo directory", studentID: 7, profile: redaction.ProfileFull, photoDir: photoDir, expectPhoto: true},
+		{name: "Kept for parent-facing reports", studentID: 7, profile: redaction.ProfileParentFacing, photoDir: photoDir, expectPhoto: true},
+		{name: "Withheld from public notices", studentID: 7, profile: redaction.ProfilePublicNotice, photoDir: photoDir},
+		{name: "Invalid file falls back to the placeholder", studentID: 8, profile: redaction.ProfileFull, photoDir: photoDir},
+		{name: "Missing file falls back to the placeholder", studentID: 9, profile: redaction.ProfileFull, photoDir: photoDir},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockNodeClient.On("GetStudentByID", tt.studentID).Return(&models.Student{ID: tt.studentID, Name: "Ana"}, nil)
+
+			service := NewPDFReportService(mockNodeClient, new(MockPDFGenerator), &config.Config{
+				Report: config.ReportConfig{MaxFileSize: 10 * 1024 * 1024, PhotoDir: tt.photoDir},
+			})
+			student, err := service.fetchRedactedStudent(tt.studentID, tt.profile)
+			require.NoError(t, err)
+
+			if !tt.expectPhoto {
+				assert.Nil(t, student.Photo)
+				return
+			}
+			cfg, err := jpeg.DecodeConfig(bytes.NewReader(student.Photo))
+			require.NoError(t, err, "photos are prepared as JPEG")
+			assert.Equal(t, photoMaxDimension, cfg.Height)
+			assert.Equal(t, photoMaxDimension*4/5, cfg.Width)
+			mockNodeClient.AssertExpectations(t)
+		})
+	}
+}
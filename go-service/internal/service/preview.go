@@ -0,0 +1,65 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"student-report-service/internal/audit"
+	"student-report-service/internal/content"
+	"student-report-service/internal/models"
+	"student-report-service/internal/preview"
+	"student-report-service/internal/redaction"
+)
+
+// PreviewStudentHTML renders the student report as an HTML page from the same
+// content as the PDF. Nothing is written to the output directory.
+func (ps *PDFReportService) PreviewStudentHTML(studentID int, opts ReportOptions) ([]byte, error) {
+	if studentID <= 0 {
+		return nil, fmt.Errorf("invalid student ID: %d", studentID)
+	}
+
+	if opts.Profile == "" {
+		opts.Profile = redaction.ProfileFull
+	}
+
+	page, reportID, err := ps.previewStudentHTML(studentID, opts)
+
+	record := ps.newAuditRecord(audit.ActionPreview, studentID, opts)
+	if err != nil {
+		record.Outcome = audit.OutcomeFailure
+		record.Details = err.Error()
+		ps.auditStore.Append(record)
+		return nil, err
+	}
+
+	record.ReportID = reportID
+	if err := ps.auditStore.Append(record); err != nil {
+		return nil, fmt.Errorf("failed to write audit record: %w", err)
+	}
+
+	return page, nil
+}
+
+func (ps *PDFReportService) previewStudentHTML(studentID int, opts ReportOptions) ([]byte, string, error) {
+	student, err := ps.fetchRedactedStudent(studentID, opts.Profile)
+	if err != nil {
+		return nil, "", err
+	}
+
+	metadata := &models.ReportMetadata{
+		GeneratedAt: time.Now(),
+		GeneratedBy: opts.GeneratedBy,
+		ReportID:    fmt.Sprintf("PRV-%d-%d", studentID, time.Now().Unix()),
+		Profile:     string(opts.Profile),
+		School:      ps.Config().Report.SchoolName,
+	}
+
+	var page bytes.Buffer
+	renderer := preview.NewRenderer(ps.Config().Report.WatermarkText)
+	if err := renderer.Render(&page, content.NewStudentDocument(student, metadata)); err != nil {
+		return nil, "", err
+	}
+
+	return page.Bytes(), metadata.ReportID, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"student-report-service/internal/audit"
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPDFReportService_PreviewStudentHTML(t *testing.T) {
+	store, err := audit.NewFileStore(filepath.Join(t.TempDir(), "audit.jsonl"), false)
+	require.NoError(t, err)
+	defer store.Close()
+
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+	mockNodeClient.On("GetStudentByID", 1).Return(&models.Student{
+		ID:          1,
+		Name:        "John Doe",
+		Email:       "john@example.com",
+		FatherPhone: stringPtr("9800000002"),
+	}, nil)
+	mockNodeClient.On("GetStudentByID", 2).Return(nil, errors.New("API Error 404: Student not found"))
+
+	cfg := &config.Config{Report: config.ReportConfig{WatermarkText: "Confidential"}}
+	service := NewPDFReportService(mockNodeClient, mockPDFGen, cfg)
+	service.SetAuditStore(store)
+
+	page, err := service.PreviewStudentHTML(1, ReportOptions{GeneratedBy: "alice", Profile: redaction.ProfilePublicNotice})
+	require.NoError(t, err)
+	html := string(page)
+	assert.Contains(t, html, "John Doe")
+	assert.NotContains(t, html, "john@example.com", "the redaction profile applies to previews")
+	assert.Contains(t, html, "Redaction profile: public-notice")
+	assert.Contains(t, html, "Confidential")
+
+	_, err = service.PreviewStudentHTML(2, ReportOptions{GeneratedBy: "alice"})
+	assert.Error(t, err)
+
+	records, err := service.QueryAudit(audit.Filter{Action: audit.ActionPreview})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, 1, records[0].StudentID)
+	assert.Equal(t, audit.OutcomeSuccess, records[0].Outcome)
+	assert.Equal(t, audit.OutcomeFailure, records[1].Outcome)
+
+	// Previews never touch the PDF generator
+	mockPDFGen.AssertNotCalled(t, "GenerateStudentReport", mock.Anything, mock.Anything)
+}
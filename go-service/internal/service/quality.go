@@ -0,0 +1,194 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+)
+
+// QualityReportResult represents the result of a data checklist generation
+type QualityReportResult struct {
+	ReportID    string    `json:"report_id"`
+	ClassName   string    `json:"class_name,omitempty"`
+	Section     string    `json:"section,omitempty"`
+	Students    int       `json:"students"`
+	Incomplete  int       `json:"incomplete"`
+	FilePath    string    `json:"file_path"`
+	GeneratedAt time.Time `json:"generated_at"`
+	GeneratedBy string    `json:"generated_by"`
+	FileSize    int64     `json:"file_size"`
+}
+
+// CheckStudentQuality scans students, optionally limited to a class or section,
+// for missing required fields. Without fields, STUDENT_REQUIRED_FIELDS applies,
+// falling back to models.DefaultRequiredStudentFields.
+func (ps *PDFReportService) CheckStudentQuality(className, section string, fields []string) (*models.QualityReport, error) {
+	if len(fields) == 0 {
+		fields = ps.Config().Report.RequiredFields
+	}
+	if len(fields) == 0 {
+		fields = models.DefaultRequiredStudentFields
+	}
+	required, err := models.ResolveStudentFields(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make(map[string]string)
+	if className != "" {
+		filters["className"] = className
+	}
+	if section != "" {
+		filters["section"] = section
+	}
+
+	items, err := ps.nodeClient.GetAllStudents(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch students list: %w", err)
+	}
+
+	// The list endpoint omits most of the checked fields
+	students, err := ps.fetchStudents(items)
+	if err != nil {
+		return nil, err
+	}
+
+	report := buildQualityReport(students, required)
+	report.ClassName = className
+	report.Section = section
+	return report, nil
+}
+
+// CreateQualityPDF renders the checklist of incomplete student records
+func (ps *PDFReportService) CreateQualityPDF(report *models.QualityReport, opts ReportOptions) (*QualityReportResult, error) {
+	// The checklist names missing fields, not their values, so any role may
+	// download it
+	opts.Profile = redaction.ProfilePublicNotice
+	metadata := &models.ReportMetadata{
+		GeneratedAt: time.Now(),
+		GeneratedBy: opts.GeneratedBy,
+		ReportID:    fmt.Sprintf("DQC-%d", time.Now().UnixNano()),
+		Archival:    opts.Archival,
+		School:      ps.Config().Report.SchoolName,
+	}
+
+	filePath, err := ps.jobs.run(func() (string, error) {
+		return ps.pdfGenerator.GenerateQualityChecklist(report, metadata)
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to generate PDF report: %w", err)
+		ps.auditGeneration(opts, "student data checklist", "", "", err)
+		return nil, err
+	}
+
+	if err := ps.auditGeneration(opts, "", metadata.ReportID, filePath, nil); err != nil {
+		return nil, err
+	}
+
+	return &QualityReportResult{
+		ReportID:    metadata.ReportID,
+		ClassName:   report.ClassName,
+		Section:     report.Section,
+		Students:    report.Students,
+		Incomplete:  len(report.Incomplete),
+		FilePath:    filePath,
+		GeneratedAt: metadata.GeneratedAt,
+		GeneratedBy: opts.GeneratedBy,
+		FileSize:    ps.getActualFileSize(filePath),
+	}, nil
+}
+
+// WriteQualityCSV writes the checklist as CSV with one row per incomplete student
+func WriteQualityCSV(w io.Writer, report *models.QualityReport) error {
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"Done", "Class", "Section", "Roll", "Student ID", "Name", "Missing Fields"})
+
+	for _, student := range report.Incomplete {
+		roll := ""
+		if student.Roll != nil {
+			roll = strconv.Itoa(*student.Roll)
+		}
+		writer.Write([]string{
+			"",
+			student.Class,
+			student.Section,
+			roll,
+			strconv.Itoa(student.StudentID),
+			student.Name,
+			strings.Join(student.Missing, "; "),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// buildQualityReport checks every student against the required fields
+func buildQualityReport(students []models.Student, required []models.StudentField) *models.QualityReport {
+	report := &models.QualityReport{
+		GeneratedAt:    time.Now(),
+		RequiredFields: make([]string, 0, len(required)),
+		Students:       len(students),
+		MissingByField: make(map[string]int),
+		Incomplete:     []models.StudentQuality{},
+	}
+	for _, field := range required {
+		report.RequiredFields = append(report.RequiredFields, field.Label)
+		report.MissingByField[field.Label] = 0
+	}
+
+	for i := range students {
+		student := &students[i]
+
+		var missing []string
+		for _, field := range required {
+			if !field.Present(student) {
+				missing = append(missing, field.Label)
+				report.MissingByField[field.Label]++
+			}
+		}
+		if len(missing) == 0 {
+			report.Complete++
+			continue
+		}
+
+		report.Incomplete = append(report.Incomplete, models.StudentQuality{
+			StudentID: student.ID,
+			Name:      student.FormatName(),
+			Class:     models.SafeString(student.Class, ""),
+			Section:   models.SafeString(student.Section, ""),
+			Roll:      student.Roll,
+			Missing:   missing,
+		})
+	}
+
+	sortChecklist(report.Incomplete)
+	return report
+}
+
+// sortChecklist orders the checklist by class, section, roll (unassigned last) and name
+func sortChecklist(items []models.StudentQuality) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.Class != b.Class {
+			return a.Class < b.Class
+		}
+		if a.Section != b.Section {
+			return a.Section < b.Section
+		}
+		if (a.Roll == nil) != (b.Roll == nil) {
+			return a.Roll != nil
+		}
+		if a.Roll != nil && *a.Roll != *b.Roll {
+			return *a.Roll < *b.Roll
+		}
+		return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+	})
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func qualityStudents() []models.Student {
+	return []models.Student{
+		{ID: 1, Name: "Zara", Class: stringPtr("Grade 5"), Section: stringPtr("A"), Roll: intPtr(2),
+			DOB: stringPtr("2014-06-01"), GuardianPhone: stringPtr("9800000001")},
+		{ID: 2, Name: "Bina", Class: stringPtr("Grade 5"), Section: stringPtr("A"),
+			GuardianPhone: stringPtr(" ")},
+		{ID: 3, Name: "Arun", Class: stringPtr("Grade 5"), Section: stringPtr("A"), Roll: intPtr(1),
+			DOB: stringPtr("2014-02-11")},
+		{ID: 4, Name: "Chen", Class: stringPtr("Grade 4"), Section: stringPtr("B"), Roll: intPtr(7),
+			DOB: stringPtr("2015-01-20"), GuardianPhone: stringPtr("9800000002")},
+	}
+}
+
+func TestBuildQualityReport(t *testing.T) {
+	required, err := models.ResolveStudentFields([]string{"dob", "guardianPhone", "roll"})
+	require.NoError(t, err)
+
+	report := buildQualityReport(qualityStudents(), required)
+
+	assert.Equal(t, 4, report.Students)
+	assert.Equal(t, 2, report.Complete)
+	assert.Equal(t, []string{"Date of Birth", "Guardian's Phone", "Roll Number"}, report.RequiredFields)
+	assert.Equal(t, map[string]int{"Date of Birth": 1, "Guardian's Phone": 2, "Roll Number": 1}, report.MissingByField)
+
+	require.Len(t, report.Incomplete, 2)
+	assert.Equal(t, 3, report.Incomplete[0].StudentID, "ordered by roll with unassigned rolls last")
+	assert.Equal(t, []string{"Guardian's Phone"}, report.Incomplete[0].Missing)
+	assert.Equal(t, 2, report.Incomplete[1].StudentID)
+	assert.Equal(t, []string{"Date of Birth", "Guardian's Phone", "Roll Number"}, report.Incomplete[1].Missing)
+}
+
+func TestPDFReportService_CheckStudentQuality(t *testing.T) {
+	tests := []struct {
+		name          string
+		fields        []string
+		configured    []string
+		expectedError string
+		expectedLabel []string
+	}{
+		{
+			name:          "Explicit fields",
+			fields:        []string{"dob"},
+			configured:    []string{"roll"},
+			expectedLabel: []string{"Date of Birth"},
+		},
+		{
+			name:          "Configured fields",
+			configured:    []string{"roll"},
+			expectedLabel: []string{"Roll Number"},
+		},
+		{
+			name:          "Default fields",
+			expectedLabel: []string{"Date of Birth", "Guardian's Phone", "Roll Number", "Admission Date", "Current Address", "Permanent Address"},
+		},
+		{
+			name:          "Unknown field",
+			fields:        []string{"dob", "shoeSize"},
+			expectedError: "invalid required fields: shoeSize",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			filters := map[string]string{"className": "Grade 5"}
+			students := qualityStudents()
+			mockNodeClient.On("GetAllStudents", filters).Return([]models.StudentListItem{{ID: 1}}, nil)
+			mockNodeClient.On("GetStudentByID", 1).Return(&students[0], nil)
+
+			cfg := &config.Config{Report: config.ReportConfig{RequiredFields: tt.configured}}
+			service := NewPDFReportService(mockNodeClient, new(MockPDFGenerator), cfg)
+
+			report, err := service.CheckStudentQuality("Grade 5", "", tt.fields)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				mockNodeClient.AssertNotCalled(t, "GetAllStudents", filters)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedLabel, report.RequiredFields)
+			assert.Equal(t, "Grade 5", report.ClassName)
+			assert.Equal(t, 1, report.Students)
+		})
+	}
+}
+
+func TestWriteQualityCSV(t *testing.T) {
+	report := &models.QualityReport{
+		Incomplete: []models.StudentQuality{
+			{StudentID: 3, Name: "Arun", Class: "Grade 5", Section: "A", Roll: intPtr(1), Missing: []string{"Guardian's Phone"}},
+			{StudentID: 2, Name: "Bina, K", Class: "Grade 5", Section: "A", Missing: []string{"Date of Birth", "Roll Number"}},
+		},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, WriteQualityCSV(&buffer, report))
+
+	expected := "Done,Class,Section,Roll,Student ID,Name,Missing Fields\n" +
+		",Grade 5,A,1,3,Arun,Guardian's Phone\n" +
+		",Grade 5,A,,2,\"Bina, K\",Date of Birth; Roll Number\n"
+	assert.Equal(t, expected, buffer.String())
+}
@@ -3,145 +3,414 @@ package service
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"student-report-service/internal/audit"
 	"student-report-service/internal/client"
 	"student-report-service/internal/config"
+	"student-report-service/internal/docx"
 	"student-report-service/internal/models"
 	"student-report-service/internal/pdf"
+	"student-report-service/internal/redaction"
+	"student-report-service/internal/retention"
+	"student-report-service/internal/snapshot"
 )
 
 // PDFReportService orchestrates the student report generation process
 type PDFReportService struct {
-	nodeClient   NodeJSClientInterface
-	pdfGenerator PDFGeneratorInterface
-	config       *config.Config
+	nodeClient    NodeJSClientInterface
+	pdfGenerator  PDFGeneratorInterface
+	docGenerator  DocumentGeneratorInterface
+	config        atomic.Pointer[config.Config]
+	auditStore    audit.Store
+	mailer        ReportMailerInterface
+	snapshotStore snapshot.Store
+	analytics     analyticsCache
+	jobs          *jobQueue
 }
 
 // NewPDFReportService creates a new report service
 func NewPDFReportService(nodeClient NodeJSClientInterface, pdfGenerator PDFGeneratorInterface, cfg *config.Config) *PDFReportService {
-	return &PDFReportService{
-		nodeClient:   nodeClient,
-		pdfGenerator: pdfGenerator,
-		config:       cfg,
+	ps := &PDFReportService{
+		nodeClient:    nodeClient,
+		pdfGenerator:  pdfGenerator,
+		docGenerator:  docx.NewGenerator(&cfg.Report),
+		auditStore:    audit.NopStore{},
+		snapshotStore: snapshot.NopStore{},
+		jobs:          newJobQueue(cfg.Report.MaxJobs, cfg.Report.MaxQueuedJobs),
 	}
+	ps.config.Store(cfg)
+	return ps
 }
 
 // NewPDFReportServiceWithConcreteTypes creates a new report service with concrete types (for production use)
 func NewPDFReportServiceWithConcreteTypes(nodeClient *client.NodeJSClient, pdfGenerator *pdf.Generator, cfg *config.Config) *PDFReportService {
-	return &PDFReportService{
-		nodeClient:   nodeClient,
-		pdfGenerator: pdfGenerator,
-		config:       cfg,
+	ps := &PDFReportService{
+		nodeClient:    nodeClient,
+		pdfGenerator:  pdfGenerator,
+		docGenerator:  docx.NewGenerator(&cfg.Report),
+		auditStore:    audit.NopStore{},
+		snapshotStore: snapshot.NopStore{},
+		jobs:          newJobQueue(cfg.Report.MaxJobs, cfg.Report.MaxQueuedJobs),
 	}
+	ps.config.Store(cfg)
+	return ps
+}
+
+// Config returns the configuration in effect
+func (ps *PDFReportService) Config() *config.Config {
+	return ps.config.Load()
+}
+
+// UpdateConfig switches the service, its generators and its backend client
+// to a reloaded configuration. Reports already being generated finish with
+// the configuration they started with where they read it once
+func (ps *PDFReportService) UpdateConfig(cfg *config.Config) {
+	if cfg == nil {
+		return
+	}
+	ps.config.Store(cfg)
+
+	if updater, ok := ps.pdfGenerator.(reportConfigUpdater); ok {
+		updater.UpdateConfig(&cfg.Report)
+	}
+	if updater, ok := ps.docGenerator.(reportConfigUpdater); ok {
+		updater.UpdateConfig(&cfg.Report)
+	}
+	if updater, ok := ps.nodeClient.(clientConfigUpdater); ok {
+		updater.UpdateConfig(&cfg.NodeJS)
+	}
+}
+
+// SetAuditStore replaces the audit store used to record report access
+func (ps *PDFReportService) SetAuditStore(store audit.Store) {
+	if store == nil {
+		store = audit.NopStore{}
+	}
+	ps.auditStore = store
+}
+
+// VerifyAuditLog checks the audit log's hash chain for tampering. It returns
+// audit.ErrNotChained when the log has no chain to check
+func (ps *PDFReportService) VerifyAuditLog() error {
+	verifier, ok := ps.auditStore.(audit.Verifier)
+	if !ok {
+		return audit.ErrNotChained
+	}
+	return verifier.Verify()
+}
+
+// Student report file formats
+const (
+	FormatPDF  = "pdf"
+	FormatDOCX = "docx"
+)
+
+// ReportOptions carries per-request settings for report generation
+type ReportOptions struct {
+	GeneratedBy string
+	Profile     redaction.Profile
+
+	// Format selects the student report file format, PDF when empty
+	Format string
+
+	// Archival requests PDF/A-2b output for long-term storage
+	Archival bool
+
+	// Tags label generated reports for the tag retention rules
+	Tags []string
+
+	// Request context recorded in the audit log
+	ClientIP  string
+	RequestID string
 }
 
 // GetAllStudents retrieves a list of all students with optional filtering
-func (ps *PDFReportService) GetAllStudents(filters map[string]string) ([]models.StudentListItem, error) {
+func (ps *PDFReportService) GetAllStudents(filters map[string]string, profile redaction.Profile) ([]models.StudentListItem, error) {
 	students, err := ps.nodeClient.GetAllStudents(filters)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch students list: %w", err)
 	}
 
-	return students, nil
+	return redaction.ApplyListItems(profile, students), nil
 }
 
 // CreateStudentPDF generates a complete student report
-func (ps *PDFReportService) CreateStudentPDF(studentID int, generatedBy string) (*PDFReportResult, error) {
+func (ps *PDFReportService) CreateStudentPDF(studentID int, opts ReportOptions) (*PDFReportResult, error) {
 	if studentID <= 0 {
 		return nil, fmt.Errorf("invalid student ID: %d", studentID)
 	}
 
-	// Step 1: Fetch student data from Node.js API
-	student, err := ps.nodeClient.GetStudentByID(studentID)
+	if opts.Profile == "" {
+		opts.Profile = redaction.ProfileFull
+	}
+
+	result, err := ps.createStudentPDF(studentID, opts)
+
+	record := ps.newAuditRecord(audit.ActionGenerate, studentID, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch student data: %w", err)
+		record.Outcome = audit.OutcomeFailure
+		record.Details = err.Error()
+		ps.auditStore.Append(record)
+		return nil, err
 	}
 
-	if student == nil {
-		return nil, fmt.Errorf("student with ID %d not found", studentID)
+	record.ReportID = result.ReportID
+	record.Details = filepath.Base(result.FilePath)
+	record.Tags = opts.Tags
+	if err := ps.auditStore.Append(record); err != nil {
+		return nil, fmt.Errorf("failed to write audit record: %w", err)
+	}
+
+	if err := ps.writeReportMetadata(result.FilePath, result.ReportID, opts); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createStudentPDF fetches, redacts and renders the student report
+func (ps *PDFReportService) createStudentPDF(studentID int, opts ReportOptions) (*PDFReportResult, error) {
+	// Steps 1-2: Fetch student data and redact it for the requested audience
+	student, err := ps.fetchRedactedStudent(studentID, opts.Profile)
+	if err != nil {
+		return nil, err
 	}
 
-	// Step 2: Create report metadata
+	// Step 3: Create report metadata
 	metadata := &models.ReportMetadata{
 		GeneratedAt: time.Now(),
-		GeneratedBy: generatedBy,
+		GeneratedBy: opts.GeneratedBy,
 		ReportID:    fmt.Sprintf("RPT-%d-%d", studentID, time.Now().Unix()),
+		Profile:     string(opts.Profile),
+		Archival:    opts.Archival,
+		School:      ps.Config().Report.SchoolName,
+	}
+
+	// Step 4: Generate the report file
+	format := opts.Format
+	if format == "" {
+		format = FormatPDF
 	}
 
-	// Step 3: Generate PDF report
-	filePath, err := ps.pdfGenerator.GenerateStudentReport(student, metadata)
+	var filePath string
+	switch format {
+	case FormatPDF:
+		filePath, err = ps.jobs.run(func() (string, error) {
+			return ps.pdfGenerator.GenerateStudentReport(student, metadata)
+		})
+	case FormatDOCX:
+		filePath, err = ps.jobs.run(func() (string, error) {
+			return ps.docGenerator.GenerateStudentReport(student, metadata)
+		})
+	default:
+		return nil, fmt.Errorf("invalid report format: %s", format)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate PDF report: %w", err)
+		return nil, fmt.Errorf("failed to generate %s report: %w", strings.ToUpper(format), err)
 	}
 
-	// Step 4: Get actual file size
+	// Step 5: Get actual file size
 	fileSize := ps.getActualFileSize(filePath)
 
-	// Step 5: Create result
+	// Step 6: Create result
 	result := &PDFReportResult{
 		ReportID:    metadata.ReportID,
 		StudentID:   studentID,
 		StudentName: student.FormatName(),
 		FilePath:    filePath,
 		GeneratedAt: metadata.GeneratedAt,
-		GeneratedBy: generatedBy,
+		GeneratedBy: opts.GeneratedBy,
+		Profile:     string(opts.Profile),
+		Format:      format,
 		FileSize:    fileSize,
 	}
 
 	return result, nil
 }
 
-// HealthCheck performs a comprehensive health check
-func (ps *PDFReportService) HealthCheck() *ServiceHealthStatus {
-	status := &ServiceHealthStatus{
-		Service:    "Report Service",
-		Timestamp:  time.Now(),
-		Healthy:    true,
-		Components: make(map[string]ComponentStatus),
+// fetchRedactedStudent fetches a student and applies the redaction profile
+func (ps *PDFReportService) fetchRedactedStudent(studentID int, profile redaction.Profile) (*models.Student, error) {
+	student, err := ps.nodeClient.GetStudentByID(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch student data: %w", err)
 	}
 
-	// Check Node.js API connectivity
-	if err := ps.nodeClient.HealthCheck(); err != nil {
-		status.Healthy = false
-		status.Components["nodejs_api"] = ComponentStatus{
-			Status:  "unhealthy",
-			Message: err.Error(),
-		}
-	} else {
-		status.Components["nodejs_api"] = ComponentStatus{
-			Status:  "healthy",
-			Message: "API is responsive",
-		}
+	if student == nil {
+		return nil, fmt.Errorf("student with ID %d not found", studentID)
 	}
+	student.Photo = ps.studentPhoto(studentID, profile)
 
-	// Check PDF generator (output directory)
-	if generator := ps.pdfGenerator; generator != nil {
-		status.Components["pdf_generator"] = ComponentStatus{
-			Status:  "healthy",
-			Message: "Generator is ready",
-		}
-	} else {
-		status.Healthy = false
-		status.Components["pdf_generator"] = ComponentStatus{
-			Status:  "unhealthy",
-			Message: "Generator not initialized",
-		}
+	return redaction.ApplyStudent(profile, student), nil
+}
+
+// ResolveProfile picks the redaction profile for a caller, using the configured
+// default role when the request does not carry one
+func (ps *PDFReportService) ResolveProfile(role, requested string) (redaction.Profile, error) {
+	if role == "" {
+		role = ps.Config().Report.DefaultRole
 	}
+	return redaction.Resolve(role, requested)
+}
 
-	// Set overall status message
-	if status.Healthy {
-		status.Message = "All systems operational"
-	} else {
-		status.Message = "Some components are unhealthy"
+// CleanupOldReports applies the retention policy and records each deletion.
+// Dry runs are not audited because nothing is removed.
+func (ps *PDFReportService) CleanupOldReports(opts ReportOptions, dryRun bool) (*retention.Summary, error) {
+	summary, err := ps.pdfGenerator.CleanupOldReports(dryRun)
+	if err != nil {
+		record := ps.newAuditRecord(audit.ActionCleanup, 0, opts)
+		record.Outcome = audit.OutcomeFailure
+		record.Details = err.Error()
+		ps.auditStore.Append(record)
+		return nil, fmt.Errorf("failed to cleanup reports: %w", err)
+	}
+
+	if dryRun {
+		return summary, nil
+	}
+
+	for _, entry := range summary.Deleted {
+		record := ps.newAuditRecord(audit.ActionCleanup, studentIDFromFilename(entry.Path), opts)
+		record.Details = fmt.Sprintf("%s (%s)", filepath.Base(entry.Path), entry.Reason)
+		ps.auditStore.Append(record)
+	}
+	for _, entry := range summary.Failed {
+		record := ps.newAuditRecord(audit.ActionCleanup, studentIDFromFilename(entry.Path), opts)
+		record.Outcome = audit.OutcomeFailure
+		record.Details = fmt.Sprintf("%s: %s", filepath.Base(entry.Path), entry.Error)
+		ps.auditStore.Append(record)
+	}
+
+	return summary, nil
+}
+
+// OpenReport resolves a generated report file for download and records the
+// access. The caller role must be allowed the profile the report was generated with.
+func (ps *PDFReportService) OpenReport(filename, role string, opts ReportOptions) (string, error) {
+	record := ps.newAuditRecord(audit.ActionDownload, studentIDFromFilename(filename), opts)
+	record.Details = filename
+
+	path, err := ps.resolveReportPath(filename)
+	if err == nil {
+		err = ps.authorizeReport(path, role)
+	}
+	if err != nil {
+		record.Outcome = audit.OutcomeFailure
+		record.Details = fmt.Sprintf("%s: %v", filename, err)
+		ps.auditStore.Append(record)
+		return "", err
+	}
+
+	if err := ps.auditStore.Append(record); err != nil {
+		return "", fmt.Errorf("failed to write audit record: %w", err)
+	}
+
+	return path, nil
+}
+
+// QueryAudit returns audit records matching the filter
+func (ps *PDFReportService) QueryAudit(filter audit.Filter) ([]audit.Record, error) {
+	return ps.auditStore.Query(filter)
+}
+
+// authorizeReport checks the caller role against the profile stored in the
+// report's metadata. Reports without readable metadata, such as those generated
+// before metadata was written, need the full profile.
+func (ps *PDFReportService) authorizeReport(path, role string) error {
+	profile := redaction.ProfileFull
+	if metadata, err := retention.ReadMetadata(path); err == nil && metadata.Profile != "" {
+		profile = redaction.Profile(metadata.Profile)
+	}
+
+	_, err := ps.ResolveProfile(role, string(profile))
+	return err
+}
+
+// resolveReportPath maps a bare report filename to a file inside the output directory
+func (ps *PDFReportService) resolveReportPath(filename string) (string, error) {
+	if filename == "" || filename != filepath.Base(filename) || !retention.IsReportFile(filename) {
+		return "", fmt.Errorf("invalid report filename: %s", filename)
+	}
+
+	path := filepath.Join(ps.pdfGenerator.OutputDir(), filename)
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return "", fmt.Errorf("report %s not found", filename)
+	}
+
+	return path, nil
+}
+
+// auditGeneration records the outcome of generating a report that is not tied
+// to a student and stores the metadata of a generated one. Failure records are
+// best effort; an error is returned only when a success record or the metadata
+// cannot be written.
+func (ps *PDFReportService) auditGeneration(opts ReportOptions, subject, reportID, filePath string, genErr error) error {
+	record := ps.newAuditRecord(audit.ActionGenerate, 0, opts)
+	if genErr != nil {
+		record.Outcome = audit.OutcomeFailure
+		record.Details = fmt.Sprintf("%s: %v", subject, genErr)
+		ps.auditStore.Append(record)
+		return nil
+	}
+
+	record.ReportID = reportID
+	record.Details = filepath.Base(filePath)
+	record.Tags = opts.Tags
+	if err := ps.auditStore.Append(record); err != nil {
+		return fmt.Errorf("failed to write audit record: %w", err)
+	}
+	return ps.writeReportMetadata(filePath, reportID, opts)
+}
+
+// writeReportMetadata stores the profile and tags a report was generated with
+// next to the report, for download checks and retention rules
+func (ps *PDFReportService) writeReportMetadata(filePath, reportID string, opts ReportOptions) error {
+	profile := opts.Profile
+	if profile == "" {
+		profile = redaction.ProfileFull
 	}
 
-	return status
+	err := retention.WriteMetadata(filePath, retention.Metadata{
+		ReportID:    reportID,
+		Profile:     string(profile),
+		Tags:        opts.Tags,
+		GeneratedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store report metadata: %w", err)
+	}
+	return nil
+}
+
+// newAuditRecord creates a successful audit record for the request
+func (ps *PDFReportService) newAuditRecord(action audit.Action, studentID int, opts ReportOptions) audit.Record {
+	return audit.Record{
+		Timestamp: time.Now(),
+		Actor:     opts.GeneratedBy,
+		Action:    action,
+		StudentID: studentID,
+		Profile:   string(opts.Profile),
+		ClientIP:  opts.ClientIP,
+		RequestID: opts.RequestID,
+		Outcome:   audit.OutcomeSuccess,
+	}
 }
 
-// CleanupOldReports cleans up old report files
-func (ps *PDFReportService) CleanupOldReports() error {
-	return ps.pdfGenerator.CleanupOldReports()
+var reportFilenamePattern = regexp.MustCompile(`^student_report_(\d+)_`)
+
+// studentIDFromFilename extracts the student ID from a generated report filename
+func studentIDFromFilename(path string) int {
+	match := reportFilenamePattern.FindStringSubmatch(filepath.Base(path))
+	if match == nil {
+		return 0
+	}
+	id, _ := strconv.Atoi(match[1])
+	return id
 }
 
 // getActualFileSize gets the actual file size for the generated report
@@ -160,20 +429,7 @@ type PDFReportResult struct {
 	FilePath    string    `json:"file_path"`
 	GeneratedAt time.Time `json:"generated_at"`
 	GeneratedBy string    `json:"generated_by"`
+	Profile     string    `json:"profile"`
+	Format      string    `json:"format"`
 	FileSize    int64     `json:"file_size"`
 }
-
-// ServiceHealthStatus represents the health status of the service
-type ServiceHealthStatus struct {
-	Service    string                     `json:"service"`
-	Healthy    bool                       `json:"healthy"`
-	Message    string                     `json:"message"`
-	Timestamp  time.Time                  `json:"timestamp"`
-	Components map[string]ComponentStatus `json:"components"`
-}
-
-// ComponentStatus represents the status of an individual component
-type ComponentStatus struct {
-	Status  string `json:"status"`
-	Message string `json:"message"`
-}
@@ -1,11 +1,18 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"student-report-service/internal/audit"
 	"student-report-service/internal/config"
 	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+	"student-report-service/internal/retention"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -32,7 +39,79 @@ func (m *MockNodeJSClient) GetAllStudents(filters map[string]string) ([]models.S
 	return args.Get(0).([]models.StudentListItem), args.Error(1)
 }
 
-func (m *MockNodeJSClient) HealthCheck() error {
+func (m *MockNodeJSClient) GetStaffByID(staffID int) (*models.Staff, error) {
+	args := m.Called(staffID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Staff), args.Error(1)
+}
+
+func (m *MockNodeJSClient) GetAllStaff(filters map[string]string) ([]models.StaffListItem, error) {
+	args := m.Called(filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StaffListItem), args.Error(1)
+}
+
+func (m *MockNodeJSClient) GetDashboard() (*models.Dashboard, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Dashboard), args.Error(1)
+}
+
+func (m *MockNodeJSClient) GetNotices(userID int) ([]models.Notice, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Notice), args.Error(1)
+}
+
+func (m *MockNodeJSClient) GetNoticeByID(noticeID int) (*models.NoticeDetail, error) {
+	args := m.Called(noticeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NoticeDetail), args.Error(1)
+}
+
+func (m *MockNodeJSClient) GetNoticeRecipients() ([]models.NoticeRecipient, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.NoticeRecipient), args.Error(1)
+}
+
+func (m *MockNodeJSClient) GetLeaveHistory(userID int) ([]models.LeaveRequest, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LeaveRequest), args.Error(1)
+}
+
+func (m *MockNodeJSClient) GetPendingLeaves() ([]models.LeaveRequest, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LeaveRequest), args.Error(1)
+}
+
+func (m *MockNodeJSClient) GetLeavePolicies() ([]models.LeavePolicy, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LeavePolicy), args.Error(1)
+}
+
+func (m *MockNodeJSClient) HealthCheck(ctx context.Context) error {
 	args := m.Called()
 	return args.Error(0)
 }
@@ -52,12 +131,56 @@ func (m *MockPDFGenerator) GenerateStudentReport(student *models.Student, metada
 	return args.String(0), args.Error(1)
 }
 
-func (m *MockPDFGenerator) CleanupOldReports() error {
+func (m *MockPDFGenerator) GenerateStaffReport(staff *models.Staff, metadata *models.ReportMetadata) (string, error) {
+	args := m.Called(staff, metadata)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPDFGenerator) GenerateClassRoster(roster *models.ClassRoster, metadata *models.ReportMetadata) (string, error) {
+	args := m.Called(roster, metadata)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPDFGenerator) GenerateQualityChecklist(report *models.QualityReport, metadata *models.ReportMetadata) (string, error) {
+	args := m.Called(report, metadata)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPDFGenerator) GenerateDashboardReport(report *models.DashboardReport, metadata *models.ReportMetadata) (string, error) {
+	args := m.Called(report, metadata)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPDFGenerator) GenerateNoticeDigest(digest *models.NoticeDigest, metadata *models.ReportMetadata) (string, error) {
+	args := m.Called(digest, metadata)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPDFGenerator) GenerateLeaveReport(report *models.LeaveReport, metadata *models.ReportMetadata) (string, error) {
+	args := m.Called(report, metadata)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPDFGenerator) GeneratePacket(packet *models.ReportPacket, metadata *models.ReportMetadata) (string, error) {
+	args := m.Called(packet, metadata)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPDFGenerator) CleanupOldReports(dryRun bool) (*retention.Summary, error) {
+	args := m.Called(dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*retention.Summary), args.Error(1)
+}
+
+func (m *MockPDFGenerator) OutputDir() string {
 	args := m.Called()
-	return args.Error(0)
+	return args.String(0)
 }
 
 func TestPDFReportService_CreateStudentPDF(t *testing.T) {
+	outputDir := t.TempDir()
 	mockStudent := &models.Student{
 		ID:    1,
 		Name:  "John Doe",
@@ -78,7 +201,7 @@ func TestPDFReportService_CreateStudentPDF(t *testing.T) {
 			generatedBy: "Test User",
 			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
 				nodeClient.On("GetStudentByID", 1).Return(mockStudent, nil)
-				pdfGen.On("GenerateStudentReport", mock.AnythingOfType("*models.Student"), mock.AnythingOfType("*models.ReportMetadata")).Return("/path/to/report.pdf", nil)
+				pdfGen.On("GenerateStudentReport", mock.AnythingOfType("*models.Student"), mock.AnythingOfType("*models.ReportMetadata")).Return(filepath.Join(outputDir, "report.pdf"), nil)
 			},
 			expectedError: false,
 		},
@@ -129,7 +252,7 @@ func TestPDFReportService_CreateStudentPDF(t *testing.T) {
 			service := NewPDFReportService(mockNodeClient, mockPDFGen, cfg)
 
 			// Execute
-			result, err := service.CreateStudentPDF(tt.studentID, tt.generatedBy)
+			result, err := service.CreateStudentPDF(tt.studentID, ReportOptions{GeneratedBy: tt.generatedBy})
 
 			// Verify
 			if tt.expectedError {
@@ -155,54 +278,210 @@ func TestPDFReportService_CreateStudentPDF(t *testing.T) {
 	}
 }
 
-func TestPDFReportService_HealthCheck(t *testing.T) {
+func TestPDFReportService_CreateStudentPDF_AppliesRedaction(t *testing.T) {
+	outputDir := t.TempDir()
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+
+	mockNodeClient.On("GetStudentByID", 1).Return(&models.Student{
+		ID:          1,
+		Name:        "John Doe",
+		Email:       "john@example.com",
+		FatherPhone: stringPtr("9800000002"),
+	}, nil)
+	mockPDFGen.On("GenerateStudentReport",
+		mock.MatchedBy(func(s *models.Student) bool {
+			return s.Email == redaction.Withheld && *s.FatherPhone == redaction.Withheld
+		}),
+		mock.MatchedBy(func(m *models.ReportMetadata) bool {
+			return m.Profile == string(redaction.ProfilePublicNotice)
+		}),
+	).Return(filepath.Join(outputDir, "report.pdf"), nil)
+
+	service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+	result, err := service.CreateStudentPDF(1, ReportOptions{GeneratedBy: "Test User", Profile: redaction.ProfilePublicNotice})
+
+	assert.NoError(t, err)
+	assert.Equal(t, string(redaction.ProfilePublicNotice), result.Profile)
+	mockNodeClient.AssertExpectations(t)
+	mockPDFGen.AssertExpectations(t)
+}
+
+func TestPDFReportService_CreateStudentPDF_Formats(t *testing.T) {
 	tests := []struct {
-		name            string
-		setupMocks      func(*MockNodeJSClient, *MockPDFGenerator)
-		expectedHealthy bool
+		name          string
+		format        string
+		expectedExt   string
+		expectedError string
 	}{
-		{
-			name: "All components healthy",
-			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
-				nodeClient.On("HealthCheck").Return(nil)
-			},
-			expectedHealthy: true,
-		},
-		{
-			name: "Node.js API unhealthy",
-			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
-				nodeClient.On("HealthCheck").Return(errors.New("API unavailable"))
-			},
-			expectedHealthy: false,
-		},
+		{name: "Default is PDF", format: "", expectedExt: ".pdf"},
+		{name: "DOCX", format: FormatDOCX, expectedExt: ".docx"},
+		{name: "Unknown format", format: "odt", expectedError: "invalid report format: odt"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create mocks
+			outputDir := t.TempDir()
 			mockNodeClient := new(MockNodeJSClient)
 			mockPDFGen := new(MockPDFGenerator)
+			mockNodeClient.On("GetStudentByID", 1).Return(&models.Student{ID: 1, Name: "John Doe"}, nil)
+			mockPDFGen.On("GenerateStudentReport", mock.Anything, mock.Anything).
+				Return(filepath.Join(outputDir, "student_report_1_John_Doe_20240115_103000.pdf"), nil)
 
-			// Setup mocks
-			tt.setupMocks(mockNodeClient, mockPDFGen)
-
-			// Create service
-			cfg := &config.Config{}
+			cfg := &config.Config{Report: config.ReportConfig{OutputDir: outputDir, MaxFileSize: 10 * 1024 * 1024}}
 			service := NewPDFReportService(mockNodeClient, mockPDFGen, cfg)
 
-			// Execute
-			status := service.HealthCheck()
+			result, err := service.CreateStudentPDF(1, ReportOptions{GeneratedBy: "Test User", Format: tt.format})
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
 
-			// Verify
-			assert.Equal(t, tt.expectedHealthy, status.Healthy)
-			assert.Equal(t, "Report Service", status.Service)
-			assert.NotEmpty(t, status.Components)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedExt, filepath.Ext(result.FilePath))
+			assert.Equal(t, strings.TrimPrefix(tt.expectedExt, "."), result.Format)
+			if tt.format == FormatDOCX {
+				mockPDFGen.AssertNotCalled(t, "GenerateStudentReport", mock.Anything, mock.Anything)
+				assert.FileExists(t, result.FilePath)
+				assert.Greater(t, result.FileSize, int64(0))
+			}
+		})
+	}
+}
 
-			// Assert that all expectations were met
-			mockNodeClient.AssertExpectations(t)
-			mockPDFGen.AssertExpectations(t)
+func TestPDFReportService_AuditTrail(t *testing.T) {
+	outputDir := t.TempDir()
+	reportFile := "student_report_1_John_Doe_20240115_103000.pdf"
+	assert.NoError(t, os.WriteFile(filepath.Join(outputDir, reportFile), []byte("%PDF"), 0644))
+
+	store, err := audit.NewFileStore(filepath.Join(t.TempDir(), "audit.jsonl"), true)
+	assert.NoError(t, err)
+	defer store.Close()
+
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+	mockNodeClient.On("GetStudentByID", 1).Return(&models.Student{ID: 1, Name: "John Doe"}, nil)
+	mockNodeClient.On("GetStudentByID", 2).Return(nil, errors.New("API Error 404: Student not found"))
+	mockPDFGen.On("GenerateStudentReport", mock.Anything, mock.Anything).Return(filepath.Join(outputDir, reportFile), nil)
+	mockPDFGen.On("OutputDir").Return(outputDir)
+
+	service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+	service.SetAuditStore(store)
+	opts := ReportOptions{GeneratedBy: "alice", ClientIP: "10.0.0.1", RequestID: "req-1"}
+
+	result, err := service.CreateStudentPDF(1, opts)
+	assert.NoError(t, err)
+	_, err = service.CreateStudentPDF(2, opts)
+	assert.Error(t, err)
+	_, err = service.OpenReport(reportFile, "admin", opts)
+	assert.NoError(t, err)
+	_, err = service.OpenReport("../../etc/passwd.pdf", "admin", opts)
+	assert.Error(t, err)
+
+	records, err := service.QueryAudit(audit.Filter{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 4)
+
+	assert.Equal(t, audit.ActionGenerate, records[0].Action)
+	assert.Equal(t, result.ReportID, records[0].ReportID)
+	assert.Equal(t, "alice", records[0].Actor)
+	assert.Equal(t, "10.0.0.1", records[0].ClientIP)
+	assert.Equal(t, "req-1", records[0].RequestID)
+	assert.Equal(t, audit.OutcomeSuccess, records[0].Outcome)
+	assert.Equal(t, "full", records[0].Profile)
+
+	assert.Equal(t, 2, records[1].StudentID)
+	assert.Equal(t, audit.OutcomeFailure, records[1].Outcome)
+
+	assert.Equal(t, audit.ActionDownload, records[2].Action)
+	assert.Equal(t, 1, records[2].StudentID)
+	assert.Equal(t, audit.OutcomeFailure, records[3].Outcome)
+
+	assert.NoError(t, store.Verify())
+}
+
+func TestPDFReportService_OpenReportChecksProfile(t *testing.T) {
+	outputDir := t.TempDir()
+	reportFile := "student_report_1_John_Doe_20240115_103000.pdf"
+	unrecordedFile := "student_report_2_Jane_Doe_20240115_103000.pdf"
+	corruptFile := "student_report_3_Ann_Doe_20240115_103000.pdf"
+	for _, name := range []string{reportFile, unrecordedFile, corruptFile} {
+		assert.NoError(t, os.WriteFile(filepath.Join(outputDir, name), []byte("%PDF"), 0644))
+	}
+	assert.NoError(t, os.WriteFile(retention.MetadataPath(filepath.Join(outputDir, corruptFile)), []byte("{"), 0644))
+
+	store, err := audit.NewFileStore(filepath.Join(t.TempDir(), "audit.jsonl"), false)
+	assert.NoError(t, err)
+	defer store.Close()
+
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+	mockNodeClient.On("GetStudentByID", 1).Return(&models.Student{ID: 1, Name: "John Doe"}, nil)
+	mockPDFGen.On("GenerateStudentReport", mock.Anything, mock.Anything).Return(filepath.Join(outputDir, reportFile), nil)
+	mockPDFGen.On("OutputDir").Return(outputDir)
+
+	service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+	service.SetAuditStore(store)
+	opts := ReportOptions{GeneratedBy: "alice", Profile: redaction.ProfileParentFacing}
+	_, err = service.CreateStudentPDF(1, opts)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		filename string
+		role     string
+		allowed  bool
+	}{
+		{name: "Role allowed the report's profile", filename: reportFile, role: "parent", allowed: true},
+		{name: "More permissive role", filename: reportFile, role: "admin", allowed: true},
+		{name: "Role limited to public notices", filename: reportFile, role: "student"},
+		{name: "No role", filename: reportFile},
+		{name: "Unrecorded report needs full", filename: unrecordedFile, role: "teacher"},
+		{name: "Unrecorded report for admin", filename: unrecordedFile, role: "admin", allowed: true},
+		{name: "Unreadable metadata needs full", filename: corruptFile, role: "parent"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.OpenReport(tt.filename, tt.role, ReportOptions{GeneratedBy: "bob"})
+			if tt.allowed {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, redaction.ErrProfileNotAllowed)
 		})
 	}
+
+	denied, err := service.QueryAudit(audit.Filter{Action: audit.ActionDownload, Outcome: audit.OutcomeFailure})
+	assert.NoError(t, err)
+	assert.Len(t, denied, 4, "refused downloads are audited")
+}
+
+func TestPDFReportService_WritesReportMetadata(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "student_report_1_John_Doe_20240115_103000.pdf")
+
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+	mockNodeClient.On("GetStudentByID", 1).Return(&models.Student{ID: 1, Name: "John Doe"}, nil)
+	mockPDFGen.On("GenerateStudentReport", mock.Anything, mock.Anything).Return(reportPath, nil)
+
+	// Auditing is disabled, so retention and downloads rely on the metadata alone
+	service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+	result, err := service.CreateStudentPDF(1, ReportOptions{
+		GeneratedBy: "alice",
+		Profile:     redaction.ProfileParentFacing,
+		Tags:        []string{"term-1", "legal-hold"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	metadata, err := retention.ReadMetadata(reportPath)
+	if assert.NoError(t, err) {
+		assert.Equal(t, result.ReportID, metadata.ReportID)
+		assert.Equal(t, string(redaction.ProfileParentFacing), metadata.Profile)
+		assert.Equal(t, []string{"term-1", "legal-hold"}, metadata.Tags)
+	}
 }
 
 func TestPDFReportService_CleanupOldReports(t *testing.T) {
@@ -214,14 +493,16 @@ func TestPDFReportService_CleanupOldReports(t *testing.T) {
 		{
 			name: "Successful cleanup",
 			setupMocks: func(pdfGen *MockPDFGenerator) {
-				pdfGen.On("CleanupOldReports").Return(nil)
+				pdfGen.On("CleanupOldReports", false).Return(&retention.Summary{
+					Deleted: []retention.Entry{{Path: "/reports/student_report_1_John_Doe_20240115_103000.pdf", Reason: retention.ReasonExpired}},
+				}, nil)
 			},
 			expectedError: false,
 		},
 		{
 			name: "Cleanup fails",
 			setupMocks: func(pdfGen *MockPDFGenerator) {
-				pdfGen.On("CleanupOldReports").Return(errors.New("cleanup failed"))
+				pdfGen.On("CleanupOldReports", false).Return(nil, errors.New("cleanup failed"))
 			},
 			expectedError: true,
 		},
@@ -241,7 +522,7 @@ func TestPDFReportService_CleanupOldReports(t *testing.T) {
 			service := NewPDFReportService(mockNodeClient, mockPDFGen, cfg)
 
 			// Execute
-			err := service.CleanupOldReports()
+			_, err := service.CleanupOldReports(ReportOptions{GeneratedBy: "Test User"}, false)
 
 			// Verify
 			if tt.expectedError {
@@ -257,6 +538,37 @@ func TestPDFReportService_CleanupOldReports(t *testing.T) {
 	}
 }
 
+// updatablePDFGenerator records configuration updates
+type updatablePDFGenerator struct {
+	MockPDFGenerator
+	updated *config.ReportConfig
+}
+
+func (g *updatablePDFGenerator) UpdateConfig(cfg *config.ReportConfig) {
+	g.updated = cfg
+}
+
+func TestPDFReportService_UpdateConfig(t *testing.T) {
+	generator := &updatablePDFGenerator{}
+	service := NewPDFReportService(new(MockNodeJSClient), generator, &config.Config{
+		Report: config.ReportConfig{DefaultRole: "admin"},
+	})
+
+	profile, err := service.ResolveProfile("", "")
+	assert.NoError(t, err)
+	assert.Equal(t, redaction.ProfileFull, profile)
+
+	reloaded := &config.Config{Report: config.ReportConfig{DefaultRole: "parent", WatermarkText: "DRAFT"}}
+	service.UpdateConfig(reloaded)
+
+	assert.Same(t, reloaded, service.Config())
+	assert.Same(t, &reloaded.Report, generator.updated, "generator receives the report settings")
+
+	profile, err = service.ResolveProfile("", "")
+	assert.NoError(t, err)
+	assert.NotEqual(t, redaction.ProfileFull, profile, "new default role applies to the next request")
+}
+
 func TestPDFReportService_GetAllStudents(t *testing.T) {
 	mockStudents := []models.StudentListItem{
 		{
@@ -330,7 +642,7 @@ func TestPDFReportService_GetAllStudents(t *testing.T) {
 			service := NewPDFReportService(mockNodeClient, mockPDFGen, cfg)
 
 			// Execute
-			students, err := service.GetAllStudents(tt.filters)
+			students, err := service.GetAllStudents(tt.filters, redaction.ProfileFull)
 
 			// Verify
 			if tt.expectedError {
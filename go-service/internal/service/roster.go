@@ -0,0 +1,196 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+)
+
+// rosterFetchConcurrency bounds parallel student detail requests to the backend
+const rosterFetchConcurrency = 4
+
+// RosterReportResult represents the result of a class roster generation
+type RosterReportResult struct {
+	ReportID    string    `json:"report_id"`
+	ClassName   string    `json:"class_name"`
+	Section     string    `json:"section,omitempty"`
+	SortBy      string    `json:"sort_by"`
+	Students    int       `json:"students"`
+	FilePath    string    `json:"file_path"`
+	GeneratedAt time.Time `json:"generated_at"`
+	GeneratedBy string    `json:"generated_by"`
+	Profile     string    `json:"profile"`
+	FileSize    int64     `json:"file_size"`
+}
+
+// CreateClassRosterPDF generates a roster for a class, optionally limited to a section.
+// sortBy is "roll" (default) or "name".
+func (ps *PDFReportService) CreateClassRosterPDF(className, section, sortBy string, opts ReportOptions) (*RosterReportResult, error) {
+	if className == "" {
+		return nil, fmt.Errorf("invalid request: className is required")
+	}
+	if sortBy == "" {
+		sortBy = models.RosterSortRoll
+	}
+	if sortBy != models.RosterSortRoll && sortBy != models.RosterSortName {
+		return nil, fmt.Errorf("invalid sort order %q: expected roll or name", sortBy)
+	}
+	if opts.Profile == "" {
+		opts.Profile = redaction.ProfileFull
+	}
+
+	subject := fmt.Sprintf("roster for class %s", className)
+	result, err := ps.createClassRosterPDF(className, section, sortBy, opts)
+	if err != nil {
+		ps.auditGeneration(opts, subject, "", "", err)
+		return nil, err
+	}
+
+	if err := ps.auditGeneration(opts, "", result.ReportID, result.FilePath, nil); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createClassRosterPDF fetches, redacts, sorts and renders the roster
+func (ps *PDFReportService) createClassRosterPDF(className, section, sortBy string, opts ReportOptions) (*RosterReportResult, error) {
+	roster, err := ps.buildClassRoster(className, section, sortBy, opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &models.ReportMetadata{
+		GeneratedAt: time.Now(),
+		GeneratedBy: opts.GeneratedBy,
+		ReportID:    fmt.Sprintf("RST-%d", time.Now().UnixNano()),
+		Profile:     string(opts.Profile),
+		Archival:    opts.Archival,
+		School:      ps.Config().Report.SchoolName,
+	}
+
+	filePath, err := ps.jobs.run(func() (string, error) {
+		return ps.pdfGenerator.GenerateClassRoster(roster, metadata)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF report: %w", err)
+	}
+
+	return &RosterReportResult{
+		ReportID:    metadata.ReportID,
+		ClassName:   className,
+		Section:     section,
+		SortBy:      sortBy,
+		Students:    len(roster.Students),
+		FilePath:    filePath,
+		GeneratedAt: metadata.GeneratedAt,
+		GeneratedBy: opts.GeneratedBy,
+		Profile:     string(opts.Profile),
+		FileSize:    ps.getActualFileSize(filePath),
+	}, nil
+}
+
+// buildClassRoster fetches the full records of a class's students, redacts
+// them for the profile and sorts them
+func (ps *PDFReportService) buildClassRoster(className, section, sortBy string, profile redaction.Profile) (*models.ClassRoster, error) {
+	filters := map[string]string{"className": className}
+	if section != "" {
+		filters["section"] = section
+	}
+
+	items, err := ps.nodeClient.GetAllStudents(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch students list: %w", err)
+	}
+
+	// The list endpoint omits roll and guardian details, so fetch each student
+	students, err := ps.fetchStudents(items)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range students {
+		students[i] = *redaction.ApplyStudent(profile, &students[i])
+	}
+	sortRoster(students, sortBy)
+
+	return &models.ClassRoster{
+		ClassName: className,
+		Section:   section,
+		SortBy:    sortBy,
+		Students:  students,
+	}, nil
+}
+
+// fetchStudents loads full student records with bounded concurrency,
+// preserving the order of items
+func (ps *PDFReportService) fetchStudents(items []models.StudentListItem) ([]models.Student, error) {
+	students := make([]models.Student, len(items))
+	errs := make([]error, len(items))
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, rosterFetchConcurrency)
+	for i, item := range items {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i, id int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			student, err := ps.nodeClient.GetStudentByID(id)
+			switch {
+			case err != nil:
+				errs[i] = fmt.Errorf("failed to fetch student %d: %w", id, err)
+			case student == nil:
+				errs[i] = fmt.Errorf("student with ID %d not found", id)
+			default:
+				students[i] = *student
+			}
+		}(i, item.ID)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return students, nil
+}
+
+// sortRoster orders students by roll number (unassigned last) or by name,
+// using the other key to break ties
+func sortRoster(students []models.Student, sortBy string) {
+	byName := func(a, b models.Student) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}
+	byRoll := func(a, b models.Student) int {
+		switch {
+		case a.Roll == nil && b.Roll == nil:
+			return 0
+		case a.Roll == nil:
+			return 1
+		case b.Roll == nil:
+			return -1
+		default:
+			return *a.Roll - *b.Roll
+		}
+	}
+
+	primary, secondary := byRoll, byName
+	if sortBy == models.RosterSortName {
+		primary, secondary = byName, byRoll
+	}
+
+	sort.SliceStable(students, func(i, j int) bool {
+		if c := primary(students[i], students[j]); c != 0 {
+			return c < 0
+		}
+		return secondary(students[i], students[j]) < 0
+	})
+}
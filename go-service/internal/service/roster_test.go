@@ -0,0 +1,103 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func rosterStudents() map[int]*models.Student {
+	roll := func(n int) *int { return &n }
+	return map[int]*models.Student{
+		1: {ID: 1, Name: "charlie", Roll: roll(3), GuardianPhone: stringPtr("9800000001")},
+		2: {ID: 2, Name: "Alice", Roll: roll(2)},
+		3: {ID: 3, Name: "Bob"},
+		4: {ID: 4, Name: "alice", Roll: roll(1)},
+	}
+}
+
+func TestPDFReportService_CreateClassRosterPDF(t *testing.T) {
+	outputDir := t.TempDir()
+	tests := []struct {
+		name          string
+		sortBy        string
+		profile       redaction.Profile
+		expectedOrder []int
+		checkStudent  func(*testing.T, models.Student)
+	}{
+		{name: "Sorted by roll with unassigned last", sortBy: "", expectedOrder: []int{4, 2, 1, 3}},
+		{name: "Sorted by name with roll breaking ties", sortBy: "name", expectedOrder: []int{4, 2, 3, 1}},
+		{
+			name:          "Redacted for parent-facing profile",
+			sortBy:        "roll",
+			profile:       redaction.ProfileParentFacing,
+			expectedOrder: []int{4, 2, 1, 3},
+			checkStudent: func(t *testing.T, s models.Student) {
+				if s.ID == 1 {
+					assert.NotEqual(t, "9800000001", *s.GuardianPhone)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockPDFGen := new(MockPDFGenerator)
+
+			mockNodeClient.On("GetAllStudents", map[string]string{"className": "Grade 5", "section": "A"}).
+				Return([]models.StudentListItem{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}, nil)
+			for id, student := range rosterStudents() {
+				mockNodeClient.On("GetStudentByID", id).Return(student, nil)
+			}
+
+			var rendered *models.ClassRoster
+			mockPDFGen.On("GenerateClassRoster", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { rendered = args.Get(0).(*models.ClassRoster) }).
+				Return(filepath.Join(outputDir, "roster.pdf"), nil)
+
+			service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+			result, err := service.CreateClassRosterPDF("Grade 5", "A", tt.sortBy, ReportOptions{GeneratedBy: "Test User", Profile: tt.profile})
+
+			assert.NoError(t, err)
+			assert.Equal(t, 4, result.Students)
+
+			order := make([]int, 0, len(rendered.Students))
+			for _, student := range rendered.Students {
+				order = append(order, student.ID)
+				if tt.checkStudent != nil {
+					tt.checkStudent(t, student)
+				}
+			}
+			assert.Equal(t, tt.expectedOrder, order)
+		})
+	}
+}
+
+func TestPDFReportService_CreateClassRosterPDF_Errors(t *testing.T) {
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+	service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+
+	_, err := service.CreateClassRosterPDF("", "", "", ReportOptions{})
+	assert.ErrorContains(t, err, "className is required")
+
+	_, err = service.CreateClassRosterPDF("Grade 5", "", "age", ReportOptions{})
+	assert.ErrorContains(t, err, "invalid sort order")
+
+	mockNodeClient.On("GetAllStudents", map[string]string{"className": "Grade 5"}).
+		Return([]models.StudentListItem{{ID: 1}, {ID: 2}}, nil)
+	mockNodeClient.On("GetStudentByID", 1).Return(&models.Student{ID: 1}, nil)
+	mockNodeClient.On("GetStudentByID", 2).Return(nil, errors.New("API Error 500: boom"))
+
+	_, err = service.CreateClassRosterPDF("Grade 5", "", "", ReportOptions{})
+	assert.ErrorContains(t, err, "failed to fetch student 2")
+	mockPDFGen.AssertNotCalled(t, "GenerateClassRoster", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"student-report-service/internal/redaction"
+	"student-report-service/internal/schedule"
+)
+
+// ScheduleExecutor runs report schedules through the report service
+type ScheduleExecutor struct {
+	service *PDFReportService
+}
+
+// NewScheduleExecutor creates an executor backed by the report service
+func NewScheduleExecutor(service *PDFReportService) *ScheduleExecutor {
+	return &ScheduleExecutor{service: service}
+}
+
+// Validate rejects templates and delivery types the service cannot handle
+func (e *ScheduleExecutor) Validate(s *schedule.Schedule) error {
+	switch s.Template {
+	case schedule.TemplateStudent:
+	case schedule.TemplateDashboard:
+		if len(s.Target.StudentIDs) > 0 || len(s.Target.Filters) > 0 {
+			return fmt.Errorf("invalid schedule: the dashboard template takes no target")
+		}
+		for _, recipient := range s.Delivery.Recipients {
+			if isRecipientKeyword(recipient) {
+				return fmt.Errorf("invalid schedule: dashboard reports cannot be sent to students")
+			}
+		}
+	default:
+		return fmt.Errorf("invalid schedule: unknown template %q", s.Template)
+	}
+
+	switch s.Delivery.Type {
+	case schedule.DeliveryStore:
+	case schedule.DeliveryEmail:
+		if !e.service.EmailEnabled() {
+			return fmt.Errorf("invalid schedule: email delivery is not configured")
+		}
+	default:
+		return fmt.Errorf("invalid schedule: unknown delivery type %q", s.Delivery.Type)
+	}
+
+	// Pin the default role, so later changes to it do not widen what an
+	// existing schedule may render
+	if s.Role == "" {
+		s.Role = e.service.Config().Report.DefaultRole
+	}
+	profile, err := e.authorize(*s)
+	if err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+	s.Profile = string(profile)
+
+	if s.Delivery.Type == schedule.DeliveryEmail {
+		for _, recipient := range s.Delivery.Recipients {
+			// Student addresses are only known, and checked, when the schedule runs
+			if isRecipientKeyword(recipient) {
+				continue
+			}
+			if err := e.service.checkRecipient(strings.TrimSpace(recipient), false, profile); err != nil {
+				return fmt.Errorf("invalid schedule: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Authorize checks the caller role against the schedule's profile, as when
+// the schedule is defined
+func (e *ScheduleExecutor) Authorize(s schedule.Schedule, role string) error {
+	s.Role = role
+	_, err := e.authorize(s)
+	return err
+}
+
+// authorize checks the schedule's profile against the role it was defined
+// with. Dashboard reports have no redacted variant and need the full profile
+func (e *ScheduleExecutor) authorize(s schedule.Schedule) (redaction.Profile, error) {
+	if s.Profile == "" {
+		return "", errors.New("profile is required")
+	}
+	profile, err := e.service.ResolveProfile(s.Role, s.Profile)
+	if err != nil {
+		return "", err
+	}
+	if s.Template == schedule.TemplateDashboard && profile != redaction.ProfileFull {
+		return "", fmt.Errorf("%w: dashboard reports require the full profile", redaction.ErrProfileNotAllowed)
+	}
+	return profile, nil
+}
+
+// Execute runs the schedule's template once its profile is still allowed
+func (e *ScheduleExecutor) Execute(s schedule.Schedule) schedule.Result {
+	if _, err := e.authorize(s); err != nil {
+		return schedule.Result{Errors: []error{err}}
+	}
+	if s.Template == schedule.TemplateDashboard {
+		return e.executeDashboard(s)
+	}
+	return e.executeStudents(s)
+}
+
+// executeDashboard generates one dashboard summary report
+func (e *ScheduleExecutor) executeDashboard(s schedule.Schedule) schedule.Result {
+	var result schedule.Result
+	opts := ReportOptions{GeneratedBy: "schedule:" + s.ID, Tags: s.Tags}
+
+	report, err := e.service.CreateDashboardPDF(time.Time{}, opts)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("dashboard: %w", err))
+		return result
+	}
+	result.Reports = append(result.Reports, report.FilePath)
+
+	if s.Delivery.Type == schedule.DeliveryEmail {
+		email := &PDFReportResult{
+			ReportID:    report.ReportID,
+			StudentName: "School Dashboard",
+			FilePath:    report.FilePath,
+			GeneratedAt: report.GeneratedAt,
+			GeneratedBy: report.GeneratedBy,
+		}
+		if _, err := e.service.emailReport(email, s.Delivery.Recipients, opts); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("dashboard: %w", err))
+		}
+	}
+
+	return result
+}
+
+// executeStudents generates a report for every targeted student. Failures for
+// one student are collected and do not stop the remaining reports.
+func (e *ScheduleExecutor) executeStudents(s schedule.Schedule) schedule.Result {
+	var result schedule.Result
+
+	studentIDs, err := e.resolveTargets(s.Target)
+	if err != nil {
+		result.Errors = append(result.Errors, err)
+		return result
+	}
+
+	opts := ReportOptions{
+		GeneratedBy: "schedule:" + s.ID,
+		Profile:     redaction.Profile(s.Profile),
+		Tags:        s.Tags,
+	}
+
+	for _, studentID := range studentIDs {
+		report, err := e.service.CreateStudentPDF(studentID, opts)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("student %d: %w", studentID, err))
+			continue
+		}
+		result.Reports = append(result.Reports, report.FilePath)
+
+		if s.Delivery.Type == schedule.DeliveryEmail {
+			if _, err := e.service.emailReport(report, s.Delivery.Recipients, opts); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("student %d: %w", studentID, err))
+			}
+		}
+	}
+
+	return result
+}
+
+// resolveTargets expands the schedule target into student IDs
+func (e *ScheduleExecutor) resolveTargets(target schedule.Target) ([]int, error) {
+	if len(target.StudentIDs) > 0 {
+		return target.StudentIDs, nil
+	}
+
+	students, err := e.service.GetAllStudents(target.Filters, redaction.ProfileFull)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(students))
+	for _, student := range students {
+		ids = append(ids, student.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+	"student-report-service/internal/schedule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleExecutor_ValidateProfile(t *testing.T) {
+	tests := []struct {
+		name            string
+		role            string
+		profile         string
+		expectedRole    string
+		expectedProfile string
+		expectedError   string
+	}{
+		{name: "Admin uses the full profile", role: "admin", profile: "Full", expectedRole: "admin", expectedProfile: "full"},
+		{name: "Teacher uses parent-facing", role: "teacher", profile: "parent-facing", expectedRole: "teacher", expectedProfile: "parent-facing"},
+		{name: "Teacher cannot use full", role: "teacher", profile: "full", expectedError: "teacher cannot use full"},
+		{name: "Missing role uses the default role", profile: "full", expectedError: "public cannot use full"},
+		{name: "Missing role is pinned", profile: "public-notice", expectedRole: "public", expectedProfile: "public-notice"},
+		{name: "Profile is required", role: "admin", expectedError: "profile is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{Report: config.ReportConfig{DefaultRole: "public"}}
+			executor := NewScheduleExecutor(NewPDFReportService(new(MockNodeJSClient), new(MockPDFGenerator), cfg))
+
+			s := schedule.Schedule{
+				Template: schedule.TemplateStudent,
+				Target:   schedule.Target{StudentIDs: []int{1}},
+				Profile:  tt.profile,
+				Role:     tt.role,
+				Delivery: schedule.Delivery{Type: schedule.DeliveryStore},
+			}
+			err := executor.Validate(&s)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRole, s.Role)
+			assert.Equal(t, tt.expectedProfile, s.Profile)
+		})
+	}
+}
+
+func TestScheduleExecutor_Authorize(t *testing.T) {
+	cfg := &config.Config{Report: config.ReportConfig{DefaultRole: "public"}}
+	executor := NewScheduleExecutor(NewPDFReportService(new(MockNodeJSClient), new(MockPDFGenerator), cfg))
+	student := schedule.Schedule{Template: schedule.TemplateStudent, Profile: "parent-facing", Role: "admin"}
+
+	assert.NoError(t, executor.Authorize(student, "teacher"))
+	assert.ErrorIs(t, executor.Authorize(student, "student"), redaction.ErrProfileNotAllowed)
+	assert.ErrorIs(t, executor.Authorize(student, ""), redaction.ErrProfileNotAllowed, "the default role applies")
+	assert.ErrorIs(t, executor.Authorize(schedule.Schedule{Template: schedule.TemplateDashboard, Profile: "full"}, "teacher"), redaction.ErrProfileNotAllowed)
+}
+
+func TestScheduleExecutor_ExecuteRechecksProfile(t *testing.T) {
+	outputDir := t.TempDir()
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+	executor := NewScheduleExecutor(NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{}))
+
+	// A stored schedule whose role no longer allows its profile renders nothing
+	result := executor.Execute(schedule.Schedule{
+		ID:       "sch_1",
+		Template: schedule.TemplateStudent,
+		Target:   schedule.Target{StudentIDs: []int{1}},
+		Profile:  "full",
+		Role:     "teacher",
+	})
+	require.Len(t, result.Errors, 1)
+	assert.ErrorIs(t, result.Errors[0], redaction.ErrProfileNotAllowed)
+	assert.Empty(t, result.Reports)
+
+	mockNodeClient.On("GetStudentByID", 1).Return(&models.Student{ID: 1, Name: "John Doe"}, nil)
+	mockPDFGen.On("GenerateStudentReport", mock.Anything, mock.MatchedBy(func(m *models.ReportMetadata) bool {
+		return m.Profile == string(redaction.ProfileParentFacing)
+	})).Return(filepath.Join(outputDir, "report.pdf"), nil)
+
+	result = executor.Execute(schedule.Schedule{
+		ID:       "sch_1",
+		Template: schedule.TemplateStudent,
+		Target:   schedule.Target{StudentIDs: []int{1}},
+		Profile:  "parent-facing",
+		Role:     "teacher",
+	})
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, []string{filepath.Join(outputDir, "report.pdf")}, result.Reports)
+	mockPDFGen.AssertExpectations(t)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"student-report-service/internal/models"
+	"student-report-service/internal/redaction"
+)
+
+// StaffReportResult represents the result of a staff report generation
+type StaffReportResult struct {
+	ReportID    string    `json:"report_id"`
+	StaffID     int       `json:"staff_id"`
+	StaffName   string    `json:"staff_name"`
+	FilePath    string    `json:"file_path"`
+	GeneratedAt time.Time `json:"generated_at"`
+	GeneratedBy string    `json:"generated_by"`
+	FileSize    int64     `json:"file_size"`
+}
+
+// GetAllStaff retrieves staff members with optional filtering
+func (ps *PDFReportService) GetAllStaff(filters map[string]string) ([]models.StaffListItem, error) {
+	staff, err := ps.nodeClient.GetAllStaff(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch staff list: %w", err)
+	}
+	return staff, nil
+}
+
+// CreateStaffPDF generates a staff profile report
+func (ps *PDFReportService) CreateStaffPDF(staffID int, opts ReportOptions) (*StaffReportResult, error) {
+	// The report has no redacted variant
+	opts.Profile = redaction.ProfileFull
+	if staffID <= 0 {
+		return nil, fmt.Errorf("invalid staff ID: %d", staffID)
+	}
+
+	result, err := ps.createStaffPDF(staffID, opts)
+	if err != nil {
+		ps.auditGeneration(opts, fmt.Sprintf("staff report for user %d", staffID), "", "", err)
+		return nil, err
+	}
+
+	if err := ps.auditGeneration(opts, "", result.ReportID, result.FilePath, nil); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// createStaffPDF fetches and renders the staff profile report
+func (ps *PDFReportService) createStaffPDF(staffID int, opts ReportOptions) (*StaffReportResult, error) {
+	staff, err := ps.nodeClient.GetStaffByID(staffID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch staff data: %w", err)
+	}
+
+	if staff == nil {
+		return nil, fmt.Errorf("staff with ID %d not found", staffID)
+	}
+
+	metadata := &models.ReportMetadata{
+		GeneratedAt: time.Now(),
+		GeneratedBy: opts.GeneratedBy,
+		ReportID:    fmt.Sprintf("STF-%d-%d", staffID, time.Now().Unix()),
+		Archival:    opts.Archival,
+		School:      ps.Config().Report.SchoolName,
+	}
+
+	filePath, err := ps.jobs.run(func() (string, error) {
+		return ps.pdfGenerator.GenerateStaffReport(staff, metadata)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF report: %w", err)
+	}
+
+	return &StaffReportResult{
+		ReportID:    metadata.ReportID,
+		StaffID:     staffID,
+		StaffName:   staff.FormatName(),
+		FilePath:    filePath,
+		GeneratedAt: metadata.GeneratedAt,
+		GeneratedBy: opts.GeneratedBy,
+		FileSize:    ps.getActualFileSize(filePath),
+	}, nil
+}
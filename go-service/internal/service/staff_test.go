@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"student-report-service/internal/audit"
+	"student-report-service/internal/config"
+	"student-report-service/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPDFReportService_CreateStaffPDF(t *testing.T) {
+	outputDir := t.TempDir()
+	department := "Science"
+	mockStaff := &models.Staff{ID: 7, Name: "Jane Teacher", Email: "jane@school.test", Department: &department}
+
+	tests := []struct {
+		name          string
+		staffID       int
+		setupMocks    func(*MockNodeJSClient, *MockPDFGenerator)
+		expectedError bool
+		errorContains string
+	}{
+		{
+			name:    "Successful report generation",
+			staffID: 7,
+			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
+				nodeClient.On("GetStaffByID", 7).Return(mockStaff, nil)
+				pdfGen.On("GenerateStaffReport", mockStaff, mock.AnythingOfType("*models.ReportMetadata")).Return(filepath.Join(outputDir, "staff.pdf"), nil)
+			},
+		},
+		{
+			name:          "Invalid staff ID",
+			staffID:       -1,
+			setupMocks:    func(*MockNodeJSClient, *MockPDFGenerator) {},
+			expectedError: true,
+			errorContains: "invalid staff ID",
+		},
+		{
+			name:    "Staff not found",
+			staffID: 99,
+			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
+				nodeClient.On("GetStaffByID", 99).Return(nil, errors.New("API Error 404: Staff detail not found"))
+			},
+			expectedError: true,
+			errorContains: "failed to fetch staff data",
+		},
+		{
+			name:    "PDF generation fails",
+			staffID: 7,
+			setupMocks: func(nodeClient *MockNodeJSClient, pdfGen *MockPDFGenerator) {
+				nodeClient.On("GetStaffByID", 7).Return(mockStaff, nil)
+				pdfGen.On("GenerateStaffReport", mockStaff, mock.Anything).Return("", errors.New("disk full"))
+			},
+			expectedError: true,
+			errorContains: "failed to generate PDF report",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockNodeClient := new(MockNodeJSClient)
+			mockPDFGen := new(MockPDFGenerator)
+			tt.setupMocks(mockNodeClient, mockPDFGen)
+
+			service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+			result, err := service.CreateStaffPDF(tt.staffID, ReportOptions{GeneratedBy: "Test User"})
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 7, result.StaffID)
+				assert.Equal(t, "Jane Teacher", result.StaffName)
+				assert.Contains(t, result.ReportID, "STF-7-")
+			}
+
+			mockNodeClient.AssertExpectations(t)
+			mockPDFGen.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPDFReportService_CreateStaffPDF_Audited(t *testing.T) {
+	outputDir := t.TempDir()
+	reportPath := filepath.Join(outputDir, "staff_report_7_Jane_Teacher_20240115_103000.pdf")
+	assert.NoError(t, os.WriteFile(reportPath, []byte("%PDF"), 0644))
+
+	store, err := audit.NewFileStore(filepath.Join(t.TempDir(), "audit.jsonl"), false)
+	assert.NoError(t, err)
+	defer store.Close()
+
+	mockNodeClient := new(MockNodeJSClient)
+	mockPDFGen := new(MockPDFGenerator)
+	mockNodeClient.On("GetStaffByID", 7).Return(&models.Staff{ID: 7, Name: "Jane Teacher"}, nil)
+	mockNodeClient.On("GetStaffByID", 8).Return(nil, errors.New("API Error 404: Staff detail not found"))
+	mockPDFGen.On("GenerateStaffReport", mock.Anything, mock.Anything).Return(reportPath, nil)
+
+	service := NewPDFReportService(mockNodeClient, mockPDFGen, &config.Config{})
+	service.SetAuditStore(store)
+
+	result, err := service.CreateStaffPDF(7, ReportOptions{GeneratedBy: "alice"})
+	assert.NoError(t, err)
+	_, err = service.CreateStaffPDF(8, ReportOptions{GeneratedBy: "alice"})
+	assert.Error(t, err)
+
+	records, err := service.QueryAudit(audit.Filter{Action: audit.ActionGenerate})
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, result.ReportID, records[0].ReportID)
+	assert.Equal(t, filepath.Base(reportPath), records[0].Details)
+	assert.Equal(t, audit.OutcomeFailure, records[1].Outcome)
+	assert.Contains(t, records[1].Details, "staff report for user 8")
+}
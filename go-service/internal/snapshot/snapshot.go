@@ -0,0 +1,114 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// maxSnapshots bounds the history kept on disk, about a year of daily reports
+const maxSnapshots = 400
+
+// Snapshot records dashboard metrics at the time a report was generated
+type Snapshot struct {
+	TakenAt  time.Time          `json:"taken_at"`
+	ReportID string             `json:"report_id"`
+	Metrics  map[string]float64 `json:"metrics"`
+}
+
+// Store persists snapshots for trend comparisons
+type Store interface {
+	Save(s Snapshot) error
+	// Latest returns the newest snapshot taken before t, or nil when there is none
+	Latest(before time.Time) (*Snapshot, error)
+}
+
+// NopStore keeps no snapshots
+type NopStore struct{}
+
+// Save discards the snapshot
+func (NopStore) Save(Snapshot) error { return nil }
+
+// Latest never finds a snapshot
+func (NopStore) Latest(time.Time) (*Snapshot, error) { return nil, nil }
+
+// FileStore keeps snapshots in a JSON document that is rewritten atomically on every change
+type FileStore struct {
+	path      string
+	mutex     sync.Mutex
+	snapshots []Snapshot
+}
+
+// NewFileStore loads the store from path, creating it if missing
+func NewFileStore(path string) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	store := &FileStore{path: path}
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot store: %w", err)
+	}
+
+	if err := json.Unmarshal(content, &store.snapshots); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot store: %w", err)
+	}
+
+	return store, nil
+}
+
+// Save appends a snapshot, trimming the oldest entries beyond the history limit
+func (s *FileStore) Save(snapshot Snapshot) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	snapshots := append(s.snapshots, snapshot)
+	if len(snapshots) > maxSnapshots {
+		snapshots = snapshots[len(snapshots)-maxSnapshots:]
+	}
+	s.snapshots = snapshots
+	return s.persist()
+}
+
+// Latest returns the newest snapshot taken before t
+func (s *FileStore) Latest(before time.Time) (*Snapshot, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var latest *Snapshot
+	for i := range s.snapshots {
+		snapshot := s.snapshots[i]
+		if !snapshot.TakenAt.Before(before) {
+			continue
+		}
+		if latest == nil || snapshot.TakenAt.After(latest.TakenAt) {
+			latest = &snapshot
+		}
+	}
+	return latest, nil
+}
+
+// persist writes the document to a temporary file and renames it into place
+func (s *FileStore) persist() error {
+	content, err := json.MarshalIndent(s.snapshots, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot store: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, content, 0640); err != nil {
+		return fmt.Errorf("failed to write snapshot store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace snapshot store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package snapshot
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStore_Latest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshots.json")
+	store, err := NewFileStore(path)
+	require.NoError(t, err)
+
+	day := func(d int) time.Time { return time.Date(2024, 3, d, 9, 0, 0, 0, time.UTC) }
+	for _, d := range []int{1, 8, 15} {
+		require.NoError(t, store.Save(Snapshot{TakenAt: day(d), ReportID: day(d).Format("Jan 2")}))
+	}
+
+	tests := []struct {
+		name     string
+		before   time.Time
+		expected string
+	}{
+		{name: "Before first snapshot", before: day(1), expected: ""},
+		{name: "Between snapshots", before: day(10), expected: "Mar 8"},
+		{name: "After last snapshot", before: day(20), expected: "Mar 15"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			latest, err := store.Latest(tt.before)
+			require.NoError(t, err)
+			if tt.expected == "" {
+				assert.Nil(t, latest)
+				return
+			}
+			require.NotNil(t, latest)
+			assert.Equal(t, tt.expected, latest.ReportID)
+		})
+	}
+
+	// Snapshots survive a reload
+	reloaded, err := NewFileStore(path)
+	require.NoError(t, err)
+	latest, err := reloaded.Latest(day(20))
+	require.NoError(t, err)
+	assert.Equal(t, "Mar 15", latest.ReportID)
+}
+
+func TestFileStore_TrimsHistory(t *testing.T) {
+	store, err := NewFileStore(filepath.Join(t.TempDir(), "snapshots.json"))
+	require.NoError(t, err)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < maxSnapshots+5; i++ {
+		require.NoError(t, store.Save(Snapshot{TakenAt: start.Add(time.Duration(i) * time.Hour)}))
+	}
+
+	assert.Len(t, store.snapshots, maxSnapshots)
+	assert.Equal(t, start.Add(5*time.Hour), store.snapshots[0].TakenAt)
+}
@@ -0,0 +1,211 @@
+package tenant
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"student-report-service/internal/config"
+)
+
+// File is the tenants file listing the schools served by this instance
+type File struct {
+	// Default is the tenant of requests that name none; empty rejects them
+	Default string       `json:"default"`
+	Tenants []FileTenant `json:"tenants"`
+}
+
+// FileTenant configures one school. Settings left empty inherit the base
+// configuration, except the backend, which every tenant must name
+type FileTenant struct {
+	ID    string   `json:"id"`
+	Name  string   `json:"name"`
+	Hosts []string `json:"hosts"`
+
+	Backend   BackendSettings   `json:"backend"`
+	Branding  BrandingSettings  `json:"branding"`
+	Retention RetentionSettings `json:"retention"`
+	RateLimit RateLimitSettings `json:"rate_limit"`
+}
+
+// BackendSettings selects the tenant's Node.js backend and service account
+type BackendSettings struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	// PasswordEnv names the environment variable holding the password, so
+	// the tenants file contains no secrets. The password may instead be read
+	// from the file named by PasswordEnv_FILE
+	PasswordEnv string `json:"password_env"`
+}
+
+// BrandingSettings customise the report template
+type BrandingSettings struct {
+	SchoolName string  `json:"school_name"`
+	Watermark  *string `json:"watermark"`
+	Logo       string  `json:"logo"`
+	PhotoDir   string  `json:"photo_dir"`
+}
+
+// RetentionSettings override how long the tenant's reports are kept
+type RetentionSettings struct {
+	CleanupAfter string            `json:"cleanup_after"`
+	Rules        map[string]string `json:"rules"`
+	TagRules     map[string]string `json:"tag_rules"`
+	DiskQuota    int64             `json:"disk_quota"`
+}
+
+// RateLimitSettings override the tenant's request rate limit
+type RateLimitSettings struct {
+	PerMinute *int `json:"per_minute"`
+	Burst     *int `json:"burst"`
+}
+
+// LoadRegistry returns a single default tenant using base when path is
+// empty, otherwise the tenants listed in the file at path
+func LoadRegistry(path string, base *config.Config) (*Registry, error) {
+	if path == "" {
+		t, err := New(DefaultID, base.Report.SchoolName, nil, base)
+		if err != nil {
+			return nil, err
+		}
+		return Single(t), nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenants file: %w", err)
+	}
+
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tenants file: %w", err)
+	}
+
+	tenants := make([]*Tenant, 0, len(file.Tenants))
+	for _, entry := range file.Tenants {
+		cfg, err := entry.Config(base)
+		if err == nil {
+			err = cfg.Validate()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("tenant %s: %w", entry.ID, err)
+		}
+		t, err := New(entry.ID, entry.Name, entry.Hosts, cfg)
+		if err != nil {
+			return nil, err
+		}
+		tenants = append(tenants, t)
+	}
+
+	return NewRegistry(tenants, file.Default)
+}
+
+// Config derives the tenant's configuration from base. Every file the
+// service writes is moved into a directory named after the tenant, so
+// tenants never share reports, audit logs, schedules or delivery records
+func (ft FileTenant) Config(base *config.Config) (*config.Config, error) {
+	if !idPattern.MatchString(ft.ID) {
+		return nil, fmt.Errorf("invalid tenant ID %q: use lowercase letters, digits and dashes", ft.ID)
+	}
+	if ft.Backend.URL == "" || ft.Backend.Username == "" || ft.Backend.PasswordEnv == "" {
+		return nil, fmt.Errorf("backend url, username and password_env are required")
+	}
+	password, err := config.LookupSecretEnv(ft.Backend.PasswordEnv)
+	if err != nil {
+		return nil, err
+	}
+	if password == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", ft.Backend.PasswordEnv)
+	}
+	passwordFiles := config.SecretSourceFiles(ft.Backend.PasswordEnv, password)
+	if password, err = config.ResolveSecret(password); err != nil {
+		return nil, fmt.Errorf("%s: %w", ft.Backend.PasswordEnv, err)
+	}
+
+	cfg := *base
+	cfg.AddSecretFiles(passwordFiles...)
+	cfg.NodeJS.BaseURL = ft.Backend.URL
+	cfg.NodeJS.ServiceUsername = ft.Backend.Username
+	cfg.NodeJS.ServicePassword = password
+
+	cfg.Report.OutputDir = filepath.Join(base.Report.OutputDir, ft.ID)
+	cfg.Report.SnapshotPath = namespace(base.Report.SnapshotPath, ft.ID)
+	cfg.Audit.Path = namespace(base.Audit.Path, ft.ID)
+	cfg.Schedule.StorePath = namespace(base.Schedule.StorePath, ft.ID)
+	cfg.SMTP.StatusPath = namespace(base.SMTP.StatusPath, ft.ID)
+
+	cfg.Report.SchoolName = orDefault(ft.Branding.SchoolName, orDefault(ft.Name, base.Report.SchoolName))
+	if ft.Branding.Watermark != nil {
+		cfg.Report.WatermarkText = *ft.Branding.Watermark
+	}
+	cfg.Report.LogoPath = orDefault(ft.Branding.Logo, base.Report.LogoPath)
+	cfg.Report.PhotoDir = ft.Branding.PhotoDir
+	if cfg.Report.PhotoDir == "" && base.Report.PhotoDir != "" {
+		cfg.Report.PhotoDir = filepath.Join(base.Report.PhotoDir, ft.ID)
+	}
+
+	if ft.Retention.CleanupAfter != "" {
+		after, err := time.ParseDuration(ft.Retention.CleanupAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention cleanup_after: %w", err)
+		}
+		cfg.Report.CleanupAfter = after
+	}
+	if ft.Retention.Rules != nil {
+		rules, err := parseRetentionRules(ft.Retention.Rules)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention rule for %w", err)
+		}
+		cfg.Report.Retention = rules
+	}
+	if ft.Retention.TagRules != nil {
+		rules, err := parseRetentionRules(ft.Retention.TagRules)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention tag rule for %w", err)
+		}
+		cfg.Report.TagRetention = rules
+	}
+	if ft.Retention.DiskQuota > 0 {
+		cfg.Report.DiskQuota = ft.Retention.DiskQuota
+	}
+
+	if ft.RateLimit.PerMinute != nil {
+		cfg.Server.RateLimit = *ft.RateLimit.PerMinute
+	}
+	if ft.RateLimit.Burst != nil {
+		cfg.Server.RateLimitBurst = *ft.RateLimit.Burst
+	}
+
+	return &cfg, nil
+}
+
+// namespace moves a file into a directory named after the tenant, next to
+// where the base configuration keeps it
+func namespace(path, id string) string {
+	if path == "" {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(path), id, filepath.Base(path))
+}
+
+func orDefault(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
+
+// parseRetentionRules parses the durations of retention rules
+func parseRetentionRules(values map[string]string) (map[string]time.Duration, error) {
+	rules := make(map[string]time.Duration, len(values))
+	for key, value := range values {
+		after, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		rules[key] = after
+	}
+	return rules, nil
+}
@@ -0,0 +1,181 @@
+package tenant
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"student-report-service/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func baseConfig(t *testing.T) *config.Config {
+	t.Setenv("REPORT_OUTPUT_DIR", filepath.Join(t.TempDir(), "reports"))
+	t.Setenv("NODEJS_SERVICE_USERNAME", "reports@school.edu")
+	t.Setenv("NODEJS_SERVICE_PASSWORD", "base-secret")
+	cfg, err := config.Load("")
+	require.NoError(t, err)
+
+	cfg.NodeJS.BaseURL = "http://localhost:5007"
+	cfg.Report.SchoolName = "Base School"
+	cfg.Report.WatermarkText = "CONFIDENTIAL"
+	cfg.Report.PhotoDir = "/srv/photos"
+	cfg.Report.CleanupAfter = 24 * time.Hour
+	cfg.Audit.Path = "./data/audit.log"
+	cfg.Schedule.StorePath = "./data/schedules.json"
+	cfg.Server.RateLimit = 120
+	cfg.Server.RateLimitBurst = 10
+	return cfg
+}
+
+func TestFileTenant_Config(t *testing.T) {
+	t.Setenv("SPRINGFIELD_PASSWORD", "tenant-secret")
+	base := baseConfig(t)
+
+	watermark := ""
+	perMinute := 30
+	entry := FileTenant{
+		ID:   "springfield",
+		Name: "Springfield Elementary",
+		Backend: BackendSettings{
+			URL:         "http://springfield-backend:5007",
+			Username:    "reports@springfield.edu",
+			PasswordEnv: "SPRINGFIELD_PASSWORD",
+		},
+		Branding:  BrandingSettings{Watermark: &watermark},
+		Retention: RetentionSettings{CleanupAfter: "720h", Rules: map[string]string{"student": "48h"}},
+		RateLimit: RateLimitSettings{PerMinute: &perMinute},
+	}
+
+	cfg, err := entry.Config(base)
+	require.NoError(t, err)
+
+	assert.Equal(t, "http://springfield-backend:5007", cfg.NodeJS.BaseURL)
+	assert.Equal(t, "reports@springfield.edu", cfg.NodeJS.ServiceUsername)
+	assert.Equal(t, "tenant-secret", cfg.NodeJS.ServicePassword)
+
+	assert.Equal(t, filepath.Join(base.Report.OutputDir, "springfield"), cfg.Report.OutputDir)
+	assert.Equal(t, filepath.Join("data", "springfield", "audit.log"), cfg.Audit.Path)
+	assert.Equal(t, filepath.Join("data", "springfield", "schedules.json"), cfg.Schedule.StorePath)
+	assert.Equal(t, filepath.Join("data", "springfield", "dashboard_snapshots.json"), cfg.Report.SnapshotPath)
+	assert.Equal(t, filepath.Join("/srv/photos", "springfield"), cfg.Report.PhotoDir)
+
+	assert.Equal(t, "Springfield Elementary", cfg.Report.SchoolName, "name is the default school name")
+	assert.Empty(t, cfg.Report.WatermarkText, "watermark can be switched off")
+	assert.Equal(t, 720*time.Hour, cfg.Report.CleanupAfter)
+	assert.Equal(t, map[string]time.Duration{"student": 48 * time.Hour}, cfg.Report.Retention)
+	assert.Equal(t, 30, cfg.Server.RateLimit)
+	assert.Equal(t, 10, cfg.Server.RateLimitBurst, "burst inherited")
+
+	assert.NotEqual(t, cfg.Report.OutputDir, base.Report.OutputDir, "base config is not modified")
+	assert.Equal(t, "base-secret", base.NodeJS.ServicePassword)
+}
+
+func TestFileTenant_PasswordFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(path, []byte("from-file\n"), 0600))
+	t.Setenv("FILE_PASSWORD_FILE", path)
+	entry := FileTenant{ID: "school", Backend: BackendSettings{URL: "http://backend", Username: "user", PasswordEnv: "FILE_PASSWORD"}}
+
+	cfg, err := entry.Config(baseConfig(t))
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", cfg.NodeJS.ServicePassword)
+	assert.Contains(t, cfg.SecretFiles(), path, "watched for rotation")
+
+	t.Setenv("FILE_PASSWORD", "from-env")
+	_, err = entry.Config(baseConfig(t))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "set either FILE_PASSWORD or FILE_PASSWORD_FILE")
+}
+
+func TestFileTenant_ConfigErrors(t *testing.T) {
+	t.Setenv("SET_PASSWORD", "secret")
+	backend := BackendSettings{URL: "http://backend", Username: "user", PasswordEnv: "SET_PASSWORD"}
+
+	tests := []struct {
+		name     string
+		entry    FileTenant
+		errorMsg string
+	}{
+		{name: "Invalid ID", entry: FileTenant{ID: "../escape", Backend: backend}, errorMsg: "invalid tenant ID"},
+		{name: "Missing backend", entry: FileTenant{ID: "school"}, errorMsg: "are required"},
+		{
+			name:     "Unset password variable",
+			entry:    FileTenant{ID: "school", Backend: BackendSettings{URL: "http://backend", Username: "user", PasswordEnv: "UNSET_TENANT_PASSWORD"}},
+			errorMsg: "UNSET_TENANT_PASSWORD is not set",
+		},
+		{
+			name:     "Invalid retention",
+			entry:    FileTenant{ID: "school", Backend: backend, Retention: RetentionSettings{CleanupAfter: "a month"}},
+			errorMsg: "invalid retention cleanup_after",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.entry.Config(baseConfig(t))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestLoadRegistry(t *testing.T) {
+	t.Run("No tenants file", func(t *testing.T) {
+		base := baseConfig(t)
+		registry, err := LoadRegistry("", base)
+		require.NoError(t, err)
+
+		require.Len(t, registry.Tenants(), 1)
+		only := registry.Tenants()[0]
+		assert.Equal(t, DefaultID, only.ID)
+		assert.Same(t, base, only.Config, "single tenant keeps the base paths")
+	})
+
+	t.Run("Tenants file", func(t *testing.T) {
+		t.Setenv("A_PASSWORD", "a")
+		t.Setenv("B_PASSWORD", "b")
+		path := filepath.Join(t.TempDir(), "tenants.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{
+			"default": "a",
+			"tenants": [
+				{"id": "a", "backend": {"url": "http://a", "username": "a", "password_env": "A_PASSWORD"}},
+				{"id": "b", "hosts": ["b.example.edu"], "backend": {"url": "http://b", "username": "b", "password_env": "B_PASSWORD"},
+				 "rate_limit": {"per_minute": 0}}
+			]
+		}`), 0644))
+
+		registry, err := LoadRegistry(path, baseConfig(t))
+		require.NoError(t, err)
+		require.Len(t, registry.Tenants(), 2)
+
+		b, ok := registry.Get("b")
+		require.True(t, ok)
+		assert.Equal(t, "http://b", b.Config.NodeJS.BaseURL)
+		assert.Nil(t, b.Limiter(), "rate limit disabled for tenant b")
+
+		a, _ := registry.Get("a")
+		assert.NotNil(t, a.Limiter())
+	})
+
+	t.Run("Invalid tenant configuration", func(t *testing.T) {
+		t.Setenv("A_PASSWORD", "a")
+		path := filepath.Join(t.TempDir(), "tenants.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{
+			"tenants": [{"id": "a", "backend": {"url": "backend:5007", "username": "a", "password_env": "A_PASSWORD"}}]
+		}`), 0644))
+
+		_, err := LoadRegistry(path, baseConfig(t))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "tenant a: invalid configuration")
+		assert.Contains(t, err.Error(), "nodejs.base_url")
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := LoadRegistry(filepath.Join(t.TempDir(), "missing.json"), baseConfig(t))
+		assert.Error(t, err)
+	})
+}
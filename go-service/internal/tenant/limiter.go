@@ -0,0 +1,59 @@
+package tenant
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket allowing a sustained rate of requests per minute
+// with bursts of up to burst requests
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewLimiter creates a full bucket, or returns nil for no limit when
+// perMinute is not positive. A burst below one allows one request at a time
+func NewLimiter(perMinute, burst int) *Limiter {
+	if perMinute <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   float64(perMinute) / 60,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Allow takes a token if one is available. Otherwise it reports how long
+// until the next token. A nil limiter allows every request
+func (l *Limiter) Allow() (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return true, 0
+	}
+	wait := (1 - l.tokens) / l.rate
+	return false, time.Duration(math.Ceil(wait * float64(time.Second)))
+}
@@ -0,0 +1,50 @@
+package tenant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(60, 2)
+	limiter.now = func() time.Time { return now }
+
+	allowed, _ := limiter.Allow()
+	assert.True(t, allowed)
+	allowed, _ = limiter.Allow()
+	assert.True(t, allowed)
+
+	allowed, wait := limiter.Allow()
+	assert.False(t, allowed, "burst exhausted")
+	assert.Equal(t, time.Second, wait)
+
+	now = now.Add(500 * time.Millisecond)
+	allowed, wait = limiter.Allow()
+	assert.False(t, allowed)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	now = now.Add(500 * time.Millisecond)
+	allowed, _ = limiter.Allow()
+	assert.True(t, allowed, "token refilled")
+
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		allowed, _ = limiter.Allow()
+		assert.True(t, allowed, "refill is capped at the burst")
+	}
+	allowed, _ = limiter.Allow()
+	assert.False(t, allowed)
+}
+
+func TestLimiter_Unlimited(t *testing.T) {
+	limiter := NewLimiter(0, 10)
+	assert.Nil(t, limiter)
+
+	for i := 0; i < 100; i++ {
+		allowed, _ := limiter.Allow()
+		assert.True(t, allowed)
+	}
+}
@@ -0,0 +1,143 @@
+package tenant
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	// Header names the tenant of a request
+	Header = "X-Tenant-ID"
+	// PathPrefix precedes the tenant ID in tenant-scoped URLs, e.g. /t/springfield/api/v1/students
+	PathPrefix = "/t/"
+)
+
+var (
+	// ErrUnknownTenant is returned when a request names a tenant that is not configured
+	ErrUnknownTenant = errors.New("unknown tenant")
+	// ErrNoTenant is returned when a request cannot be resolved and there is no default tenant
+	ErrNoTenant = errors.New("no tenant specified")
+	// ErrConflictingTenants is returned when the path, header and host name different tenants
+	ErrConflictingTenants = errors.New("conflicting tenants")
+)
+
+// Registry holds the configured tenants and resolves requests to them
+type Registry struct {
+	tenants  []*Tenant
+	byID     map[string]*Tenant
+	byHost   map[string]*Tenant
+	fallback *Tenant
+}
+
+// NewRegistry indexes the tenants by ID and host. Requests that name no
+// tenant go to defaultID, or are rejected when it is empty
+func NewRegistry(tenants []*Tenant, defaultID string) (*Registry, error) {
+	if len(tenants) == 0 {
+		return nil, fmt.Errorf("at least one tenant is required")
+	}
+
+	r := &Registry{
+		tenants: tenants,
+		byID:    make(map[string]*Tenant, len(tenants)),
+		byHost:  make(map[string]*Tenant),
+	}
+	for _, t := range tenants {
+		if _, exists := r.byID[t.ID]; exists {
+			return nil, fmt.Errorf("duplicate tenant ID %q", t.ID)
+		}
+		r.byID[t.ID] = t
+
+		for _, host := range t.Hosts {
+			host = normalizeHost(host)
+			if other, exists := r.byHost[host]; exists {
+				return nil, fmt.Errorf("host %q is used by tenants %s and %s", host, other.ID, t.ID)
+			}
+			r.byHost[host] = t
+		}
+	}
+
+	if defaultID != "" {
+		fallback, ok := r.byID[defaultID]
+		if !ok {
+			return nil, fmt.Errorf("default tenant %q is not configured", defaultID)
+		}
+		r.fallback = fallback
+	}
+	return r, nil
+}
+
+// Single returns a registry with one default tenant that every request resolves to
+func Single(t *Tenant) *Registry {
+	return &Registry{
+		tenants:  []*Tenant{t},
+		byID:     map[string]*Tenant{t.ID: t},
+		byHost:   map[string]*Tenant{},
+		fallback: t,
+	}
+}
+
+// Tenants returns the tenants in configuration order
+func (r *Registry) Tenants() []*Tenant {
+	return r.tenants
+}
+
+// Get returns a tenant by ID
+func (r *Registry) Get(id string) (*Tenant, bool) {
+	t, ok := r.byID[id]
+	return t, ok
+}
+
+// Resolve finds the tenant of a request from its /t/{id} path prefix, its
+// X-Tenant-ID header and its host. Every source present must name the same
+// tenant; hosts that belong to no tenant are ignored. It returns the request
+// path with any tenant prefix removed
+func (r *Registry) Resolve(req *http.Request) (*Tenant, string, error) {
+	path := req.URL.Path
+	var candidates []*Tenant
+
+	if rest, ok := strings.CutPrefix(path, PathPrefix); ok {
+		id, remainder, _ := strings.Cut(rest, "/")
+		t, ok := r.byID[id]
+		if !ok {
+			return nil, path, fmt.Errorf("%w: %q", ErrUnknownTenant, id)
+		}
+		candidates = append(candidates, t)
+		path = "/" + remainder
+	}
+
+	if id := strings.TrimSpace(req.Header.Get(Header)); id != "" {
+		t, ok := r.byID[strings.ToLower(id)]
+		if !ok {
+			return nil, path, fmt.Errorf("%w: %q", ErrUnknownTenant, id)
+		}
+		candidates = append(candidates, t)
+	}
+
+	if t, ok := r.byHost[normalizeHost(req.Host)]; ok {
+		candidates = append(candidates, t)
+	}
+
+	if len(candidates) == 0 {
+		if r.fallback == nil {
+			return nil, path, ErrNoTenant
+		}
+		return r.fallback, path, nil
+	}
+	for _, t := range candidates[1:] {
+		if t != candidates[0] {
+			return nil, path, fmt.Errorf("%w: %s and %s", ErrConflictingTenants, candidates[0].ID, t.ID)
+		}
+	}
+	return candidates[0], path, nil
+}
+
+// normalizeHost lowercases a host and drops its port
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSuffix(host, "."))
+}
@@ -0,0 +1,116 @@
+package tenant
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"student-report-service/internal/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry(t *testing.T, defaultID string) *Registry {
+	springfield, err := New("springfield", "Springfield Elementary", []string{"reports.springfield.edu"}, &config.Config{})
+	require.NoError(t, err)
+	shelbyville, err := New("shelbyville", "Shelbyville High", []string{"Reports.Shelbyville.edu."}, &config.Config{})
+	require.NoError(t, err)
+
+	registry, err := NewRegistry([]*Tenant{springfield, shelbyville}, defaultID)
+	require.NoError(t, err)
+	return registry
+}
+
+func TestRegistry_Resolve(t *testing.T) {
+	registry := newTestRegistry(t, "springfield")
+
+	tests := []struct {
+		name         string
+		target       string
+		host         string
+		header       string
+		expectedID   string
+		expectedPath string
+		expectedErr  error
+	}{
+		{name: "Fallback to default", target: "/api/v1/students", host: "localhost:8080", expectedID: "springfield", expectedPath: "/api/v1/students"},
+		{name: "Path prefix", target: "/t/shelbyville/api/v1/students", expectedID: "shelbyville", expectedPath: "/api/v1/students"},
+		{name: "Bare path prefix", target: "/t/shelbyville", expectedID: "shelbyville", expectedPath: "/"},
+		{name: "Header", target: "/api/v1/students", header: "Shelbyville", expectedID: "shelbyville", expectedPath: "/api/v1/students"},
+		{name: "Host with port", target: "/health", host: "reports.shelbyville.edu:443", expectedID: "shelbyville", expectedPath: "/health"},
+		{name: "Matching path and header", target: "/t/shelbyville/health", header: "shelbyville", expectedID: "shelbyville", expectedPath: "/health"},
+		{name: "Unknown host ignored", target: "/t/shelbyville/health", host: "example.com", expectedID: "shelbyville", expectedPath: "/health"},
+		{name: "Unknown path tenant", target: "/t/capital-city/health", expectedErr: ErrUnknownTenant},
+		{name: "Unknown header tenant", target: "/health", header: "capital-city", expectedErr: ErrUnknownTenant},
+		{name: "Path conflicts with header", target: "/t/shelbyville/health", header: "springfield", expectedErr: ErrConflictingTenants},
+		{name: "Header conflicts with host", target: "/health", header: "springfield", host: "reports.shelbyville.edu", expectedErr: ErrConflictingTenants},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.target, nil)
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			if tt.header != "" {
+				req.Header.Set(Header, tt.header)
+			}
+
+			resolved, path, err := registry.Resolve(req)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedID, resolved.ID)
+			assert.Equal(t, tt.expectedPath, path)
+		})
+	}
+}
+
+func TestRegistry_ResolveWithoutDefault(t *testing.T) {
+	registry := newTestRegistry(t, "")
+
+	_, _, err := registry.Resolve(httptest.NewRequest("GET", "/api/v1/students", nil))
+	assert.ErrorIs(t, err, ErrNoTenant)
+
+	resolved, _, err := registry.Resolve(httptest.NewRequest("GET", "/t/springfield/api/v1/students", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "springfield", resolved.ID)
+}
+
+func TestNewRegistry_Validation(t *testing.T) {
+	first, err := New("first", "", []string{"reports.example.edu"}, &config.Config{})
+	require.NoError(t, err)
+	second, err := New("second", "", []string{"REPORTS.example.edu:8443"}, &config.Config{})
+	require.NoError(t, err)
+	duplicate, err := New("first", "", nil, &config.Config{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		tenants   []*Tenant
+		defaultID string
+		errorMsg  string
+	}{
+		{name: "No tenants", errorMsg: "at least one tenant"},
+		{name: "Duplicate ID", tenants: []*Tenant{first, duplicate}, errorMsg: "duplicate tenant ID"},
+		{name: "Shared host", tenants: []*Tenant{first, second}, errorMsg: "is used by tenants"},
+		{name: "Unknown default", tenants: []*Tenant{first}, defaultID: "second", errorMsg: "is not configured"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRegistry(tt.tenants, tt.defaultID)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
+
+func TestNew_InvalidID(t *testing.T) {
+	for _, id := range []string{"", "Springfield", "../etc", "-lead", "a/b"} {
+		_, err := New(id, "", nil, &config.Config{})
+		assert.Error(t, err, id)
+	}
+}
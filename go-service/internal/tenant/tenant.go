@@ -0,0 +1,76 @@
+// Package tenant serves several schools from one instance. Each tenant has its
+// own backend, credentials, branding, storage namespace, retention policy and
+// rate limit, and every request is resolved to exactly one tenant
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"student-report-service/internal/config"
+)
+
+// DefaultID is the tenant of a single-tenant deployment
+const DefaultID = "default"
+
+// idPattern keeps tenant IDs safe as path segments and directory names
+var idPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// Tenant is a school served by this instance
+type Tenant struct {
+	ID    string
+	Name  string
+	Hosts []string
+
+	// Config is the tenant's complete configuration, derived from the base
+	// configuration with the tenant's overrides and storage namespace applied
+	Config *config.Config
+
+	limiter *Limiter
+}
+
+// New creates a tenant with a rate limiter built from its configuration
+func New(id, name string, hosts []string, cfg *config.Config) (*Tenant, error) {
+	if !idPattern.MatchString(id) {
+		return nil, fmt.Errorf("invalid tenant ID %q: use lowercase letters, digits and dashes", id)
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("tenant %s: config cannot be nil", id)
+	}
+
+	return &Tenant{
+		ID:      id,
+		Name:    name,
+		Hosts:   hosts,
+		Config:  cfg,
+		limiter: NewLimiter(cfg.Server.RateLimit, cfg.Server.RateLimitBurst),
+	}, nil
+}
+
+// Limiter returns the tenant's rate limiter, nil when requests are unlimited
+func (t *Tenant) Limiter() *Limiter {
+	return t.limiter
+}
+
+type contextKey struct{}
+
+// WithTenant returns a context carrying the tenant
+func WithTenant(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the tenant a request was resolved to, or nil
+func FromContext(ctx context.Context) *Tenant {
+	t, _ := ctx.Value(contextKey{}).(*Tenant)
+	return t
+}
+
+// IDFromContext returns the ID of the request's tenant, DefaultID when the
+// request was not resolved
+func IDFromContext(ctx context.Context) string {
+	if t := FromContext(ctx); t != nil {
+		return t.ID
+	}
+	return DefaultID
+}
@@ -34,7 +34,7 @@ go mod tidy
 
 # Build the service
 echo "🔨 Building the service..."
-go build -o student-report-service cmd/main.go
+go build -o student-report-service ./cmd
 
 # Set environment variables if not set
 export GO_SERVICE_PORT=${GO_SERVICE_PORT:-8080}